@@ -0,0 +1,170 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+)
+
+// DefaultTTL is how long cached EPG data is used before it is downloaded again
+const DefaultTTL = time.Hour
+
+// EPGCache is an on-disk store of EPG events and channel lists, so that repeated queries
+// do not download the guide again and the guide can be browsed while the recorder is asleep
+// There is one JSON file per server and channel:
+//
+//	<Dir>/<server>/channels.json
+//	<Dir>/<server>/epg/<ONID-TSID-SID>.json
+type EPGCache struct {
+	Dir string
+	TTL time.Duration
+
+	// Now returns the current time (time.Now if nil); used to expire entries
+	Now func() time.Time
+}
+
+// EPGEntry is the cached EPG of one channel
+type EPGEntry struct {
+	Channel   string             `json:"channel"` // ONID-TSID-SID
+	FetchedAt time.Time          `json:"fetched_at"`
+	Events    []models.EventInfo `json:"events"`
+}
+
+// ChannelsEntry is the cached channel list of a server
+type ChannelsEntry struct {
+	FetchedAt time.Time            `json:"fetched_at"`
+	Channels  []models.ChannelInfo `json:"channels"`
+}
+
+// DefaultDir returns the default cache directory
+// ($XDG_CACHE_HOME/epgtimer, or the user cache directory of the platform)
+func DefaultDir() (string, error) {
+	if dir := os.Getenv("XDG_CACHE_HOME"); dir != "" {
+		return filepath.Join(dir, "epgtimer"), nil
+	}
+
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate cache directory: %w", err)
+	}
+	return filepath.Join(dir, "epgtimer"), nil
+}
+
+// NewEPGCache creates a cache in dir; a ttl of 0 uses DefaultTTL
+func NewEPGCache(dir string, ttl time.Duration) *EPGCache {
+	if ttl == 0 {
+		ttl = DefaultTTL
+	}
+	return &EPGCache{Dir: dir, TTL: ttl}
+}
+
+// LoadEPG returns the cached EPG of a channel
+// Returns nil and no error if the channel is not cached.
+func (c *EPGCache) LoadEPG(server string, channelID string) (*EPGEntry, error) {
+	var entry EPGEntry
+	found, err := c.load(c.epgPath(server, channelID), &entry)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// StoreEPG caches the EPG of a channel
+func (c *EPGCache) StoreEPG(server string, channelID string, events []models.EventInfo) error {
+	return c.store(c.epgPath(server, channelID), EPGEntry{Channel: channelID, FetchedAt: c.now(), Events: events})
+}
+
+// LoadChannels returns the cached channel list of a server
+// Returns nil and no error if the list is not cached.
+func (c *EPGCache) LoadChannels(server string) (*ChannelsEntry, error) {
+	var entry ChannelsEntry
+	found, err := c.load(filepath.Join(c.serverDir(server), "channels.json"), &entry)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// StoreChannels caches the channel list of a server
+func (c *EPGCache) StoreChannels(server string, channels []models.ChannelInfo) error {
+	return c.store(filepath.Join(c.serverDir(server), "channels.json"), ChannelsEntry{FetchedAt: c.now(), Channels: channels})
+}
+
+// Fresh reports whether data fetched at the given time is younger than the TTL
+func (c *EPGCache) Fresh(fetchedAt time.Time) bool {
+	return c.now().Sub(fetchedAt) < c.TTL
+}
+
+func (c *EPGCache) now() time.Time {
+	if c.Now != nil {
+		return c.Now()
+	}
+	return time.Now()
+}
+
+// serverDir returns the directory of a server; characters that are not allowed in
+// file names on Windows (e.g. the ':' of host:port) are replaced
+func (c *EPGCache) serverDir(server string) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`:/\*?"<>|`, r) {
+			return '_'
+		}
+		return r
+	}, server)
+	return filepath.Join(c.Dir, name)
+}
+
+func (c *EPGCache) epgPath(server string, channelID string) string {
+	return filepath.Join(c.serverDir(server), "epg", channelID+".json")
+}
+
+// load reads a JSON file; found is false if the file does not exist
+func (c *EPGCache) load(path string, v any) (found bool, err error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read cache: %w", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, fmt.Errorf("failed to read cache %s: %w", path, err)
+	}
+	return true, nil
+}
+
+// store writes a JSON file atomically, so that concurrent commands never read a partial file
+func (c *EPGCache) store(path string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	return nil
+}
@@ -1,21 +1,36 @@
 package client
 
 import (
-	"encoding/xml"
+	"context"
 	"fmt"
 
 	"github.com/epy0n0ff/epgtimer-cli/internal/models"
 )
 
 // SetAutoAdd creates a new automatic recording rule via the SetAutoAdd API
-func (c *Client) SetAutoAdd(req *models.AutoAddRuleRequest) (*models.AutoAddRuleResponse, error) {
+func (c *Client) SetAutoAdd(ctx context.Context, req *models.AutoAddRuleRequest) (*models.AutoAddRuleResponse, error) {
+	return c.postAutoAdd(ctx, 0, req)
+}
+
+// UpdateAutoAdd overwrites an existing automatic recording rule via the SetAutoAdd API
+// The request replaces all settings of the rule, so it should be built from the
+// current rule with models.NewAutoAddRuleRequestFromRule
+func (c *Client) UpdateAutoAdd(ctx context.Context, id int, req *models.AutoAddRuleRequest) (*models.AutoAddRuleResponse, error) {
+	if id <= 0 {
+		return nil, newValidationError("invalid rule ID: must be greater than 0")
+	}
+	return c.postAutoAdd(ctx, id, req)
+}
+
+// postAutoAdd sends the rule to SetAutoAdd?id=N (id 0 creates a new rule)
+func (c *Client) postAutoAdd(ctx context.Context, id int, req *models.AutoAddRuleRequest) (*models.AutoAddRuleResponse, error) {
 	// Validate request
 	if err := req.Validate(); err != nil {
-		return nil, fmt.Errorf("validation failed: %w", err)
+		return nil, &ErrValidation{Err: err}
 	}
 
 	// Fetch CSRF token from HTML page
-	ctok, err := c.GetCToken()
+	ctok, err := c.GetCToken(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get CSRF token: %w", err)
 	}
@@ -25,26 +40,11 @@ func (c *Client) SetAutoAdd(req *models.AutoAddRuleRequest) (*models.AutoAddRule
 	formData := req.ToFormData()
 
 	// Send POST request
-	body, err := c.Post("/api/SetAutoAdd?id=0", formData)
+	endpoint := fmt.Sprintf("/api/SetAutoAdd?id=%d", id)
+	body, err := c.Post(ctx, endpoint, formData)
 	if err != nil {
 		return nil, fmt.Errorf("API call failed: %w", err)
 	}
 
-	// Parse XML response
-	var response models.AutoAddRuleResponse
-	if err := xml.Unmarshal(body, &response); err != nil {
-		// If XML parsing fails, show the response body for debugging
-		return nil, fmt.Errorf("failed to parse XML response: %w\nResponse body: %s", err, string(body))
-	}
-
-	// Check if request was successful
-	if !response.IsSuccess() {
-		errMsg := response.GetError()
-		if errMsg == "" {
-			errMsg = "unknown error"
-		}
-		return nil, fmt.Errorf("API returned error: %s", errMsg)
-	}
-
-	return &response, nil
+	return parseSetResponse(body)
 }
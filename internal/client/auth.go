@@ -0,0 +1,298 @@
+package client
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+// WithAuth authenticates every request (including the ctok page) with the given credentials
+// Both Basic and Digest authentication are supported; the scheme is chosen from the
+// server's WWW-Authenticate challenge.
+func WithAuth(username, password string) Option {
+	return func(c *Client) {
+		c.username = username
+		c.password = password
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used for https endpoints
+func WithTLSConfig(config *tls.Config) Option {
+	return func(c *Client) {
+		c.tlsConfig = config
+	}
+}
+
+// LoadTLSConfig builds a TLS configuration that trusts the CA certificate in caCertPath
+// (in addition to the system roots) and optionally skips certificate verification.
+// Returns nil when neither option is set.
+func LoadTLSConfig(caCertPath string, insecureSkipVerify bool) (*tls.Config, error) {
+	if caCertPath == "" && !insecureSkipVerify {
+		return nil, nil
+	}
+
+	config := &tls.Config{
+		InsecureSkipVerify: insecureSkipVerify,
+	}
+
+	if caCertPath != "" {
+		pem, err := os.ReadFile(caCertPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate '%s': %w", caCertPath, err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM certificates found in '%s'", caCertPath)
+		}
+		config.RootCAs = pool
+	}
+
+	return config, nil
+}
+
+// configureTransport applies the TLS and authentication options to the HTTP client
+func (c *Client) configureTransport() {
+	transport := c.HTTPClient.Transport
+
+	if c.tlsConfig != nil {
+		base, ok := transport.(*http.Transport)
+		if !ok || base == nil {
+			base = http.DefaultTransport.(*http.Transport)
+		}
+		base = base.Clone()
+		base.TLSClientConfig = c.tlsConfig
+		transport = base
+	}
+
+	if c.username != "" {
+		transport = &authTransport{
+			Base:     transport,
+			Username: c.username,
+			Password: c.password,
+		}
+	}
+
+	c.HTTPClient.Transport = transport
+}
+
+// authTransport answers Basic and Digest authentication challenges
+// The last challenge is remembered, so later requests are authenticated up front.
+type authTransport struct {
+	Base     http.RoundTripper // nil = http.DefaultTransport
+	Username string
+	Password string
+
+	mu        sync.Mutex
+	challenge *authChallenge
+	nc        int // Digest nonce count for the current nonce
+}
+
+// authChallenge is a parsed WWW-Authenticate challenge
+type authChallenge struct {
+	Scheme string            // "basic" or "digest"
+	Params map[string]string // realm, nonce, qop, opaque, algorithm, ...
+}
+
+func (t *authTransport) base() http.RoundTripper {
+	if t.Base == nil {
+		return http.DefaultTransport
+	}
+	return t.Base
+}
+
+// RoundTrip sends the request, answering a 401 challenge once
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	first := req.Clone(req.Context())
+	if err := t.authorize(first); err != nil {
+		return nil, err
+	}
+
+	resp, err := t.base().RoundTrip(first)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	challenge := parseAuthChallenges(resp.Header.Values("WWW-Authenticate"))
+	if challenge == nil {
+		return resp, nil
+	}
+
+	// The request can only be resent if its body can be recreated
+	retry := req.Clone(req.Context())
+	if req.Body != nil && req.Body != http.NoBody {
+		if req.GetBody == nil {
+			return resp, nil
+		}
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+		retry.Body = body
+	}
+
+	t.mu.Lock()
+	t.challenge = challenge
+	t.nc = 0
+	t.mu.Unlock()
+
+	if err := t.authorize(retry); err != nil {
+		return nil, err
+	}
+
+	resp.Body.Close()
+	return t.base().RoundTrip(retry)
+}
+
+// authorize sets the Authorization header for the remembered challenge, if any
+func (t *authTransport) authorize(req *http.Request) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.challenge == nil {
+		return nil
+	}
+
+	switch t.challenge.Scheme {
+	case "basic":
+		req.SetBasicAuth(t.Username, t.Password)
+	case "digest":
+		t.nc++
+		header, err := digestAuthorization(t.challenge.Params, t.Username, t.Password, req.Method, req.URL.RequestURI(), t.nc)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", header)
+	}
+	return nil
+}
+
+// digestAuthorization computes the Authorization header for a Digest challenge (RFC 7616)
+func digestAuthorization(params map[string]string, username, password, method, uri string, nc int) (string, error) {
+	algorithm := params["algorithm"]
+	if algorithm == "" {
+		algorithm = "MD5"
+	}
+
+	var newHash func() hash.Hash
+	switch strings.TrimSuffix(strings.ToUpper(algorithm), "-SESS") {
+	case "MD5":
+		newHash = md5.New
+	case "SHA-256":
+		newHash = sha256.New
+	default:
+		return "", fmt.Errorf("unsupported digest algorithm '%s'", algorithm)
+	}
+	h := func(s string) string {
+		sum := newHash()
+		sum.Write([]byte(s))
+		return hex.EncodeToString(sum.Sum(nil))
+	}
+
+	realm, nonce := params["realm"], params["nonce"]
+
+	cnonceBytes := make([]byte, 8)
+	if _, err := rand.Read(cnonceBytes); err != nil {
+		return "", fmt.Errorf("failed to generate cnonce: %w", err)
+	}
+	cnonce := hex.EncodeToString(cnonceBytes)
+	ncValue := fmt.Sprintf("%08x", nc)
+
+	ha1 := h(username + ":" + realm + ":" + password)
+	if strings.HasSuffix(strings.ToUpper(algorithm), "-SESS") {
+		ha1 = h(ha1 + ":" + nonce + ":" + cnonce)
+	}
+	ha2 := h(method + ":" + uri)
+
+	// Only qop=auth is supported; servers offering none use the RFC 2069 form
+	qop := ""
+	for _, q := range strings.Split(params["qop"], ",") {
+		if strings.TrimSpace(q) == "auth" {
+			qop = "auth"
+		}
+	}
+
+	var response string
+	if qop != "" {
+		response = h(strings.Join([]string{ha1, nonce, ncValue, cnonce, qop, ha2}, ":"))
+	} else {
+		response = h(ha1 + ":" + nonce + ":" + ha2)
+	}
+
+	header := fmt.Sprintf(`Digest username="%s", realm="%s", nonce="%s", uri="%s", algorithm=%s, response="%s"`,
+		username, realm, nonce, uri, algorithm, response)
+	if qop != "" {
+		header += fmt.Sprintf(`, qop=%s, nc=%s, cnonce="%s"`, qop, ncValue, cnonce)
+	}
+	if opaque, ok := params["opaque"]; ok {
+		header += fmt.Sprintf(`, opaque="%s"`, opaque)
+	}
+
+	return header, nil
+}
+
+// parseAuthChallenges picks the challenge to answer from WWW-Authenticate headers
+// Digest is preferred over Basic; returns nil if neither is offered.
+func parseAuthChallenges(headers []string) *authChallenge {
+	var basic *authChallenge
+	for _, header := range headers {
+		scheme, rest, _ := strings.Cut(strings.TrimSpace(header), " ")
+		switch strings.ToLower(scheme) {
+		case "digest":
+			return &authChallenge{Scheme: "digest", Params: parseAuthParams(rest)}
+		case "basic":
+			basic = &authChallenge{Scheme: "basic", Params: parseAuthParams(rest)}
+		}
+	}
+	return basic
+}
+
+// parseAuthParams parses comma-separated key=value pairs; values may be quoted
+func parseAuthParams(s string) map[string]string {
+	params := make(map[string]string)
+
+	for s = strings.TrimSpace(s); s != ""; s = strings.TrimSpace(s) {
+		key, rest, found := strings.Cut(s, "=")
+		if !found {
+			break
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		rest = strings.TrimSpace(rest)
+
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			// Quoted value, may contain commas and escaped characters
+			var sb strings.Builder
+			i := 1
+			for ; i < len(rest) && rest[i] != '"'; i++ {
+				if rest[i] == '\\' && i+1 < len(rest) {
+					i++
+				}
+				sb.WriteByte(rest[i])
+			}
+			value = sb.String()
+			rest = rest[min(i+1, len(rest)):]
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+			value = strings.TrimSpace(value)
+			rest = "," + rest
+		}
+
+		params[key] = value
+		_, s, _ = strings.Cut(rest, ",")
+	}
+
+	return params
+}
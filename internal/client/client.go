@@ -1,52 +1,105 @@
 package client
 
 import (
+	"context"
+	"crypto/tls"
+	"encoding/xml"
 	"fmt"
 	"io"
 	"net/http"
 	"regexp"
 	"strings"
 	"time"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
 )
 
+// DefaultTimeout is the HTTP timeout used when no other timeout is configured
+const DefaultTimeout = 10 * time.Second
+
 // Client wraps HTTP client for EMWUI API calls
+// Every API method takes a context; cancelling it aborts the in-flight request.
 type Client struct {
 	BaseURL    string
 	HTTPClient *http.Client
+	Retry      RetryPolicy
+
+	// Set by WithAuth and WithTLSConfig, applied to HTTPClient by NewClient
+	username  string
+	password  string
+	tlsConfig *tls.Config
 }
 
-// NewClient creates a new EMWUI API client
-func NewClient(baseURL string) *Client {
-	return &Client{
-		BaseURL: baseURL,
-		HTTPClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
+// RetryPolicy configures retries of idempotent requests (the Enum* APIs and the ctok page)
+// Connection errors, timeouts and 5xx responses are retried; writes are never retried.
+type RetryPolicy struct {
+	MaxRetries     int           // Retries after the first attempt (0 = no retry)
+	InitialBackoff time.Duration // Wait before the first retry, doubled for each further retry
+	MaxBackoff     time.Duration // Upper bound of the wait (0 = unbounded)
+}
+
+// backoff returns the wait before the given retry (1-based)
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	wait := p.InitialBackoff
+	for i := 1; i < retry; i++ {
+		wait *= 2
+		if p.MaxBackoff > 0 && wait >= p.MaxBackoff {
+			break
+		}
+	}
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		wait = p.MaxBackoff
 	}
+	return wait
 }
 
-// GetCToken fetches the CSRF token from EMWUI autoaddepg.html page
-func (c *Client) GetCToken() (string, error) {
-	url := c.BaseURL + "/EMWUI/autoaddepg.html"
+// Option configures a Client created with NewClient
+type Option func(*Client)
 
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+// WithTimeout sets the timeout of each HTTP request (0 disables the timeout)
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.HTTPClient.Timeout = timeout
 	}
+}
 
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("failed to fetch HTML page: %w", err)
+// WithHTTPClient replaces the underlying HTTP client
+// The other options (timeout, authentication, TLS) modify the given client.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.HTTPClient = httpClient
 	}
-	defer resp.Body.Close()
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("failed to fetch HTML page: status %d", resp.StatusCode)
+// WithRetry enables retries with exponential backoff for idempotent requests
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.Retry = policy
+	}
+}
+
+// NewClient creates a new EMWUI API client
+func NewClient(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		BaseURL: baseURL,
+		HTTPClient: &http.Client{
+			Timeout: DefaultTimeout,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(c)
 	}
+	c.configureTransport()
 
-	body, err := io.ReadAll(resp.Body)
+	return c
+}
+
+// GetCToken fetches the CSRF token from EMWUI autoaddepg.html page
+func (c *Client) GetCToken(ctx context.Context) (string, error) {
+	body, err := c.get(ctx, "/EMWUI/autoaddepg.html")
 	if err != nil {
-		return "", fmt.Errorf("failed to read HTML response: %w", err)
+		return "", fmt.Errorf("failed to fetch HTML page: %w", err)
 	}
 
 	// Extract ctok from hidden input field
@@ -55,42 +108,101 @@ func (c *Client) GetCToken() (string, error) {
 	matches := re.FindSubmatch(body)
 
 	if len(matches) < 2 {
-		return "", fmt.Errorf("ctok not found in HTML page")
+		return "", &ErrCTokenMissing{Reason: "ctok not found in HTML page"}
 	}
 
 	ctok := string(matches[1])
 	if ctok == "" {
-		return "", fmt.Errorf("ctok value is empty")
+		return "", &ErrCTokenMissing{Reason: "ctok value is empty"}
 	}
 
 	return ctok, nil
 }
 
 // Post sends a POST request with form data
-func (c *Client) Post(endpoint string, formData string) ([]byte, error) {
-	url := c.BaseURL + endpoint
+func (c *Client) Post(ctx context.Context, endpoint string, formData string) ([]byte, error) {
+	return c.do(ctx, http.MethodPost, endpoint, strings.NewReader(formData))
+}
 
-	req, err := http.NewRequest("POST", url, strings.NewReader(formData))
+// do sends a request to the EMWUI server and returns the body of a 200 response
+// A non-nil body is sent as application/x-www-form-urlencoded form data.
+func (c *Client) do(ctx context.Context, method string, path string, body io.Reader) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
 
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return nil, c.classifyRequestError(ctx, err)
 	}
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+		return nil, &ErrAPI{Status: resp.StatusCode, Body: string(respBody)}
+	}
+
+	return respBody, nil
+}
+
+// get sends an idempotent GET request, retrying transient failures per c.Retry
+func (c *Client) get(ctx context.Context, path string) ([]byte, error) {
+	for retry := 0; ; retry++ {
+		body, err := c.do(ctx, http.MethodGet, path, nil)
+		if err == nil || retry >= c.Retry.MaxRetries || ctx.Err() != nil || !isRetryable(err) {
+			return body, err
+		}
+
+		timer := time.NewTimer(c.Retry.backoff(retry + 1))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+	}
+}
+
+// getXML sends a GET request and decodes the XML response into T
+func getXML[T any](ctx context.Context, c *Client, path string) (*T, error) {
+	body, err := c.get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var response T
+	if err := xml.Unmarshal(body, &response); err != nil {
+		return nil, &ErrUnexpectedResponse{Body: string(body), Err: err}
+	}
+
+	return &response, nil
+}
+
+// parseSetResponse parses the <entry><success>/<err> response of the Set* APIs
+func parseSetResponse(body []byte) (*models.AutoAddRuleResponse, error) {
+	var response models.AutoAddRuleResponse
+	if err := xml.Unmarshal(body, &response); err != nil {
+		// If XML parsing fails, show the response body for debugging
+		return nil, &ErrUnexpectedResponse{Body: string(body), Err: err}
+	}
+
+	// Check if request was successful
+	if !response.IsSuccess() {
+		errMsg := response.GetError()
+		if errMsg == "" {
+			errMsg = "unknown error"
+		}
+		return nil, &ErrServerRejected{Message: errMsg}
 	}
 
-	return body, nil
+	return &response, nil
 }
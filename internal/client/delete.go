@@ -1,7 +1,7 @@
 package client
 
 import (
-	"encoding/xml"
+	"context"
 	"fmt"
 	"net/url"
 
@@ -9,13 +9,13 @@ import (
 )
 
 // DeleteAutoAdd deletes an automatic recording rule via the SetAutoAdd API
-func (c *Client) DeleteAutoAdd(id int) (*models.AutoAddRuleResponse, error) {
+func (c *Client) DeleteAutoAdd(ctx context.Context, id int) (*models.AutoAddRuleResponse, error) {
 	if id <= 0 {
-		return nil, fmt.Errorf("invalid rule ID: must be greater than 0")
+		return nil, newValidationError("invalid rule ID: must be greater than 0")
 	}
 
 	// Fetch CSRF token from HTML page
-	ctok, err := c.GetCToken()
+	ctok, err := c.GetCToken(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get CSRF token: %w", err)
 	}
@@ -27,26 +27,10 @@ func (c *Client) DeleteAutoAdd(id int) (*models.AutoAddRuleResponse, error) {
 
 	// Send POST request with id in query parameter
 	endpoint := fmt.Sprintf("/api/SetAutoAdd?id=%d", id)
-	body, err := c.Post(endpoint, formData.Encode())
+	body, err := c.Post(ctx, endpoint, formData.Encode())
 	if err != nil {
 		return nil, fmt.Errorf("API call failed: %w", err)
 	}
 
-	// Parse XML response
-	var response models.AutoAddRuleResponse
-	if err := xml.Unmarshal(body, &response); err != nil {
-		// If XML parsing fails, show the response body for debugging
-		return nil, fmt.Errorf("failed to parse XML response: %w\nResponse body: %s", err, string(body))
-	}
-
-	// Check if request was successful
-	if !response.IsSuccess() {
-		errMsg := response.GetError()
-		if errMsg == "" {
-			errMsg = "unknown error"
-		}
-		return nil, fmt.Errorf("API returned error: %s", errMsg)
-	}
-
-	return &response, nil
+	return parseSetResponse(body)
 }
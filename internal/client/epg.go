@@ -1,37 +1,96 @@
 package client
 
 import (
-	"encoding/xml"
+	"context"
 	"fmt"
-	"io"
+	"sync"
+	"time"
 
 	"github.com/epy0n0ff/epgtimer-cli/internal/models"
 )
 
 // EnumEventInfo retrieves EPG (Electronic Program Guide) data for a specific channel
-func (c *Client) EnumEventInfo(onid, tsid, sid int) (*models.EnumEventInfoResponse, error) {
-	url := fmt.Sprintf("%s/api/EnumEventInfo?ONID=%d&TSID=%d&SID=%d&basic=0&count=1000",
-		c.BaseURL, onid, tsid, sid)
+func (c *Client) EnumEventInfo(ctx context.Context, onid, tsid, sid int) (*models.EnumEventInfoResponse, error) {
+	path := fmt.Sprintf("/api/EnumEventInfo?ONID=%d&TSID=%d&SID=%d&basic=0&count=1000", onid, tsid, sid)
+	return getXML[models.EnumEventInfoResponse](ctx, c, path)
+}
+
+// BatchOptions controls how requests for many channels are spread over time
+type BatchOptions struct {
+	Concurrency int     // Requests in flight at the same time (values below 1 mean 1)
+	Rate        float64 // Maximum requests started per second (0 = unlimited)
+}
 
-	resp, err := c.HTTPClient.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to EMWUI service: %w", err)
+// ChannelEPG is the EPG of one channel, or the error that prevented retrieving it
+type ChannelEPG struct {
+	Channel *models.ServiceListEntry
+	Events  []models.EventInfo
+	Err     error
+}
+
+// EnumEventInfoChannels retrieves the EPG of several channels with a pool of workers
+// The results are in channel order, whatever order the requests complete in. A failed
+// channel does not stop the others; channels not requested before ctx ended get ctx's error.
+func (c *Client) EnumEventInfoChannels(ctx context.Context, channels []*models.ServiceListEntry, opts BatchOptions) []ChannelEPG {
+	results := make([]ChannelEPG, len(channels))
+	for i, ch := range channels {
+		results[i].Channel = ch
+	}
+	if len(channels) == 0 {
+		return results
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+	var tick <-chan time.Time
+	if opts.Rate > 0 {
+		// An interval that rounds down to 0 means no effective limit
+		if interval := time.Duration(float64(time.Second) / opts.Rate); interval > 0 {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			tick = ticker.C
+		}
 	}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(max(opts.Concurrency, 1), len(channels)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				ch := channels[i]
+				response, err := c.EnumEventInfo(ctx, ch.ONID, ch.TSID, ch.SID)
+				if err != nil {
+					results[i].Err = err
+					continue
+				}
+				results[i].Events = response.Items
+			}
+		}()
 	}
 
-	var response models.EnumEventInfoResponse
-	if err := xml.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse XML response: %w\nResponse body: %s", err, string(body))
+	// Hand out the channels in order, waiting for the rate limiter before each request
+	dispatched := 0
+dispatch:
+	for i := range channels {
+		if tick != nil && i > 0 {
+			select {
+			case <-tick:
+			case <-ctx.Done():
+				break dispatch
+			}
+		}
+		select {
+		case jobs <- i:
+			dispatched++
+		case <-ctx.Done():
+			break dispatch
+		}
 	}
+	close(jobs)
+	wg.Wait()
 
-	return &response, nil
+	for i := dispatched; i < len(results); i++ {
+		results[i].Err = ctx.Err()
+	}
+	return results
 }
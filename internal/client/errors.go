@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+)
+
+// ErrConnection is returned when the EMWUI server cannot be reached
+type ErrConnection struct {
+	URL string // Base URL of the EMWUI server
+	Err error  // Underlying network error
+}
+
+func (e *ErrConnection) Error() string {
+	return fmt.Sprintf("failed to connect to EMWUI service at %s: %v", e.URL, e.Err)
+}
+
+func (e *ErrConnection) Unwrap() error {
+	return e.Err
+}
+
+// ErrTimeout is returned when the EMWUI server does not respond in time
+type ErrTimeout struct {
+	URL string // Base URL of the EMWUI server
+	Err error  // Underlying timeout error
+}
+
+func (e *ErrTimeout) Error() string {
+	return fmt.Sprintf("request to EMWUI service at %s timed out: %v", e.URL, e.Err)
+}
+
+func (e *ErrTimeout) Unwrap() error {
+	return e.Err
+}
+
+// ErrAPI is returned when the EMWUI server responds with a non-200 status
+type ErrAPI struct {
+	Status int    // HTTP status code
+	Body   string // Response body
+}
+
+func (e *ErrAPI) Error() string {
+	return fmt.Sprintf("API returned status %d: %s", e.Status, e.Body)
+}
+
+// ErrServerRejected is returned when a Set* API responds with <err>
+type ErrServerRejected struct {
+	Message string // Error message from the server (AutoAddRuleResponse.Error)
+}
+
+func (e *ErrServerRejected) Error() string {
+	return fmt.Sprintf("API returned error: %s", e.Message)
+}
+
+// ErrCTokenMissing is returned when no CSRF token (ctok) is found in the EMWUI page
+type ErrCTokenMissing struct {
+	Reason string
+}
+
+func (e *ErrCTokenMissing) Error() string {
+	return e.Reason
+}
+
+// ErrUnexpectedResponse is returned when a response cannot be parsed as EMWUI XML,
+// typically because the endpoint is not an EMWUI server
+type ErrUnexpectedResponse struct {
+	Body string // Response body
+	Err  error  // Underlying parse error
+}
+
+func (e *ErrUnexpectedResponse) Error() string {
+	return fmt.Sprintf("failed to parse XML response: %v\nResponse body: %s", e.Err, e.Body)
+}
+
+func (e *ErrUnexpectedResponse) Unwrap() error {
+	return e.Err
+}
+
+// ErrNotFound is returned when an item looked up by ID does not exist
+type ErrNotFound struct {
+	Kind string // Kind of item (e.g., "rule", "reservation")
+	ID   int
+}
+
+func (e *ErrNotFound) Error() string {
+	return fmt.Sprintf("%s ID %d not found", e.Kind, e.ID)
+}
+
+// ErrValidation is returned when a request is rejected before it is sent
+type ErrValidation struct {
+	Err error
+}
+
+func (e *ErrValidation) Error() string {
+	return fmt.Sprintf("validation failed: %v", e.Err)
+}
+
+func (e *ErrValidation) Unwrap() error {
+	return e.Err
+}
+
+// newValidationError creates an ErrValidation from a message
+func newValidationError(format string, args ...any) error {
+	return &ErrValidation{Err: fmt.Errorf(format, args...)}
+}
+
+// classifyRequestError converts an error from http.Client.Do to ErrTimeout or ErrConnection
+// Cancellation by the caller is returned unchanged so that errors.Is(err, context.Canceled) works.
+func (c *Client) classifyRequestError(ctx context.Context, err error) error {
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return err
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return &ErrTimeout{URL: c.BaseURL, Err: err}
+	}
+
+	return &ErrConnection{URL: c.BaseURL, Err: err}
+}
+
+// isRetryable reports whether a failed idempotent request may succeed when retried
+func isRetryable(err error) bool {
+	var connErr *ErrConnection
+	var timeoutErr *ErrTimeout
+	var apiErr *ErrAPI
+
+	switch {
+	case errors.As(err, &connErr), errors.As(err, &timeoutErr):
+		return true
+	case errors.As(err, &apiErr):
+		return apiErr.Status >= 500
+	default:
+		return false
+	}
+}
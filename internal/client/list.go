@@ -1,43 +1,34 @@
 package client
 
 import (
-	"encoding/xml"
-	"fmt"
-	"io"
+	"context"
 
 	"github.com/epy0n0ff/epgtimer-cli/internal/models"
 )
 
 // EnumAutoAdd retrieves all automatic recording rules from EMWUI API
 // GET /api/EnumAutoAdd
-func (c *Client) EnumAutoAdd() (*models.EnumAutoAddResponse, error) {
-	// Build request URL
-	url := c.BaseURL + "/api/EnumAutoAdd"
-
-	// Send GET request
-	resp, err := c.HTTPClient.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to EMWUI service at %s: %w", c.BaseURL, err)
-	}
-	defer resp.Body.Close()
+func (c *Client) EnumAutoAdd(ctx context.Context) (*models.EnumAutoAddResponse, error) {
+	return getXML[models.EnumAutoAddResponse](ctx, c, "/api/EnumAutoAdd")
+}
 
-	// Check status code
-	if resp.StatusCode != 200 {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+// GetAutoAdd retrieves a single automatic recording rule by ID
+// EMWUI has no single-rule endpoint, so this filters the EnumAutoAdd result
+func (c *Client) GetAutoAdd(ctx context.Context, id int) (*models.AutoAddRule, error) {
+	if id <= 0 {
+		return nil, newValidationError("invalid rule ID: must be greater than 0")
 	}
 
-	// Read response body
-	body, err := io.ReadAll(resp.Body)
+	response, err := c.EnumAutoAdd(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, err
 	}
 
-	// Parse XML response
-	var response models.EnumAutoAddResponse
-	if err := xml.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse XML response: %w\nResponse body: %s", err, string(body))
+	for i := range response.Items {
+		if response.Items[i].ID == id {
+			return &response.Items[i], nil
+		}
 	}
 
-	return &response, nil
+	return nil, &ErrNotFound{Kind: "rule", ID: id}
 }
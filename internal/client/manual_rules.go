@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+)
+
+// EnumManuAdd retrieves all program-based automatic recording rules from EMWUI API
+// GET /api/EnumManuAdd
+func (c *Client) EnumManuAdd(ctx context.Context) (*models.EnumManuAddResponse, error) {
+	return getXML[models.EnumManuAddResponse](ctx, c, "/api/EnumManuAdd")
+}
+
+// SetManuAdd creates a new program-based automatic recording rule via the SetManuAdd API
+func (c *Client) SetManuAdd(ctx context.Context, req *models.ManualAutoAddRuleRequest) (*models.AutoAddRuleResponse, error) {
+	// Validate request
+	if err := req.Validate(); err != nil {
+		return nil, &ErrValidation{Err: err}
+	}
+
+	// Fetch CSRF token from HTML page
+	ctok, err := c.GetCToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get CSRF token: %w", err)
+	}
+	req.CToken = ctok
+
+	// id=0 creates a new rule
+	body, err := c.Post(ctx, "/api/SetManuAdd?id=0", req.ToFormData())
+	if err != nil {
+		return nil, fmt.Errorf("API call failed: %w", err)
+	}
+
+	return parseSetResponse(body)
+}
+
+// DeleteManuAdd deletes a program-based automatic recording rule via the SetManuAdd API
+func (c *Client) DeleteManuAdd(ctx context.Context, id int) (*models.AutoAddRuleResponse, error) {
+	if id <= 0 {
+		return nil, newValidationError("invalid rule ID: must be greater than 0")
+	}
+
+	// Fetch CSRF token from HTML page
+	ctok, err := c.GetCToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get CSRF token: %w", err)
+	}
+
+	// Create form data with del=1 and ctok
+	formData := url.Values{}
+	formData.Set("del", "1")
+	formData.Set("ctok", ctok)
+
+	body, err := c.Post(ctx, fmt.Sprintf("/api/SetManuAdd?id=%d", id), formData.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("API call failed: %w", err)
+	}
+
+	return parseSetResponse(body)
+}
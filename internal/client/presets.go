@@ -0,0 +1,13 @@
+package client
+
+import (
+	"context"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+)
+
+// EnumRecPreset retrieves the recording presets from EMWUI API
+// GET /api/EnumRecPreset
+func (c *Client) EnumRecPreset(ctx context.Context) (*models.EnumRecPresetResponse, error) {
+	return getXML[models.EnumRecPresetResponse](ctx, c, "/api/EnumRecPreset")
+}
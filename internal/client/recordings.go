@@ -1,36 +1,84 @@
 package client
 
 import (
-	"encoding/xml"
+	"context"
 	"fmt"
-	"io"
+	"net/url"
 
 	"github.com/epy0n0ff/epgtimer-cli/internal/models"
 )
 
-// EnumRecInfo retrieves all recorded programs from EMWUI
-func (c *Client) EnumRecInfo() (*models.EnumRecInfoResponse, error) {
-	url := c.BaseURL + "/api/EnumRecInfo"
+// EnumRecInfo retrieves the first batch of recorded programs from EMWUI
+func (c *Client) EnumRecInfo(ctx context.Context) (*models.EnumRecInfoResponse, error) {
+	return getXML[models.EnumRecInfoResponse](ctx, c, "/api/EnumRecInfo")
+}
 
-	resp, err := c.HTTPClient.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to EMWUI service: %w", err)
+// recInfoPageSize is the number of recordings EMWUI returns per EnumRecInfo request
+const recInfoPageSize = 200
+
+// EnumAllRecInfo retrieves every recorded program, following EnumRecInfo's pagination
+func (c *Client) EnumAllRecInfo(ctx context.Context) ([]models.RecordingInfo, error) {
+	var recordings []models.RecordingInfo
+
+	for index := 0; ; {
+		response, err := c.enumRecInfoPage(ctx, index, recInfoPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		recordings = append(recordings, response.Items...)
+		index += len(response.Items)
+
+		// Stop on the last page (or when the server ignores paging)
+		if len(response.Items) == 0 || index >= response.Total {
+			break
+		}
+	}
+
+	return recordings, nil
+}
+
+// enumRecInfoPage retrieves one page of recorded programs
+func (c *Client) enumRecInfoPage(ctx context.Context, index, count int) (*models.EnumRecInfoResponse, error) {
+	return getXML[models.EnumRecInfoResponse](ctx, c, fmt.Sprintf("/api/EnumRecInfo?index=%d&count=%d", index, count))
+}
+
+// SetRecProtect protects or unprotects a recorded program via the SetRecInfo API
+func (c *Client) SetRecProtect(ctx context.Context, id int, protect bool) (*models.AutoAddRuleResponse, error) {
+	formData := url.Values{}
+	if protect {
+		formData.Set("protect", "1")
+	} else {
+		formData.Set("protect", "0")
 	}
-	defer resp.Body.Close()
+	return c.postRecInfo(ctx, id, formData)
+}
+
+// DeleteRecInfo deletes a recorded program entry via the SetRecInfo API
+// Whether the recorded file is also deleted depends on EpgTimer's settings
+func (c *Client) DeleteRecInfo(ctx context.Context, id int) (*models.AutoAddRuleResponse, error) {
+	formData := url.Values{}
+	formData.Set("del", "1")
+	return c.postRecInfo(ctx, id, formData)
+}
 
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+// postRecInfo sends form data with a fresh ctok to SetRecInfo?id=N
+func (c *Client) postRecInfo(ctx context.Context, id int, formData url.Values) (*models.AutoAddRuleResponse, error) {
+	if id <= 0 {
+		return nil, newValidationError("invalid recording ID: must be greater than 0")
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	// Fetch CSRF token from HTML page
+	ctok, err := c.GetCToken(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, fmt.Errorf("failed to get CSRF token: %w", err)
 	}
+	formData.Set("ctok", ctok)
 
-	var response models.EnumRecInfoResponse
-	if err := xml.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse XML response: %w\nResponse body: %s", err, string(body))
+	body, err := c.Post(ctx, fmt.Sprintf("/api/SetRecInfo?id=%d", id), formData.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("API call failed: %w", err)
 	}
 
-	return &response, nil
+	return parseSetResponse(body)
 }
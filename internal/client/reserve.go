@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+)
+
+// AddReserve reserves a single EPG event via the SetReserve API
+// The event is identified by ONID/TSID/SID/eventID, as listed by EnumEventInfo
+func (c *Client) AddReserve(ctx context.Context, req *models.ReserveRequest) (*models.AutoAddRuleResponse, error) {
+	// Validate request
+	if err := req.Validate(); err != nil {
+		return nil, &ErrValidation{Err: err}
+	}
+
+	// Fetch CSRF token from HTML page
+	ctok, err := c.GetCToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get CSRF token: %w", err)
+	}
+	req.CToken = ctok
+
+	// id=0 creates a new reservation for the event given in the form data
+	body, err := c.Post(ctx, "/api/SetReserve?id=0", req.ToFormData())
+	if err != nil {
+		return nil, fmt.Errorf("API call failed: %w", err)
+	}
+
+	return parseSetResponse(body)
+}
+
+// GetReserve retrieves a single reservation by ID
+// EMWUI has no single-reservation endpoint, so this filters the EnumReserveInfo result
+func (c *Client) GetReserve(ctx context.Context, id int) (*models.ReservationInfo, error) {
+	if id <= 0 {
+		return nil, newValidationError("invalid reservation ID: must be greater than 0")
+	}
+
+	response, err := c.EnumReserveInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range response.Items {
+		if response.Items[i].ID == id {
+			return &response.Items[i], nil
+		}
+	}
+
+	return nil, &ErrNotFound{Kind: "reservation", ID: id}
+}
+
+// UpdateReserve overwrites the recording settings of an existing reservation via the SetReserve API
+// The request replaces all settings, so it should be built with models.NewReserveRequestFromReservation
+func (c *Client) UpdateReserve(ctx context.Context, id int, req *models.ReserveRequest) (*models.AutoAddRuleResponse, error) {
+	if id <= 0 {
+		return nil, newValidationError("invalid reservation ID: must be greater than 0")
+	}
+
+	// Validate request
+	if err := req.RecSettingRequest.Validate(); err != nil {
+		return nil, &ErrValidation{Err: err}
+	}
+
+	// Fetch CSRF token from HTML page
+	ctok, err := c.GetCToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get CSRF token: %w", err)
+	}
+	req.CToken = ctok
+
+	body, err := c.Post(ctx, fmt.Sprintf("/api/SetReserve?id=%d", id), req.ToFormData())
+	if err != nil {
+		return nil, fmt.Errorf("API call failed: %w", err)
+	}
+
+	return parseSetResponse(body)
+}
+
+// DeleteReserve deletes a reservation via the SetReserve API
+func (c *Client) DeleteReserve(ctx context.Context, id int) (*models.AutoAddRuleResponse, error) {
+	if id <= 0 {
+		return nil, newValidationError("invalid reservation ID: must be greater than 0")
+	}
+
+	// Fetch CSRF token from HTML page
+	ctok, err := c.GetCToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get CSRF token: %w", err)
+	}
+
+	// Create form data with del=1 and ctok
+	formData := url.Values{}
+	formData.Set("del", "1")
+	formData.Set("ctok", ctok)
+
+	body, err := c.Post(ctx, fmt.Sprintf("/api/SetReserve?id=%d", id), formData.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("API call failed: %w", err)
+	}
+
+	return parseSetResponse(body)
+}
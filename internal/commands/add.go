@@ -1,9 +1,7 @@
 package commands
 
 import (
-	"bufio"
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/epy0n0ff/epgtimer-cli/internal/client"
@@ -16,6 +14,7 @@ var (
 	notKey          string
 	serviceList     []string
 	serviceListFile string
+	channelRefs     []string
 )
 
 // addCmd represents the add command
@@ -31,14 +30,56 @@ Example:
   epgtimer add --andKey "ニュース" --serviceList "32736-32736-1024"
   epgtimer add --andKey "ドラマ" --notKey "再放送" --serviceList "32736-32736-1024,32736-32736-1025"
   epgtimer add --andKey "映画" --serviceListFile channels.txt
-
-Channel format: ONID-TSID-SID (e.g., "32736-32736-1024" for NHK総合)
-
-serviceListFile format (one channel per line):
+  epgtimer add --andKey "アニメ" --channels @bs
+
+  # Weeknight dramas between 21:00 and 23:30, at least 45 minutes, high priority
+  epgtimer add --andKey "ドラマ" --serviceList "32736-32736-1024" \
+    --days weekdays --start-time 21:00 --end-time 23:30 --duration-min 45 --priority 4
+
+  # Regular expression over title and description, with custom margins
+  epgtimer add --andKey "^(映画|シネマ)" --regex --full-text --serviceList "32736-32736-1024" \
+    --start-margin 30 --end-margin 60
+
+  # Use the recording folder and margins of the "Kids" preset
+  epgtimer add --andKey "アニメ" --channels @terrestrial --preset Kids
+
+  # Record into two folders, naming files with the RecName_Macro plugin
+  epgtimer add --andKey "ドラマ" --serviceList "32736-32736-1024" \
+    --rec-folder 'D:\Recorded\Drama:Write_Default.dll:RecName_Macro.dll?$Title$.ts' \
+    --rec-folder 'E:\Backup'
+
+  # Check which programs the rule would record before creating it
+  epgtimer add --andKey "ドラマ" --notKey "再放送" --channels @terrestrial --dry-run
+
+Search options:
+  --regex, --case-sensitive, --fuzzy   Keyword matching mode
+  --full-text                          Also search descriptions (default: title only)
+  --days, --start-time, --end-time     Day-of-week and time-of-day window
+  --duration-min, --duration-max       Program length limits in minutes
+  --free-ca-only                       Only free-to-air programs
+
+Preview:
+  --dry-run                            List the programs the rule would record in the
+                                       current EPG instead of creating the rule (genre
+                                       filters are not evaluated)
+
+Recording options:
+  --preset                             Start from a recording preset (name or ID,
+                                       see "epgtimer presets"); other options override it
+  --priority, --rec-mode, --tuner      Priority (1-5), recording mode, tuner ID
+  --start-margin, --end-margin         Custom margins in seconds
+  --suspend-mode, --bat-file           Post-recording action and batch file
+  --rec-folder, --partial-rec-folder   Recording folders as PATH[:writePlugin[:namePlugin]]
+                                       (repeatable; default: EpgTimer's default folder)
+
+Channel format: ONID-TSID-SID (e.g., "32736-32736-1024" for NHK総合), key:N,
+a channel name or a channel group (e.g., "@bs", see "epgtimer channels export-group")
+
+serviceListFile format (one channel per line, # starts a comment):
   # Tokyo channels
-  32736-32736-1024
+  32736-32736-1024  # NHK総合
   32736-32736-1025
-  32737-32737-1032`,
+  @bs`,
 	RunE: runAddCommand,
 }
 
@@ -48,8 +89,18 @@ func init() {
 	// Define flags
 	addCmd.Flags().StringVar(&andKey, "andKey", "", "Search keywords (required, title must contain these keywords)")
 	addCmd.Flags().StringVar(&notKey, "notKey", "", "Exclusion keywords (optional, title must not contain these keywords)")
-	addCmd.Flags().StringSliceVar(&serviceList, "serviceList", []string{}, "Channel list in ONID-TSID-SID format (comma-separated)")
-	addCmd.Flags().StringVar(&serviceListFile, "serviceListFile", "", "File containing channel list (one channel per line in ONID-TSID-SID format)")
+	addCmd.Flags().StringSliceVar(&serviceList, "serviceList", []string{}, "Channel list: ONID-TSID-SID, key:N or channel name (comma-separated, default: serviceList of the config profile)")
+	addCmd.Flags().StringVar(&serviceListFile, "serviceListFile", "", "File containing channel list (one channel per line, # starts a comment)")
+	addCmd.Flags().StringSliceVar(&channelRefs, "channels", []string{}, "Channels or channel groups, added to --serviceList (comma-separated, e.g., @bs,key:1)")
+
+	// Search and recording option flags (shared with edit)
+	addSearchSettingFlags(addCmd)
+	addRecSettingFlags(addCmd)
+	addCmd.Flags().String("preset", "", "Recording preset to start from (name or ID, see 'epgtimer presets')")
+
+	// Preview flags; the EPG retrieval and cache flags are shared with epg
+	addCmd.Flags().Bool("dry-run", false, "List the programs in the current EPG that the rule would record, without creating it")
+	addEPGSourceFlags(addCmd)
 
 	// Mark required flags - andKey is always required, serviceList or serviceListFile must be provided
 	addCmd.MarkFlagRequired("andKey")
@@ -67,56 +118,75 @@ func runAddCommand(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("--andKey cannot be empty")
 	}
 
+	// Channels and groups given to --channels
+	serviceList = append(serviceList, channelRefs...)
+
 	// Read serviceList from file if specified
 	if serviceListFile != "" {
-		channels, err := readServiceListFromFile(serviceListFile)
+		channels, err := models.ReadChannelFile(serviceListFile)
 		if err != nil {
 			return fmt.Errorf("failed to read serviceListFile: %w", err)
 		}
 		serviceList = append(serviceList, channels...)
 	}
 
+	// Fall back to the default channels of the config profile
+	if len(serviceList) == 0 && !cmd.Flags().Changed("serviceList") && !cmd.Flags().Changed("channels") && serviceListFile == "" {
+		serviceList = profile.ServiceList
+	}
+
 	// Validate serviceList
 	if len(serviceList) == 0 {
-		return fmt.Errorf("--serviceList or --serviceListFile must contain at least one channel")
+		return fmt.Errorf("--serviceList, --channels or --serviceListFile must contain at least one channel (or set serviceList in the config profile)")
 	}
 
-	// Validate serviceList format
-	for i, service := range serviceList {
-		if _, err := models.ParseServiceListEntry(service); err != nil {
-			return fmt.Errorf("invalid channel format in --serviceList[%d]: %w\n\nExpected format: ONID-TSID-SID (e.g., \"32736-32736-1024\")", i, err)
-		}
+	// Create client
+	c, err := newClient(cmd, endpoint)
+	if err != nil {
+		return err
+	}
+
+	// Resolve channel names, key:N references and channel groups to channel IDs
+	serviceList, err = resolveChannelRefs(cmd, c, endpoint, serviceList)
+	if err != nil {
+		return fmt.Errorf("invalid channel in --serviceList: %w", err)
 	}
 
 	// Create request
 	req := models.NewAutoAddRuleRequest(andKey, notKey, serviceList)
 
-	// Create client
-	c := client.NewClient(endpoint)
-
-	// Call API
-	_, err = c.SetAutoAdd(req)
-	if err != nil {
-		// Provide helpful error messages based on error type
-		errMsg := err.Error()
-
-		if strings.Contains(errMsg, "connection refused") {
-			return fmt.Errorf("connection failed: %w\n\nPlease check:\n  1. EMWUI service is running\n  2. EMWUI_ENDPOINT is correct (current: %s)\n  3. Network connectivity", err, endpoint)
-		}
+	// Apply search and recording options
+	if err := applySearchSettingFlags(cmd, req); err != nil {
+		return err
+	}
 
-		if strings.Contains(errMsg, "timeout") {
-			return fmt.Errorf("connection timeout: %w\n\nEMWUI server did not respond in time (current endpoint: %s)", err, endpoint)
+	// A preset replaces the default recording settings; explicit flags override it.
+	// Its settings are copied and posted as custom settings (presetID 65535), since
+	// posting the preset's ID would make EpgTimer ignore the overrides.
+	var preset *models.RecPreset
+	if ref, _ := cmd.Flags().GetString("preset"); ref != "" {
+		preset, err = resolveRecPreset(cmd, c, endpoint, ref)
+		if err != nil {
+			return err
 		}
+		req.RecSettingRequest = models.NewRecSettingRequestFromRecordingSettings(&preset.RecordingSettings)
+	}
+	if err := applyRecSettingFlags(cmd, &req.RecSettingRequest); err != nil {
+		return err
+	}
 
-		if strings.Contains(errMsg, "validation failed") {
-			return fmt.Errorf("validation error: %w", err)
-		}
+	if err := req.Validate(); err != nil {
+		return fmt.Errorf("validation error: %w", err)
+	}
 
-		if strings.Contains(errMsg, "HTML response") {
-			return fmt.Errorf("unexpected response: %w\n\nPossible causes:\n  1. Incorrect endpoint URL\n  2. API path has changed\n  3. EMWUI version incompatibility", err)
-		}
+	if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+		return previewAutoAddRequest(cmd, c, endpoint, req)
+	}
 
-		return fmt.Errorf("failed to add recording rule: %w", err)
+	// Call API
+	_, err = c.SetAutoAdd(cmd.Context(), req)
+	if err != nil {
+		return apiError(err, endpoint, "add recording rule")
 	}
 
 	// Success
@@ -131,48 +201,43 @@ func runAddCommand(cmd *cobra.Command, args []string) error {
 	} else {
 		fmt.Printf("  %s, ... (%d more)\n", strings.Join(serviceList[:10], ", "), len(serviceList)-10)
 	}
+	if preset != nil {
+		fmt.Printf("Recording preset: %s (ID %d)\n", preset.Name, preset.ID)
+	}
+	if len(req.RecFolders) > 0 {
+		fmt.Printf("Recording folders: %s\n", req.RecFolders.String())
+	}
 
 	return nil
 }
 
-// readServiceListFromFile reads channel list from a file
-// File format: one channel per line in ONID-TSID-SID format
-// Empty lines and lines starting with # are ignored
-func readServiceListFromFile(filename string) ([]string, error) {
-	file, err := os.Open(filename)
+// previewAutoAddRequest lists the programs of the current EPG that the rule of the request would record
+func previewAutoAddRequest(cmd *cobra.Command, c *client.Client, endpoint string, req *models.AutoAddRuleRequest) error {
+	settings, err := req.SearchSettings()
 	if err != nil {
-		return nil, fmt.Errorf("failed to open file: %w", err)
+		return fmt.Errorf("validation error: %w", err)
 	}
-	defer file.Close()
-
-	var channels []string
-	scanner := bufio.NewScanner(file)
-	lineNum := 0
-
-	for scanner.Scan() {
-		lineNum++
-		line := strings.TrimSpace(scanner.Text())
-
-		// Skip empty lines and comments
-		if line == "" || strings.HasPrefix(line, "#") {
-			continue
-		}
 
-		// Validate format
-		if _, err := models.ParseServiceListEntry(line); err != nil {
-			return nil, fmt.Errorf("invalid format at line %d: %w", lineNum, err)
-		}
-
-		channels = append(channels, line)
+	epg, err := fetchPreviewEPG(cmd, c, endpoint, []*models.SearchSettings{settings})
+	if err != nil {
+		return err
+	}
+	matched, err := epg.matches(settings)
+	if err != nil {
+		return fmt.Errorf("validation error: %w", err)
 	}
 
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading file: %w", err)
+	fmt.Printf("Search keywords: %s\n", req.AndKey)
+	if req.NotKey != "" {
+		fmt.Printf("Exclusion keywords: %s\n", req.NotKey)
 	}
+	fmt.Printf("Channels: %d channels\n\n", len(req.ServiceList))
 
-	if len(channels) == 0 {
-		return nil, fmt.Errorf("file contains no valid channels")
+	fmt.Print("Programs the rule would record in the current EPG:\n\n")
+	if err := printRulePreview(matched); err != nil {
+		return err
 	}
 
-	return channels, nil
+	fmt.Println("\nDry run: the rule was not created.")
+	return epgChannelsError(epg.failed, epg.channels)
 }
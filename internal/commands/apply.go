@@ -0,0 +1,215 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// applyCmd represents the apply command
+var applyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Sync automatic recording rules from a manifest file",
+	Long: `Make the automatic recording rules on the server match a YAML or JSON manifest.
+
+Rules in the manifest are matched to existing rules by 'id' when given, otherwise
+by andKey and channel set. Missing rules are created, changed rules are updated in
+place (SetAutoAdd?id=N), and with --prune rules not in the manifest are deleted.
+Settings the manifest cannot express (e.g. recording folders) are left unchanged.
+Channels in serviceList can be given as ONID-TSID-SID, key:N, channel names or
+channel groups (@name), as with 'epgtimer add'.
+
+Before pruning, you are asked for confirmation (use --yes to skip the prompt) and
+the rules to delete are saved to a JSON backup (deleted-rules-YYYYMMDD-HHMMSS.json
+in the current directory, or --backup-file). Restore them with 'epgtimer import'.
+
+Manifest format (YAML):
+  rules:
+    - andKey: ニュース
+      serviceList: [32736-32736-1024]
+    - andKey: ドラマ
+      notKey: 再放送
+      serviceList: [NHK総合, key:2, "@bs"]
+      days: [weekdays]
+      startTime: "21:00"
+      endTime: "23:30"
+      priority: 4
+      startMargin: 30
+
+Example:
+  # Show what would change
+  epgtimer apply -f rules.yaml --dry-run
+
+  # Create and update rules
+  epgtimer apply -f rules.yaml
+
+  # Also delete rules that are not in the manifest
+  epgtimer apply -f rules.yaml --prune
+
+  # Prune without prompting, e.g. from a script
+  epgtimer apply -f rules.yaml --prune --yes`,
+	RunE: runApplyCommand,
+}
+
+func init() {
+	rootCmd.AddCommand(applyCmd)
+
+	// Define flags
+	applyCmd.Flags().StringP("file", "f", "", "Manifest file (YAML or JSON)")
+	applyCmd.Flags().Bool("prune", false, "Delete rules that are not in the manifest")
+	applyCmd.Flags().Bool("dry-run", false, "Print the plan without changing anything")
+	applyCmd.Flags().String("backup-file", "", "File for the JSON backup of the pruned rules (default: deleted-rules-YYYYMMDD-HHMMSS.json)")
+	applyCmd.Flags().BoolP("yes", "y", false, "Prune without asking for confirmation")
+
+	applyCmd.MarkFlagRequired("file")
+}
+
+func runApplyCommand(cmd *cobra.Command, args []string) error {
+	// Get EMWUI endpoint
+	endpoint, err := GetEMWUIEndpoint(cmd)
+	if err != nil {
+		return fmt.Errorf("configuration error: %w\n\nPlease set EMWUI_ENDPOINT environment variable:\n  export EMWUI_ENDPOINT=http://localhost:5510\n\nOr use --endpoint flag:\n  epgtimer apply --endpoint http://localhost:5510 -f rules.yaml", err)
+	}
+
+	file, _ := cmd.Flags().GetString("file")
+	prune, _ := cmd.Flags().GetBool("prune")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	manifest, err := models.ParseManifest(file)
+	if err != nil {
+		return fmt.Errorf("invalid manifest: %w", err)
+	}
+
+	// Create client
+	c, err := newClient(cmd, endpoint)
+	if err != nil {
+		return err
+	}
+
+	// Resolve channel names, key:N and groups in serviceList before validating the rules
+	if manifest.NeedsChannelLookup() {
+		services, err := c.EnumService(cmd.Context())
+		if err != nil {
+			return formatConnectionError(err, endpoint)
+		}
+		resolver := &models.ChannelResolver{Channels: services.Items, Groups: channelGroups}
+		if err := manifest.ResolveChannels(resolver); err != nil {
+			return fmt.Errorf("invalid manifest: %w", err)
+		}
+	}
+	if err := manifest.Validate(); err != nil {
+		return fmt.Errorf("invalid manifest: %w", err)
+	}
+
+	// Fetch current rules
+	response, err := c.EnumAutoAdd(cmd.Context())
+	if err != nil {
+		return formatConnectionError(err, endpoint)
+	}
+
+	plan, err := models.PlanManifest(manifest, response.Items, prune)
+	if err != nil {
+		return fmt.Errorf("failed to plan changes: %w", err)
+	}
+
+	printApplyPlan(plan)
+
+	if plan.IsEmpty() {
+		fmt.Println("\nServer already matches the manifest.")
+		return nil
+	}
+
+	if dryRun {
+		fmt.Println("\nDry run: no changes were made.")
+		return nil
+	}
+
+	if len(plan.Deletes) > 0 {
+		yes, _ := cmd.Flags().GetBool("yes")
+		if !yes {
+			ok, err := confirm(cmd.InOrStdin(), fmt.Sprintf("\nDelete %d rules that are not in the manifest?", len(plan.Deletes)))
+			if err != nil {
+				return err
+			}
+			if !ok {
+				fmt.Println("Cancelled.")
+				return nil
+			}
+		}
+
+		// Back up the pruned rules before changing anything
+		backupPath, _ := cmd.Flags().GetString("backup-file")
+		if backupPath == "" {
+			backupPath = fmt.Sprintf("deleted-rules-%s.json", time.Now().Format("20060102-150405"))
+		}
+		if err := models.WriteAutoAddRuleBackup(backupPath, plan.Deletes); err != nil {
+			return fmt.Errorf("failed to write backup to '%s': %w\n\nNo changes were made.", backupPath, err)
+		}
+		fmt.Printf("\nBacked up %d rules to %s (restore with: epgtimer import %s)\n", len(plan.Deletes), backupPath, backupPath)
+	}
+
+	fmt.Println()
+
+	// Apply changes, continuing past failures so one bad rule does not block the rest
+	failed := 0
+	for _, req := range plan.Creates {
+		if _, err := c.SetAutoAdd(cmd.Context(), req); err != nil {
+			fmt.Printf("✗ Failed to create %q: %v\n", req.AndKey, err)
+			failed++
+			continue
+		}
+		fmt.Printf("✓ Created %q\n", req.AndKey)
+	}
+
+	for _, update := range plan.Updates {
+		if _, err := c.UpdateAutoAdd(cmd.Context(), update.ID, update.Request); err != nil {
+			fmt.Printf("✗ Failed to update ID %d %q: %v\n", update.ID, update.Request.AndKey, err)
+			failed++
+			continue
+		}
+		fmt.Printf("✓ Updated ID %d %q\n", update.ID, update.Request.AndKey)
+	}
+
+	for _, rule := range plan.Deletes {
+		if _, err := c.DeleteAutoAdd(cmd.Context(), rule.ID); err != nil {
+			fmt.Printf("✗ Failed to delete ID %d %q: %v\n", rule.ID, rule.SearchSettings.AndKey, err)
+			failed++
+			continue
+		}
+		fmt.Printf("✓ Deleted ID %d %q\n", rule.ID, rule.SearchSettings.AndKey)
+	}
+
+	if failed > 0 {
+		total := len(plan.Creates) + len(plan.Updates) + len(plan.Deletes)
+		return fmt.Errorf("%d of %d changes failed", failed, total)
+	}
+
+	return nil
+}
+
+// printApplyPlan prints the planned creates, updates and deletes
+func printApplyPlan(plan *models.ManifestPlan) {
+	fmt.Printf("Plan: %d to create, %d to update, %d to delete (%d unchanged)\n",
+		len(plan.Creates), len(plan.Updates), len(plan.Deletes), plan.Unchanged)
+
+	if !plan.IsEmpty() {
+		fmt.Println()
+	}
+
+	for _, req := range plan.Creates {
+		fmt.Printf("  + create  %q (%d channels)\n", req.AndKey, len(req.ServiceList))
+	}
+
+	for _, update := range plan.Updates {
+		fmt.Printf("  ~ update  ID %d %q\n", update.ID, update.Request.AndKey)
+		for _, change := range update.Changes {
+			fmt.Printf("        %s\n", change)
+		}
+	}
+
+	for _, rule := range plan.Deletes {
+		fmt.Printf("  - delete  ID %d %q\n", rule.ID, rule.SearchSettings.AndKey)
+	}
+}
@@ -0,0 +1,178 @@
+package commands
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/client"
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// resolveChannelRefs converts channel names, key:N references and channel groups (@name)
+// to channel IDs (ONID-TSID-SID)
+// The channel list is only fetched from the server if some reference is not a channel ID.
+func resolveChannelRefs(cmd *cobra.Command, c *client.Client, endpoint string, refs []string) ([]string, error) {
+	needsLookup := false
+	for _, ref := range refs {
+		if !models.IsChannelID(ref) {
+			needsLookup = true
+		}
+	}
+	if !needsLookup {
+		return refs, nil
+	}
+
+	response, err := c.EnumService(cmd.Context())
+	if err != nil {
+		return nil, formatConnectionError(err, endpoint)
+	}
+
+	resolver := &models.ChannelResolver{Channels: response.Items, Groups: channelGroups}
+	return resolver.ResolveAll(refs)
+}
+
+// resolveChannelRef converts a channel name or key:N reference to a single channel ID
+// Channel groups are rejected, since the caller expects exactly one channel.
+func resolveChannelRef(cmd *cobra.Command, c *client.Client, endpoint string, ref string) (string, error) {
+	if models.IsChannelID(ref) {
+		return ref, nil
+	}
+
+	response, err := c.EnumService(cmd.Context())
+	if err != nil {
+		return "", formatConnectionError(err, endpoint)
+	}
+
+	resolver := &models.ChannelResolver{Channels: response.Items, Groups: channelGroups}
+	return resolver.Resolve(ref)
+}
+
+// resolveChannelFlag replaces a channel name or key:N given to --channel, and the channel
+// references and groups given to --channels (if the command has it), by their channel IDs,
+// so that the filters can compare channel IDs
+func resolveChannelFlag(cmd *cobra.Command, endpoint string) error {
+	channel, _ := cmd.Flags().GetString("channel")
+	channels, _ := cmd.Flags().GetStringSlice("channels")
+
+	needsLookup := channel != "" && !models.IsChannelID(channel)
+	for _, ref := range channels {
+		if !models.IsChannelID(ref) {
+			needsLookup = true
+		}
+	}
+	if !needsLookup {
+		return nil
+	}
+
+	c, err := newClient(cmd, endpoint)
+	if err != nil {
+		return err
+	}
+
+	if channel != "" {
+		id, err := resolveChannelRef(cmd, c, endpoint, channel)
+		if err != nil {
+			return err
+		}
+		if err := cmd.Flags().Set("channel", id); err != nil {
+			return err
+		}
+	}
+
+	if len(channels) > 0 {
+		ids, err := resolveChannelRefs(cmd, c, endpoint, channels)
+		if err != nil {
+			return fmt.Errorf("invalid channel in --channels: %w", err)
+		}
+		if err := cmd.Flags().Lookup("channels").Value.(pflag.SliceValue).Replace(ids); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// channelSelection holds the channel IDs selected by --channel and --channels on one server
+type channelSelection struct {
+	channel  string   // Channel ID from --channel ("" = any channel)
+	channels []string // Channel IDs from --channels (empty = any channel)
+}
+
+// channelSelections maps the server label of fetched items ("" with a single server)
+// to the channels selected on that server
+type channelSelections map[string]channelSelection
+
+// resolveChannelSelections resolves a channel name or key:N given to --channel, and the
+// channel references and groups given to --channels (if the command has it), against the
+// channel list of every server, since the IDs of terrestrial channels differ between regions
+// A server's channel list is only fetched if some reference is not a channel ID.
+func resolveChannelSelections(cmd *cobra.Command, endpoints []string) (channelSelections, error) {
+	channel, _ := cmd.Flags().GetString("channel")
+	channels, _ := cmd.Flags().GetStringSlice("channels")
+
+	needsLookup := channel != "" && !models.IsChannelID(channel)
+	for _, ref := range channels {
+		if !models.IsChannelID(ref) {
+			needsLookup = true
+		}
+	}
+
+	selections := make(channelSelections, len(endpoints))
+	for _, endpoint := range endpoints {
+		label := ""
+		if len(endpoints) > 1 {
+			label = serverName(endpoint)
+		}
+
+		if !needsLookup {
+			selections[label] = channelSelection{channel: channel, channels: channels}
+			continue
+		}
+
+		c, err := newClient(cmd, endpoint)
+		if err != nil {
+			return nil, err
+		}
+		response, err := c.EnumService(cmd.Context())
+		if err != nil {
+			return nil, formatConnectionError(err, endpoint)
+		}
+		resolver := &models.ChannelResolver{Channels: response.Items, Groups: channelGroups}
+
+		var sel channelSelection
+		if channel != "" {
+			if sel.channel, err = resolver.Resolve(channel); err != nil {
+				return nil, serverChannelError(label, err)
+			}
+		}
+		if len(channels) > 0 {
+			if sel.channels, err = resolver.ResolveAll(channels); err != nil {
+				return nil, serverChannelError(label, fmt.Errorf("invalid channel in --channels: %w", err))
+			}
+		}
+		selections[label] = sel
+	}
+	return selections, nil
+}
+
+// serverChannelError names the server whose channel list could not resolve a reference
+func serverChannelError(label string, err error) error {
+	if label == "" {
+		return err
+	}
+	return fmt.Errorf("%s: %w", label, err)
+}
+
+// matches returns true if a channel is selected on the server of an item
+func (s channelSelections) matches(server string, channelID string) bool {
+	sel := s[server]
+	if sel.channel != "" && channelID != sel.channel {
+		return false
+	}
+	if len(sel.channels) > 0 && !slices.Contains(sel.channels, channelID) {
+		return false
+	}
+	return true
+}
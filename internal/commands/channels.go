@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -37,6 +38,9 @@ Examples:
   # List channels from a specific EMWUI server
   epgtimer channels --endpoint http://192.168.1.10:5510
 
+  # Compare channels of two servers (adds a Server column)
+  epgtimer channels --endpoint http://192.168.1.10:5510,http://192.168.1.20:5510
+
   # Show only TV channels
   epgtimer channels --tv
 
@@ -69,40 +73,37 @@ func init() {
 }
 
 func runChannels(cmd *cobra.Command, args []string) error {
-	// Get EMWUI endpoint
-	endpoint, err := cmd.Flags().GetString("endpoint")
+	// Get EMWUI endpoints from flag, environment variables or config profile
+	endpoints, err := GetEMWUIEndpoints(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to get endpoint flag: %w", err)
-	}
-
-	if endpoint == "" {
-		endpoint = os.Getenv("EMWUI_ENDPOINT")
+		return fmt.Errorf("EMWUI endpoint not configured\n\nPlease set the endpoint using:\n  1. --endpoint flag: epgtimer channels --endpoint http://192.168.1.10:5510\n  2. EMWUI_ENDPOINT environment variable: export EMWUI_ENDPOINT=http://192.168.1.10:5510\n  3. a profile in ~/.config/epgtimer/config.yaml: epgtimer channels --profile living-room")
 	}
 
-	if endpoint == "" {
-		return fmt.Errorf("EMWUI endpoint not configured\n\nPlease set the endpoint using:\n  1. --endpoint flag: epgtimer channels --endpoint http://192.168.1.10:5510\n  2. EMWUI_ENDPOINT environment variable: export EMWUI_ENDPOINT=http://192.168.1.10:5510")
-	}
-
-	// Create API client
-	apiClient := client.NewClient(endpoint)
-
-	// Retrieve channels
-	response, err := apiClient.EnumService()
+	// Retrieve channels from every server
+	items, failed, err := fetchFromServers(cmd, endpoints,
+		func(ctx context.Context, c *client.Client) ([]models.ChannelInfo, error) {
+			response, err := c.EnumService(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return response.Items, nil
+		},
+		func(ch *models.ChannelInfo, server string) { ch.Server = server })
 	if err != nil {
-		return formatConnectionError(err, endpoint)
+		return err
 	}
 
 	// Apply filters
-	filteredChannels := applyChannelFilters(cmd, response.Items)
+	filteredChannels := applyChannelFilters(cmd, items)
 
 	// Handle empty results
 	if len(filteredChannels) == 0 {
 		fmt.Println("No channels match the specified filters.")
-		return nil
+		return serversError(failed, len(endpoints))
 	}
 
 	// Get format flag
-	format, _ := cmd.Flags().GetString("format")
+	format := outputFormat(cmd)
 
 	// Format channels
 	var output string
@@ -138,7 +139,7 @@ func runChannels(cmd *cobra.Command, args []string) error {
 		fmt.Print(output)
 	}
 
-	return nil
+	return serversError(failed, len(endpoints))
 }
 
 func applyChannelFilters(cmd *cobra.Command, channels []models.ChannelInfo) []models.ChannelInfo {
@@ -191,8 +192,11 @@ func formatChannelsAsJSON(channels []models.ChannelInfo) string {
 	var sb strings.Builder
 	sb.WriteString("[\n")
 	for i, ch := range channels {
-		sb.WriteString(fmt.Sprintf(`  {
-    "channel_id": "%s",
+		sb.WriteString("  {\n")
+		if ch.Server != "" {
+			sb.WriteString(fmt.Sprintf("    \"server\": \"%s\",\n", escapeJSON(ch.Server)))
+		}
+		sb.WriteString(fmt.Sprintf(`    "channel_id": "%s",
     "onid": %d,
     "tsid": %d,
     "sid": %d,
@@ -229,8 +233,15 @@ func formatChannelsAsJSON(channels []models.ChannelInfo) string {
 // Temporary CSV formatter for channels
 func formatChannelsAsCSV(channels []models.ChannelInfo) string {
 	var sb strings.Builder
+	showServer := len(channels) > 0 && channels[0].Server != ""
+	if showServer {
+		sb.WriteString("Server,")
+	}
 	sb.WriteString("ChannelID,ONID,TSID,SID,ServiceType,ServiceTypeName,ServiceName,ServiceProviderName,NetworkName,TSName,RemoteControlKeyID\n")
 	for _, ch := range channels {
+		if showServer {
+			sb.WriteString(ch.Server + ",")
+		}
 		sb.WriteString(fmt.Sprintf("%s,%d,%d,%d,%d,%s,%s,%s,%s,%s,%d\n",
 			ch.ChannelID(),
 			ch.ONID,
@@ -251,8 +262,15 @@ func formatChannelsAsCSV(channels []models.ChannelInfo) string {
 // Temporary TSV formatter for channels
 func formatChannelsAsTSV(channels []models.ChannelInfo) string {
 	var sb strings.Builder
+	showServer := len(channels) > 0 && channels[0].Server != ""
+	if showServer {
+		sb.WriteString("Server\t")
+	}
 	sb.WriteString("ChannelID\tONID\tTSID\tSID\tServiceType\tServiceTypeName\tServiceName\tServiceProviderName\tNetworkName\tTSName\tRemoteControlKeyID\n")
 	for _, ch := range channels {
+		if showServer {
+			sb.WriteString(ch.Server + "\t")
+		}
 		sb.WriteString(fmt.Sprintf("%s\t%d\t%d\t%d\t%d\t%s\t%s\t%s\t%s\t%s\t%d\n",
 			ch.ChannelID(),
 			ch.ONID,
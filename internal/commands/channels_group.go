@@ -0,0 +1,203 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+	"github.com/spf13/cobra"
+)
+
+var channelsExportGroupCmd = &cobra.Command{
+	Use:   "export-group NAME",
+	Short: "Create a channel group from the server's channel list",
+	Long: `Create a named channel group from the server's channel list.
+
+The channels are selected with the filter flags and/or --channels, and written
+as a channelGroups entry for the config file (yaml), or as a channel list file
+that a group can read with "file:" (list). Each channel is annotated with its name.
+
+The yaml output starts with a channelGroups key. Copy the group into the
+channelGroups section of ~/.config/epgtimer/config.yaml (or add the section if
+the file has none); do not append it to the file, as a second channelGroups key
+makes the config file invalid. The group can then be used as "@NAME" wherever a
+channel list is accepted (add, edit, epg, list and reservations).
+
+Built-in groups (can be redefined in the config file):
+  @all          All TV channels
+  @terrestrial  Terrestrial TV channels
+  @bs           BS TV channels
+  @cs           CS TV channels
+
+Example:
+  # Snapshot of the BS TV channels
+  epgtimer channels export-group bs-tv --broadcast bs --tv
+
+  # Hand-picked channels, saved to a file to copy into the config file
+  epgtimer channels export-group my-favorites --channels "NHK総合,key:4,@bs" -o my-favorites.yaml
+
+  # Channel list file replacing serviceList_without_local.txt
+  epgtimer channels export-group all --terrestrial --tv --format list -o channels.txt`,
+	Args: cobra.ExactArgs(1),
+	RunE: runChannelsExportGroup,
+}
+
+func init() {
+	channelsCmd.AddCommand(channelsExportGroupCmd)
+
+	channelsExportGroupCmd.Flags().StringSlice("channels", nil, "Channels and channel groups to include (comma-separated, e.g., key:1,@bs)")
+	channelsExportGroupCmd.Flags().String("broadcast", "", "Select by broadcast system: terrestrial, bs or cs")
+	channelsExportGroupCmd.Flags().Bool("terrestrial", false, "Select terrestrial channels (same as --broadcast terrestrial)")
+	channelsExportGroupCmd.Flags().Bool("tv", false, "Select TV channels (service_type=1)")
+	channelsExportGroupCmd.Flags().Bool("radio", false, "Select radio channels (service_type=2)")
+	channelsExportGroupCmd.Flags().Bool("data", false, "Select data channels (service_type=192)")
+	channelsExportGroupCmd.Flags().String("network", "", "Select by network name (substring match, case-insensitive)")
+	channelsExportGroupCmd.Flags().String("name", "", "Select by channel name (substring match, case-insensitive)")
+
+	channelsExportGroupCmd.Flags().String("format", "yaml", "Output format: yaml (config file entry) or list (channel list file)")
+	channelsExportGroupCmd.Flags().StringP("output", "o", "", "Output file path (default: stdout)")
+}
+
+func runChannelsExportGroup(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if err := models.ValidateChannelGroupName(name); err != nil {
+		return err
+	}
+
+	format, _ := cmd.Flags().GetString("format")
+	if format != "yaml" && format != "list" {
+		return fmt.Errorf("unsupported format '%s'. Supported formats: yaml, list", format)
+	}
+
+	filter, err := channelGroupFilterFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	refs, _ := cmd.Flags().GetStringSlice("channels")
+	if len(refs) == 0 && !filter.IsFilter() {
+		return fmt.Errorf("no channels selected\n\nUsage:\n  epgtimer channels export-group %s --broadcast bs --tv\n  epgtimer channels export-group %s --channels \"NHK総合,key:4\"", name, name)
+	}
+
+	// Get EMWUI endpoint from flag, environment variable or config profile
+	endpoint, err := GetEMWUIEndpoint(cmd)
+	if err != nil {
+		return fmt.Errorf("EMWUI endpoint not configured\n\nPlease set the endpoint using:\n  1. --endpoint flag: epgtimer channels export-group %s --endpoint http://192.168.1.10:5510\n  2. EMWUI_ENDPOINT environment variable: export EMWUI_ENDPOINT=http://192.168.1.10:5510\n  3. a profile in ~/.config/epgtimer/config.yaml: epgtimer channels export-group %s --profile living-room", name, name)
+	}
+
+	c, err := newClient(cmd, endpoint)
+	if err != nil {
+		return err
+	}
+
+	response, err := c.EnumService(cmd.Context())
+	if err != nil {
+		return formatConnectionError(err, endpoint)
+	}
+
+	// Start from the given channels (in their order), or from every channel
+	selected := response.Items
+	if len(refs) > 0 {
+		resolver := &models.ChannelResolver{Channels: response.Items, Groups: channelGroups}
+		ids, err := resolver.ResolveAll(refs)
+		if err != nil {
+			return fmt.Errorf("invalid channel in --channels: %w", err)
+		}
+
+		selected = nil
+		for _, id := range ids {
+			index := slices.IndexFunc(response.Items, func(ch models.ChannelInfo) bool { return ch.ChannelID() == id })
+			if index < 0 {
+				return fmt.Errorf("channel %s is not in the server's channel list", id)
+			}
+			selected = append(selected, response.Items[index])
+		}
+	}
+
+	var channels []models.ChannelInfo
+	for _, ch := range selected {
+		if filter.Matches(ch) {
+			channels = append(channels, ch)
+		}
+	}
+	if len(channels) == 0 {
+		return fmt.Errorf("no channels match the specified filters")
+	}
+
+	var output string
+	if format == "yaml" {
+		output = formatChannelGroupAsYAML(name, channels)
+	} else {
+		output = formatChannelGroupAsList(name, channels)
+	}
+
+	// Get output flag
+	outputPath, _ := cmd.Flags().GetString("output")
+
+	// Write to file or stdout
+	if outputPath != "" {
+		if err := os.WriteFile(outputPath, []byte(output), 0644); err != nil {
+			return fmt.Errorf("failed to write output to file '%s': %w", outputPath, err)
+		}
+		fmt.Printf("Successfully exported %d channels to %s\n", len(channels), outputPath)
+	} else {
+		fmt.Print(output)
+	}
+
+	return nil
+}
+
+// channelGroupFilterFromFlags builds the channel filter given by the selection flags
+func channelGroupFilterFromFlags(cmd *cobra.Command) (models.ChannelGroup, error) {
+	var filter models.ChannelGroup
+	filter.Broadcast, _ = cmd.Flags().GetString("broadcast")
+	filter.Network, _ = cmd.Flags().GetString("network")
+	filter.Name, _ = cmd.Flags().GetString("name")
+
+	if terrestrial, _ := cmd.Flags().GetBool("terrestrial"); terrestrial {
+		if filter.Broadcast != "" && filter.Broadcast != "terrestrial" {
+			return filter, fmt.Errorf("cannot use both --terrestrial and --broadcast %s", filter.Broadcast)
+		}
+		filter.Broadcast = "terrestrial"
+	}
+
+	for _, serviceType := range []string{"tv", "radio", "data"} {
+		if value, _ := cmd.Flags().GetBool(serviceType); value {
+			if filter.Type != "" {
+				return filter, fmt.Errorf("only one of --tv, --radio and --data can be used")
+			}
+			filter.Type = serviceType
+		}
+	}
+
+	switch filter.Broadcast {
+	case "", "terrestrial", "bs", "cs":
+	default:
+		return filter, fmt.Errorf("invalid --broadcast '%s' (expected terrestrial, bs or cs)", filter.Broadcast)
+	}
+
+	return filter, nil
+}
+
+// formatChannelGroupAsYAML formats the channels as a channelGroups entry of the config file
+// The entry is meant to be copied into the config file, not appended to it.
+func formatChannelGroupAsYAML(name string, channels []models.ChannelInfo) string {
+	var sb strings.Builder
+	sb.WriteString("channelGroups:\n")
+	sb.WriteString(fmt.Sprintf("  %s:\n", name))
+	for _, ch := range channels {
+		sb.WriteString(fmt.Sprintf("    - %-18s # %s\n", ch.ChannelID(), ch.ServiceName))
+	}
+	return sb.String()
+}
+
+// formatChannelGroupAsList formats the channels as a channel list file
+func formatChannelGroupAsList(name string, channels []models.ChannelInfo) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# Channel group %s (%d channels)\n", name, len(channels)))
+	for _, ch := range channels {
+		sb.WriteString(fmt.Sprintf("%-18s # %s\n", ch.ChannelID(), ch.ServiceName))
+	}
+	return sb.String()
+}
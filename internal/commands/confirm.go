@@ -0,0 +1,30 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// confirm prints a yes/no prompt and reads the user's answer
+// Anything other than "y" or "yes" (including empty input and EOF) counts as no,
+// so non-interactive runs never proceed without --yes.
+func confirm(in io.Reader, prompt string) (bool, error) {
+	fmt.Printf("%s [y/N]: ", prompt)
+
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("failed to read confirmation: %w", err)
+	}
+	if err == io.EOF {
+		fmt.Println()
+	}
+
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
@@ -0,0 +1,141 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/formatters"
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// conflictsCmd represents the conflicts command
+var conflictsCmd = &cobra.Command{
+	Use:   "conflicts",
+	Short: "Show overlapping reservations and tuner conflicts",
+	Long: `Show reservations whose recording times overlap, and which of them will not be recorded.
+
+Recording windows are computed from the start time and duration of each reservation,
+plus its custom margins (or the default margins given with --default-start-margin
+and --default-end-margin). Disabled reservations are ignored.
+
+By default the status of each reservation is the one reported by EpgTimer:
+  OK       - Recorded in full
+  PARTIAL  - Only part of the program is recorded
+  FAIL     - Not recorded (no free tuner)
+
+With --tuners N the tuner allocation is simulated instead: reservations are assigned
+to N tuners by priority and then start time, and reservations on the same
+transport stream share a tuner. Reservations fixed to a tuner keep that tuner.
+
+Output Formats:
+  timeline - One table per overlap window (default)
+  json     - JSON report for scripting
+
+Example:
+  # Show all overlapping reservations
+  epgtimer conflicts
+
+  # Only show windows where something will be missed
+  epgtimer conflicts --failing
+
+  # Simulate a household with 2 tuners and 1 minute default margins
+  epgtimer conflicts --tuners 2 --default-start-margin 60 --default-end-margin 60
+
+  # JSON report
+  epgtimer conflicts --format json -o conflicts.json`,
+	RunE: runConflictsCommand,
+}
+
+func init() {
+	rootCmd.AddCommand(conflictsCmd)
+
+	conflictsCmd.Flags().Int("tuners", 0, "Simulate the allocation with this many tuners (default: use the server's status)")
+	conflictsCmd.Flags().Int("default-start-margin", 0, "Start margin in seconds for reservations without custom margins")
+	conflictsCmd.Flags().Int("default-end-margin", 0, "End margin in seconds for reservations without custom margins")
+	conflictsCmd.Flags().Bool("failing", false, "Show only overlap windows with reservations that will not be recorded in full")
+
+	// Export flags
+	conflictsCmd.Flags().String("format", "timeline", "Output format: timeline, json")
+	conflictsCmd.Flags().StringP("output", "o", "", "Output file path (default: stdout)")
+}
+
+func runConflictsCommand(cmd *cobra.Command, args []string) error {
+	// Get EMWUI endpoint
+	endpoint, err := GetEMWUIEndpoint(cmd)
+	if err != nil {
+		return fmt.Errorf("configuration error: %w\n\nPlease set EMWUI_ENDPOINT environment variable:\n  export EMWUI_ENDPOINT=http://localhost:5510\n\nOr use --endpoint flag:\n  epgtimer conflicts --endpoint http://localhost:5510", err)
+	}
+
+	tuners, _ := cmd.Flags().GetInt("tuners")
+	if tuners < 0 {
+		return fmt.Errorf("invalid --tuners %d: must be 0 or greater", tuners)
+	}
+
+	opts := models.ConflictOptions{Tuners: tuners}
+	opts.DefaultStartMargin, _ = cmd.Flags().GetInt("default-start-margin")
+	opts.DefaultEndMargin, _ = cmd.Flags().GetInt("default-end-margin")
+
+	format, _ := cmd.Flags().GetString("format")
+	if format != "timeline" && format != "json" {
+		return fmt.Errorf("unsupported format '%s'. Supported formats: timeline, json", format)
+	}
+
+	// Retrieve reservations
+	c, err := newClient(cmd, endpoint)
+	if err != nil {
+		return err
+	}
+	response, err := c.EnumReserveInfo(cmd.Context())
+	if err != nil {
+		return formatConnectionError(err, endpoint)
+	}
+
+	groups, err := models.FindConflicts(response.Items, opts)
+	if err != nil {
+		return err
+	}
+
+	if failingOnly, _ := cmd.Flags().GetBool("failing"); failingOnly {
+		var failing []models.ConflictGroup
+		for _, group := range groups {
+			if group.Failing > 0 {
+				failing = append(failing, group)
+			}
+		}
+		groups = failing
+	}
+
+	// Format report
+	var output string
+	switch format {
+	case "timeline":
+		formatter := &formatters.ConflictsTimelineFormatter{}
+		output, err = formatter.Format(groups)
+	case "json":
+		if groups == nil {
+			groups = []models.ConflictGroup{}
+		}
+		var data []byte
+		data, err = json.MarshalIndent(groups, "", "  ")
+		output = string(data) + "\n"
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	// Write to file or stdout
+	outputPath, _ := cmd.Flags().GetString("output")
+	if outputPath != "" {
+		if err := os.WriteFile(outputPath, []byte(output), 0644); err != nil {
+			return fmt.Errorf("failed to write output to file '%s': %w", outputPath, err)
+		}
+		fmt.Printf("Successfully exported %d overlap windows to %s\n", len(groups), outputPath)
+	} else {
+		fmt.Print(output)
+	}
+
+	return nil
+}
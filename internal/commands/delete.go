@@ -2,18 +2,27 @@ package commands
 
 import (
 	"fmt"
-	"strconv"
-	"strings"
+	"time"
 
-	"github.com/epy0n0ff/epgtimer-cli/internal/client"
+	"github.com/epy0n0ff/epgtimer-cli/internal/formatters"
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
 	"github.com/spf13/cobra"
 )
 
 // deleteCmd represents the delete command
 var deleteCmd = &cobra.Command{
-	Use:   "delete [rule-id]",
-	Short: "Delete an automatic recording rule",
-	Long: `Delete an automatic recording rule by its ID.
+	Use:   "delete [rule-id...]",
+	Short: "Delete automatic recording rules",
+	Long: `Delete automatic recording rules by ID, ID range or filter.
+
+Rules can be selected by ID, by the same filters as 'epgtimer list', or both
+(filters then narrow down the given IDs). An ID range such as 20-25 selects the
+existing rules within it. The matching rules are listed and you
+are asked for confirmation; use --yes to skip the prompt.
+
+Before anything is deleted, the rules are saved to a JSON backup
+(deleted-rules-YYYYMMDD-HHMMSS.json in the current directory, or --backup-file).
+Restore them with 'epgtimer import'.
 
 To find the rule ID, use the 'list' command to see all automatic recording rules.
 
@@ -25,78 +34,102 @@ Example:
   epgtimer delete 334
 
   # Using --id flag
-  epgtimer delete --id 334`,
-	Args: cobra.MaximumNArgs(1),
+  epgtimer delete --id 334
+
+  # Delete several rules and a range of rules
+  epgtimer delete 12 15 20-25
+
+  # Delete all disabled drama rules without prompting
+  epgtimer delete --disabled --andKey ドラマ --yes
+
+  # Show what would be deleted
+  epgtimer delete --channel 32736-32736-1024 --dry-run
+
+  # Undo a deletion
+  epgtimer import deleted-rules-20250101-120000.json`,
 	RunE: runDeleteCommand,
 }
 
-var deleteRuleID int
-
 func init() {
 	rootCmd.AddCommand(deleteCmd)
 
 	// Define flags
-	deleteCmd.Flags().IntVar(&deleteRuleID, "id", 0, "Rule ID to delete")
+	addRuleSelectionFlags(deleteCmd)
+	deleteCmd.Flags().Bool("enabled", false, "Select only enabled rules")
+	deleteCmd.Flags().Bool("disabled", false, "Select only disabled rules")
+	deleteCmd.Flags().String("backup-file", "", "File for the JSON backup of the deleted rules (default: deleted-rules-YYYYMMDD-HHMMSS.json)")
+	deleteCmd.Flags().Bool("dry-run", false, "List the rules that would be deleted without deleting them")
+	deleteCmd.Flags().BoolP("yes", "y", false, "Delete without asking for confirmation")
+
+	deleteCmd.MarkFlagsMutuallyExclusive("enabled", "disabled")
 }
 
 func runDeleteCommand(cmd *cobra.Command, args []string) error {
-	// Get EMWUI endpoint
-	endpoint, err := GetEMWUIEndpoint(cmd)
+	c, endpoint, err := rulesClient(cmd)
 	if err != nil {
-		return fmt.Errorf("configuration error: %w\n\nPlease set EMWUI_ENDPOINT environment variable:\n  export EMWUI_ENDPOINT=http://localhost:5510\n\nOr use --endpoint flag:\n  epgtimer delete --endpoint http://localhost:5510 334", err)
+		return err
 	}
 
-	// Determine rule ID from args or flag
-	var ruleID int
-	if len(args) == 1 {
-		// Parse from positional argument
-		id, err := strconv.Atoi(args[0])
-		if err != nil {
-			return fmt.Errorf("invalid rule ID '%s': must be a number", args[0])
-		}
-		ruleID = id
-	} else if deleteRuleID > 0 {
-		// Use flag value
-		ruleID = deleteRuleID
-	} else {
-		return fmt.Errorf("rule ID is required\n\nUsage:\n  epgtimer delete [rule-id]\n  epgtimer delete --id [rule-id]\n\nTo find rule IDs, run:\n  epgtimer list")
+	rules, err := selectRules(cmd, args, c, endpoint)
+	if err != nil {
+		return err
 	}
 
-	// Validate rule ID
-	if ruleID <= 0 {
-		return fmt.Errorf("invalid rule ID: must be greater than 0")
+	if len(rules) == 0 {
+		fmt.Println("No automatic recording rules match the specified filters.")
+		return nil
 	}
 
-	// Create client
-	c := client.NewClient(endpoint)
-
-	// Call API
-	_, err = c.DeleteAutoAdd(ruleID)
+	formatter := &formatters.TableFormatter{}
+	preview, err := formatter.Format(rules)
 	if err != nil {
-		// Provide helpful error messages based on error type
-		errMsg := err.Error()
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+	fmt.Print(preview)
 
-		if strings.Contains(errMsg, "connection refused") {
-			return fmt.Errorf("connection failed: %w\n\nPlease check:\n  1. EMWUI service is running\n  2. EMWUI_ENDPOINT is correct (current: %s)\n  3. Network connectivity", err, endpoint)
-		}
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if dryRun {
+		fmt.Println("\nDry run: no rules were deleted.")
+		return nil
+	}
 
-		if strings.Contains(errMsg, "timeout") {
-			return fmt.Errorf("connection timeout: %w\n\nEMWUI server did not respond in time (current endpoint: %s)", err, endpoint)
+	yes, _ := cmd.Flags().GetBool("yes")
+	if !yes {
+		ok, err := confirm(cmd.InOrStdin(), fmt.Sprintf("\nDelete %d rules?", len(rules)))
+		if err != nil {
+			return err
 		}
-
-		if strings.Contains(errMsg, "invalid rule ID") {
-			return fmt.Errorf("validation error: %w", err)
+		if !ok {
+			fmt.Println("Cancelled.")
+			return nil
 		}
+	}
 
-		if strings.Contains(errMsg, "HTML response") {
-			return fmt.Errorf("unexpected response: %w\n\nPossible causes:\n  1. Incorrect endpoint URL\n  2. API path has changed\n  3. EMWUI version incompatibility", err)
+	// Back up the rules before deleting anything
+	backupPath, _ := cmd.Flags().GetString("backup-file")
+	if backupPath == "" {
+		backupPath = fmt.Sprintf("deleted-rules-%s.json", time.Now().Format("20060102-150405"))
+	}
+	if err := models.WriteAutoAddRuleBackup(backupPath, rules); err != nil {
+		return fmt.Errorf("failed to write backup to '%s': %w\n\nNo rules were deleted.", backupPath, err)
+	}
+	fmt.Printf("Backed up %d rules to %s (restore with: epgtimer import %s)\n", len(rules), backupPath, backupPath)
+
+	failed := 0
+	for _, rule := range rules {
+		if _, err := c.DeleteAutoAdd(cmd.Context(), rule.ID); err != nil {
+			if len(rules) == 1 {
+				return apiError(err, endpoint, "delete recording rule")
+			}
+			fmt.Printf("✗ Failed to delete rule %d: %v\n", rule.ID, err)
+			failed++
+			continue
 		}
-
-		return fmt.Errorf("failed to delete recording rule: %w", err)
+		fmt.Printf("✓ Automatic recording rule (ID: %d) deleted successfully: %s\n", rule.ID, rule.SearchSettings.AndKey)
 	}
 
-	// Success
-	fmt.Printf("✓ Automatic recording rule (ID: %d) deleted successfully\n", ruleID)
-
+	if failed > 0 {
+		return fmt.Errorf("%d of %d rules could not be deleted", failed, len(rules))
+	}
 	return nil
 }
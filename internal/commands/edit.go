@@ -0,0 +1,193 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/client"
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// editCmd represents the edit command
+var editCmd = &cobra.Command{
+	Use:   "edit [rule-id]",
+	Short: "Edit an existing automatic recording rule",
+	Long: `Edit an existing automatic recording rule in place.
+
+The current rule is fetched from EMWUI and only the flags you pass are changed.
+All other settings (including ones the CLI cannot set, such as the genre filter)
+are sent back unchanged, so the rule keeps its ID.
+
+Example:
+  # Change the exclusion keywords of rule 334
+  epgtimer edit 334 --notKey "再放送 [再]"
+
+  # Replace the channel list
+  epgtimer edit --id 334 --serviceList "32736-32736-1024,32736-32736-1025"
+
+  # Disable a rule without deleting it
+  epgtimer edit 334 --disable
+
+  # Re-enable a rule and raise its priority
+  epgtimer edit 334 --enable --priority 4
+
+  # Restrict a rule to weekday evenings (replaces the current date filter)
+  epgtimer edit 334 --days weekdays --start-time 18:00 --end-time 23:00
+
+  # Move recordings to another folder (replaces the current folders)
+  epgtimer edit 334 --rec-folder 'E:\Recorded\News'
+
+  # Go back to EpgTimer's default folder
+  epgtimer edit 334 --rec-folder ""
+
+The search and recording option flags are the same as for 'epgtimer add'.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runEditCommand,
+}
+
+func init() {
+	rootCmd.AddCommand(editCmd)
+
+	// Define flags
+	editCmd.Flags().Int("id", 0, "Rule ID to edit")
+	editCmd.Flags().String("andKey", "", "New search keywords")
+	editCmd.Flags().String("notKey", "", "New exclusion keywords (use \"\" to clear)")
+	editCmd.Flags().StringSlice("serviceList", []string{}, "New channel list: ONID-TSID-SID, key:N or channel name (replaces the current list)")
+	editCmd.Flags().String("serviceListFile", "", "File containing the new channel list (replaces the current list)")
+	editCmd.Flags().Bool("enable", false, "Enable the rule")
+	editCmd.Flags().Bool("disable", false, "Disable the rule")
+
+	// Search and recording option flags (shared with add)
+	addSearchSettingFlags(editCmd)
+	addRecSettingFlags(editCmd)
+
+	editCmd.MarkFlagsMutuallyExclusive("enable", "disable")
+}
+
+func runEditCommand(cmd *cobra.Command, args []string) error {
+	// Get EMWUI endpoint
+	endpoint, err := GetEMWUIEndpoint(cmd)
+	if err != nil {
+		return fmt.Errorf("configuration error: %w\n\nPlease set EMWUI_ENDPOINT environment variable:\n  export EMWUI_ENDPOINT=http://localhost:5510\n\nOr use --endpoint flag:\n  epgtimer edit --endpoint http://localhost:5510 334 --notKey \"...\"", err)
+	}
+
+	// Determine rule ID from args or flag
+	flagID, _ := cmd.Flags().GetInt("id")
+	var ruleID int
+	if len(args) == 1 {
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid rule ID '%s': must be a number", args[0])
+		}
+		ruleID = id
+	} else if flagID > 0 {
+		ruleID = flagID
+	} else {
+		return fmt.Errorf("rule ID is required\n\nUsage:\n  epgtimer edit [rule-id] [flags]\n  epgtimer edit --id [rule-id] [flags]\n\nTo find rule IDs, run:\n  epgtimer list")
+	}
+
+	if ruleID <= 0 {
+		return fmt.Errorf("invalid rule ID: must be greater than 0")
+	}
+
+	// Create client
+	c, err := newClient(cmd, endpoint)
+	if err != nil {
+		return err
+	}
+
+	// Fetch the current rule
+	rule, err := c.GetAutoAdd(cmd.Context(), ruleID)
+	if err != nil {
+		var notFoundErr *client.ErrNotFound
+		if errors.As(err, &notFoundErr) {
+			return fmt.Errorf("%w\n\nTo find rule IDs, run:\n  epgtimer list", err)
+		}
+		return formatConnectionError(err, endpoint)
+	}
+
+	current := models.NewAutoAddRuleRequestFromRule(rule)
+	req := models.NewAutoAddRuleRequestFromRule(rule)
+
+	// Merge only the flags that were given
+	if err := applyEditFlags(cmd, req); err != nil {
+		return fmt.Errorf("validation error: %w", err)
+	}
+
+	// Resolve channel names and key:N references given to --serviceList
+	req.ServiceList, err = resolveChannelRefs(cmd, c, endpoint, req.ServiceList)
+	if err != nil {
+		return fmt.Errorf("invalid channel in --serviceList: %w", err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return fmt.Errorf("validation error: %w", err)
+	}
+
+	changes := current.Diff(req)
+	if len(changes) == 0 {
+		fmt.Printf("No changes to automatic recording rule (ID: %d)\n", ruleID)
+		return nil
+	}
+
+	// Call API
+	_, err = c.UpdateAutoAdd(cmd.Context(), ruleID, req)
+	if err != nil {
+		return apiError(err, endpoint, "update recording rule")
+	}
+
+	// Success
+	fmt.Printf("✓ Automatic recording rule (ID: %d) updated successfully\n", ruleID)
+	fmt.Println("\nChanges:")
+	for _, change := range changes {
+		fmt.Printf("  %s\n", change)
+	}
+
+	return nil
+}
+
+// applyEditFlags merges explicitly set command-line flags into the request
+func applyEditFlags(cmd *cobra.Command, req *models.AutoAddRuleRequest) error {
+	flags := cmd.Flags()
+
+	if flags.Changed("andKey") {
+		value, _ := flags.GetString("andKey")
+		if strings.TrimSpace(value) == "" {
+			return fmt.Errorf("--andKey cannot be empty")
+		}
+		req.AndKey = value
+	}
+
+	if flags.Changed("notKey") {
+		req.NotKey, _ = flags.GetString("notKey")
+	}
+
+	if flags.Changed("serviceList") || flags.Changed("serviceListFile") {
+		channels, _ := flags.GetStringSlice("serviceList")
+
+		if file, _ := flags.GetString("serviceListFile"); file != "" {
+			fileChannels, err := models.ReadChannelFile(file)
+			if err != nil {
+				return fmt.Errorf("failed to read serviceListFile: %w", err)
+			}
+			channels = append(channels, fileChannels...)
+		}
+
+		req.ServiceList = channels
+	}
+
+	if enable, _ := flags.GetBool("enable"); enable {
+		req.DisableFlag = 0
+	}
+	if disable, _ := flags.GetBool("disable"); disable {
+		req.DisableFlag = 1
+	}
+
+	if err := applySearchSettingFlags(cmd, req); err != nil {
+		return err
+	}
+	return applyRecSettingFlags(cmd, &req.RecSettingRequest)
+}
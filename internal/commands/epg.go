@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -21,8 +22,27 @@ and displays them in a human-readable table format. Each program shows its date,
 time, duration, title, and genre.
 
 Channel Selection:
-  --channel       Specific channel in ONID-TSID-SID format
-  --all-channels  Retrieve EPG for all channels from serviceList_without_local.txt
+  --channel       A single channel (ONID-TSID-SID, key:N or channel name)
+  --channels      Several channels and/or channel groups (e.g., @bs,key:1)
+  --all-channels  All TV channels (same as --channels @all)
+
+The "all" group can be redefined in the config file, e.g. to read a channel list:
+  channelGroups:
+    all:
+      file: serviceList_without_local.txt
+
+With several channels, the EPG is retrieved in parallel (--concurrency, default 4);
+--rate limits the requests per second to spare a busy EpgTimer host. The output keeps
+the channel order. Channels that cannot be retrieved are listed on stderr after the
+others are retrieved, and the command exits with an error.
+
+EPG Cache:
+  The EPG and channel list of each server are cached (in $EPGTIMER_CACHE_DIR, or
+  $XDG_CACHE_HOME/epgtimer) and reused for --cache-ttl (default 1h). Only channels
+  whose cached EPG is missing or older are downloaded again.
+  --refresh   Download everything again and update the cache
+  --offline   Only use cached data (of any age), e.g. while the recorder is asleep
+  --no-cache  Neither read nor write the cache
 
 Output Formats:
   table  - Human-readable table format (default)
@@ -38,9 +58,12 @@ Examples:
   # List EPG for a specific channel
   epgtimer epg --channel "32736-32736-1024"
 
-  # List EPG for all channels in serviceList_without_local.txt
+  # List EPG for all TV channels
   epgtimer epg --all-channels
 
+  # List EPG for the BS channels and one more channel
+  epgtimer epg --channels @bs,"NHK総合"
+
   # Filter by title
   epgtimer epg --channel "32736-32736-1024" --title "ニュース"
 
@@ -52,14 +75,24 @@ Examples:
 
   # Export all channels to CSV
   epgtimer epg --all-channels --format csv -o epg.csv
+
+  # Retrieve 8 channels at a time, at most 5 requests per second
+  epgtimer epg --all-channels --concurrency 8 --rate 5
+
+  # Browse the cached guide while the recorder is asleep
+  epgtimer epg --all-channels --offline --title "ニュース"
 `,
 	RunE: runEPG,
 }
 
 func init() {
 	// Channel selection flags
-	epgCmd.Flags().String("channel", "", "Channel ID in ONID-TSID-SID format (e.g., 32736-32736-1024)")
-	epgCmd.Flags().Bool("all-channels", false, "Retrieve EPG for all channels from serviceList_without_local.txt")
+	epgCmd.Flags().String("channel", "", "Channel: ONID-TSID-SID, key:N or channel name (e.g., 32736-32736-1024, key:1, \"NHK総合\")")
+	epgCmd.Flags().StringSlice("channels", nil, "Channels and channel groups (comma-separated, e.g., @bs,key:1)")
+	epgCmd.Flags().Bool("all-channels", false, "Retrieve EPG for all TV channels (same as --channels @all)")
+
+	// Retrieval and cache flags (shared with search)
+	addEPGSourceFlags(epgCmd)
 
 	// Filter flags
 	epgCmd.Flags().String("title", "", "Filter by program title (substring match, case-insensitive)")
@@ -71,75 +104,103 @@ func init() {
 }
 
 func runEPG(cmd *cobra.Command, args []string) error {
-	// Get EMWUI endpoint
-	endpoint, err := cmd.Flags().GetString("endpoint")
+	// Get EMWUI endpoint from flag, environment variable or config profile
+	endpoint, err := GetEMWUIEndpoint(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to get endpoint flag: %w", err)
-	}
-
-	if endpoint == "" {
-		endpoint = os.Getenv("EMWUI_ENDPOINT")
-	}
-
-	if endpoint == "" {
-		return fmt.Errorf("EMWUI endpoint not configured\n\nPlease set the endpoint using:\n  1. --endpoint flag: epgtimer epg --endpoint http://192.168.1.10:5510\n  2. EMWUI_ENDPOINT environment variable: export EMWUI_ENDPOINT=http://192.168.1.10:5510")
+		return fmt.Errorf("EMWUI endpoint not configured\n\nPlease set the endpoint using:\n  1. --endpoint flag: epgtimer epg --endpoint http://192.168.1.10:5510\n  2. EMWUI_ENDPOINT environment variable: export EMWUI_ENDPOINT=http://192.168.1.10:5510\n  3. a profile in ~/.config/epgtimer/config.yaml: epgtimer epg --profile living-room")
 	}
 
 	// Get channel selection flags
 	channel, _ := cmd.Flags().GetString("channel")
+	channelRefs, _ := cmd.Flags().GetStringSlice("channels")
 	allChannels, _ := cmd.Flags().GetBool("all-channels")
 
 	// Validate channel selection
-	if !allChannels && channel == "" {
-		return fmt.Errorf("must specify either --channel or --all-channels")
+	selected := 0
+	for _, given := range []bool{channel != "", len(channelRefs) > 0, allChannels} {
+		if given {
+			selected++
+		}
+	}
+	if selected == 0 {
+		return fmt.Errorf("must specify --channel, --channels or --all-channels")
+	}
+	if selected > 1 {
+		return fmt.Errorf("only one of --channel, --channels and --all-channels can be used")
+	}
+
+	if allChannels {
+		channelRefs = []string{"@all"}
 	}
 
-	if allChannels && channel != "" {
-		return fmt.Errorf("cannot use both --channel and --all-channels")
+	fetchOpts, err := epgBatchOptions(cmd)
+	if err != nil {
+		return err
 	}
 
 	// Create API client
-	apiClient := client.NewClient(endpoint)
+	apiClient, err := newClient(cmd, endpoint)
+	if err != nil {
+		return err
+	}
 
-	var allEvents []models.EventInfo
+	// Channel lists and EPG data come from the cache when possible
+	source, err := newEPGSource(cmd, apiClient, endpoint)
+	if err != nil {
+		return err
+	}
 
-	if allChannels {
-		// Read channel list from serviceList_without_local.txt
-		channels, err := readChannelList("serviceList_without_local.txt")
+	// Resolve channels and groups to channel IDs
+	var ids []string
+	if channel != "" && models.IsChannelID(channel) {
+		ids = []string{channel}
+	} else {
+		serverChannels, err := source.channels(cmd)
 		if err != nil {
-			return fmt.Errorf("failed to read channel list: %w", err)
+			if errors.Is(err, errNotCached) {
+				return err
+			}
+			return formatConnectionError(err, endpoint)
 		}
-
-		fmt.Printf("Retrieving EPG for %d channels...\n", len(channels))
-
-		// Retrieve EPG for each channel
-		for i, ch := range channels {
-			fmt.Printf("  [%d/%d] Retrieving %s...\r", i+1, len(channels), ch.String())
-
-			response, err := apiClient.EnumEventInfo(ch.ONID, ch.TSID, ch.SID)
+		resolver := &models.ChannelResolver{Channels: serverChannels, Groups: channelGroups}
+		if channel != "" {
+			id, err := resolver.Resolve(channel)
 			if err != nil {
-				fmt.Printf("\n  Warning: Failed to retrieve EPG for %s: %v\n", ch.String(), err)
-				continue
+				return err
 			}
-
-			allEvents = append(allEvents, response.Items...)
+			ids = []string{id}
+		} else if ids, err = resolver.ResolveAll(channelRefs); err != nil {
+			return err
 		}
+	}
 
-		fmt.Printf("\nRetrieved %d programs from %d channels\n\n", len(allEvents), len(channels))
-	} else {
-		// Parse single channel
-		ch, err := models.ParseServiceListEntry(channel)
+	channels := make([]*models.ServiceListEntry, 0, len(ids))
+	for _, id := range ids {
+		ch, err := models.ParseServiceListEntry(id)
 		if err != nil {
 			return fmt.Errorf("invalid channel format: %w", err)
 		}
+		channels = append(channels, ch)
+	}
 
-		// Retrieve EPG for single channel
-		response, err := apiClient.EnumEventInfo(ch.ONID, ch.TSID, ch.SID)
-		if err != nil {
-			return formatConnectionError(err, endpoint)
-		}
+	multiChannel := len(channelRefs) > 0
+	if multiChannel {
+		fmt.Printf("Retrieving EPG for %d channels...\n", len(channels))
+	}
 
-		allEvents = response.Items
+	// Retrieve EPG for the channels in parallel; events keep the channel order
+	results := source.epg(cmd, channels, fetchOpts)
+	allEvents, failed := mergeEPGResults(results)
+	if failed == len(channels) {
+		return epgRetrievalError(results[0], endpoint)
+	}
+
+	if multiChannel {
+		fmt.Printf("Retrieved %d programs from %d channels", len(allEvents), len(channels)-failed)
+		if source.cached > 0 {
+			fmt.Printf(" (%d from cache)", source.cached)
+		}
+		fmt.Printf("\n\n")
 	}
 
 	// Apply filters
@@ -148,11 +209,11 @@ func runEPG(cmd *cobra.Command, args []string) error {
 	// Handle empty results
 	if len(filteredEvents) == 0 {
 		fmt.Println("No programs match the specified filters.")
-		return nil
+		return epgChannelsError(failed, len(channels))
 	}
 
 	// Get format flag
-	format, _ := cmd.Flags().GetString("format")
+	format := outputFormat(cmd)
 
 	// Format events
 	var output string
@@ -187,34 +248,48 @@ func runEPG(cmd *cobra.Command, args []string) error {
 		fmt.Print(output)
 	}
 
-	return nil
+	return epgChannelsError(failed, len(channels))
 }
 
-// readChannelList reads channel IDs from serviceList_without_local.txt
-func readChannelList(filename string) ([]*models.ServiceListEntry, error) {
-	data, err := os.ReadFile(filename)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read file '%s': %w", filename, err)
-	}
-
-	lines := strings.Split(string(data), "\n")
-	var channels []*models.ServiceListEntry
-
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
+// mergeEPGResults concatenates the events in channel order and reports the channels that
+// could not be retrieved on stderr (with several channels); it returns the number of failed channels
+func mergeEPGResults(results []client.ChannelEPG) ([]models.EventInfo, int) {
+	var events []models.EventInfo
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
 			continue
 		}
+		events = append(events, result.Events...)
+	}
 
-		ch, err := models.ParseServiceListEntry(line)
-		if err != nil {
-			return nil, fmt.Errorf("invalid channel format '%s': %w", line, err)
+	if failed > 0 && len(results) > 1 {
+		fmt.Fprintf(os.Stderr, "Failed to retrieve EPG for %d of %d channels:\n", failed, len(results))
+		for _, result := range results {
+			if result.Err != nil {
+				fmt.Fprintf(os.Stderr, "  ✗ %s: %v\n", result.Channel.String(), result.Err)
+			}
 		}
+	}
 
-		channels = append(channels, ch)
+	return events, failed
+}
+
+// epgRetrievalError returns the error reported when no channel could be retrieved
+func epgRetrievalError(result client.ChannelEPG, endpoint string) error {
+	if errors.Is(result.Err, errNotCached) {
+		return fmt.Errorf("EPG of %s is %w\n\nRun epgtimer epg without --offline while the server is reachable to fill the cache.", result.Channel, errNotCached)
 	}
+	return formatConnectionError(result.Err, endpoint)
+}
 
-	return channels, nil
+// epgChannelsError returns the error reported after the output when some channels could not be retrieved
+func epgChannelsError(failed int, total int) error {
+	if failed == 0 {
+		return nil
+	}
+	return fmt.Errorf("EPG could not be retrieved for %d of %d channels", failed, total)
 }
 
 func applyEPGFilters(cmd *cobra.Command, events []models.EventInfo) []models.EventInfo {
@@ -0,0 +1,178 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/cache"
+	"github.com/epy0n0ff/epgtimer-cli/internal/client"
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// epgCacheMode selects how the epg command uses the EPG cache
+type epgCacheMode int
+
+const (
+	epgCacheDefault  epgCacheMode = iota // Use fresh cached data, download the rest
+	epgCacheRefresh                      // Download everything and update the cache
+	epgCacheOffline                      // Use cached data of any age, never contact the server
+	epgCacheDisabled                     // Neither read nor write the cache
+)
+
+// errNotCached is returned in offline mode for data that is not in the cache
+var errNotCached = errors.New("not in the EPG cache")
+
+// epgSource retrieves channel lists and EPG data through the cache
+type epgSource struct {
+	client *client.Client
+	cache  *cache.EPGCache // nil if the cache is disabled
+	mode   epgCacheMode
+	server string // Cache key of the endpoint (host:port)
+	cached int    // Channels served from the cache
+}
+
+// addEPGSourceFlags registers the retrieval and cache flags shared by epg and search
+func addEPGSourceFlags(cmd *cobra.Command) {
+	cmd.Flags().Int("concurrency", 4, "Number of channels retrieved at the same time")
+	cmd.Flags().Float64("rate", 0, "Maximum EPG requests per second (0 = unlimited)")
+
+	cmd.Flags().Bool("refresh", false, "Download the EPG even if it is cached, and update the cache")
+	cmd.Flags().Bool("offline", false, "Only use the cached EPG and channel list, without contacting the server")
+	cmd.Flags().Bool("no-cache", false, "Neither read nor write the EPG cache")
+	cmd.Flags().Duration("cache-ttl", cache.DefaultTTL, "How long cached EPG data is used before it is downloaded again")
+}
+
+// epgBatchOptions returns the --concurrency and --rate flags as retrieval options
+func epgBatchOptions(cmd *cobra.Command) (client.BatchOptions, error) {
+	opts := client.BatchOptions{}
+	opts.Concurrency, _ = cmd.Flags().GetInt("concurrency")
+	opts.Rate, _ = cmd.Flags().GetFloat64("rate")
+	if opts.Concurrency < 1 {
+		return opts, fmt.Errorf("--concurrency must be at least 1")
+	}
+	if math.IsNaN(opts.Rate) || math.IsInf(opts.Rate, 0) {
+		return opts, fmt.Errorf("--rate must be a finite number")
+	}
+	if opts.Rate < 0 {
+		return opts, fmt.Errorf("--rate must not be negative")
+	}
+	return opts, nil
+}
+
+// newEPGSource creates the EPG source for the --refresh, --offline, --no-cache and --cache-ttl flags
+// The cache directory is EPGTIMER_CACHE_DIR, or the default cache directory.
+func newEPGSource(cmd *cobra.Command, c *client.Client, endpoint string) (*epgSource, error) {
+	refresh, _ := cmd.Flags().GetBool("refresh")
+	offline, _ := cmd.Flags().GetBool("offline")
+	noCache, _ := cmd.Flags().GetBool("no-cache")
+	ttl, _ := cmd.Flags().GetDuration("cache-ttl")
+
+	source := &epgSource{client: c, server: serverName(endpoint)}
+	switch {
+	case refresh && offline, refresh && noCache, offline && noCache:
+		return nil, fmt.Errorf("only one of --refresh, --offline and --no-cache can be used")
+	case refresh:
+		source.mode = epgCacheRefresh
+	case offline:
+		source.mode = epgCacheOffline
+	case noCache:
+		source.mode = epgCacheDisabled
+		return source, nil
+	}
+
+	if ttl <= 0 {
+		return nil, fmt.Errorf("--cache-ttl must be greater than 0")
+	}
+
+	dir := os.Getenv("EPGTIMER_CACHE_DIR")
+	if dir == "" {
+		var err error
+		dir, err = cache.DefaultDir()
+		if err != nil {
+			return nil, err
+		}
+	}
+	source.cache = cache.NewEPGCache(dir, ttl)
+
+	return source, nil
+}
+
+// channels returns the server's channel list, from the cache if it is fresh (or in offline mode)
+func (s *epgSource) channels(cmd *cobra.Command) ([]models.ChannelInfo, error) {
+	if s.cache != nil && s.mode != epgCacheRefresh {
+		entry, err := s.cache.LoadChannels(s.server)
+		if err != nil && s.mode == epgCacheOffline {
+			return nil, err
+		}
+		if entry != nil && (s.mode == epgCacheOffline || s.cache.Fresh(entry.FetchedAt)) {
+			return entry.Channels, nil
+		}
+	}
+
+	if s.mode == epgCacheOffline {
+		return nil, fmt.Errorf("the channel list of %s is %w\n\nRun epgtimer epg once while the server is reachable to fill the cache.", s.server, errNotCached)
+	}
+
+	response, err := s.client.EnumService(cmd.Context())
+	if err != nil {
+		return nil, err
+	}
+	s.store(func() error { return s.cache.StoreChannels(s.server, response.Items) })
+	return response.Items, nil
+}
+
+// epg returns the EPG of every channel in channel order
+// Channels with fresh cached data (any cached data in offline mode) are not downloaded.
+func (s *epgSource) epg(cmd *cobra.Command, channels []*models.ServiceListEntry, opts client.BatchOptions) []client.ChannelEPG {
+	results := make([]client.ChannelEPG, len(channels))
+	var missing []*models.ServiceListEntry
+	var missingIndex []int
+
+	for i, ch := range channels {
+		results[i].Channel = ch
+
+		if s.cache != nil && s.mode != epgCacheRefresh {
+			entry, err := s.cache.LoadEPG(s.server, ch.String())
+			if entry != nil && (s.mode == epgCacheOffline || s.cache.Fresh(entry.FetchedAt)) {
+				results[i].Events = entry.Events
+				s.cached++
+				continue
+			}
+			if s.mode == epgCacheOffline {
+				if err == nil {
+					err = errNotCached
+				}
+				results[i].Err = err
+				continue
+			}
+		}
+
+		missing = append(missing, ch)
+		missingIndex = append(missingIndex, i)
+	}
+
+	if len(missing) == 0 {
+		return results
+	}
+
+	for j, result := range s.client.EnumEventInfoChannels(cmd.Context(), missing, opts) {
+		results[missingIndex[j]] = result
+		if result.Err == nil {
+			s.store(func() error { return s.cache.StoreEPG(s.server, result.Channel.String(), result.Events) })
+		}
+	}
+	return results
+}
+
+// store writes to the cache if it is enabled; failures only produce a warning
+func (s *epgSource) store(write func() error) {
+	if s.cache == nil {
+		return
+	}
+	if err := write(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/client"
+)
+
+// apiError adds troubleshooting hints to a failed API call
+// action describes what failed (e.g., "add recording rule").
+func apiError(err error, endpoint string, action string) error {
+	var connErr *client.ErrConnection
+	var timeoutErr *client.ErrTimeout
+	var validationErr *client.ErrValidation
+	var unexpectedErr *client.ErrUnexpectedResponse
+	var ctokErr *client.ErrCTokenMissing
+	var apiErr *client.ErrAPI
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return fmt.Errorf("cancelled: %w", err)
+
+	case errors.As(err, &timeoutErr):
+		return timeoutError(err, endpoint)
+
+	case errors.As(err, &connErr):
+		return fmt.Errorf("connection failed: %w\n\nPlease check:\n  1. EMWUI service is running\n  2. EMWUI_ENDPOINT is correct (current: %s)\n  3. Network connectivity", err, endpoint)
+
+	case errors.As(err, &apiErr) && apiErr.Status == http.StatusUnauthorized:
+		return authError(err)
+
+	case errors.As(err, &validationErr):
+		return fmt.Errorf("validation error: %w", err)
+
+	case errors.As(err, &unexpectedErr), errors.As(err, &ctokErr):
+		return unexpectedResponseError(err)
+	}
+
+	return fmt.Errorf("failed to %s: %w", action, err)
+}
+
+// authError explains how to pass credentials after a 401 response
+func authError(err error) error {
+	return fmt.Errorf("authentication failed: %w\n\nThe EMWUI server requires a user name and password. Use --user and --password,\nor set EMWUI_USER and EMWUI_PASSWORD environment variables", err)
+}
+
+// timeoutError explains how to wait longer for a slow server
+func timeoutError(err error, endpoint string) error {
+	return fmt.Errorf("connection timeout: %w\n\nEMWUI server did not respond in time (current endpoint: %s)\nUse --timeout to wait longer", err, endpoint)
+}
+
+// unexpectedResponseError lists the likely causes of a response that is not from EMWUI
+func unexpectedResponseError(err error) error {
+	return fmt.Errorf("unexpected response: %w\n\nPossible causes:\n  1. Incorrect endpoint URL\n  2. API path has changed\n  3. EMWUI version incompatibility", err)
+}
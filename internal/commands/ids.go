@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseIDList parses IDs given as arguments or flag values
+// Each value may hold several comma-separated IDs (e.g., "1001,1002").
+// Duplicates are removed while keeping the original order.
+func parseIDList(values []string) ([]int, error) {
+	seen := make(map[int]bool)
+	var ids []int
+
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+
+			id, err := strconv.Atoi(part)
+			if err != nil {
+				return nil, fmt.Errorf("invalid ID '%s': must be a number", part)
+			}
+			if id <= 0 {
+				return nil, fmt.Errorf("invalid ID %d: must be greater than 0", id)
+			}
+
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	return ids, nil
+}
+
+// idSelection holds IDs given one by one and as ranges
+// IDs given singly must exist; a range selects the existing IDs within it,
+// since IDs are not contiguous.
+type idSelection struct {
+	ids    []int    // Single IDs in the given order, without duplicates
+	ranges [][2]int // Inclusive ranges
+}
+
+// parseIDSelection parses IDs and ID ranges given as arguments or flag values
+// Each value may hold several comma-separated IDs and ranges (e.g., "12,15,20-25").
+func parseIDSelection(values []string) (*idSelection, error) {
+	var parts []string
+	var ranges [][2]int
+
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			part = strings.TrimSpace(part)
+			first, last, isRange := strings.Cut(part, "-")
+			if !isRange {
+				parts = append(parts, part)
+				continue
+			}
+
+			from, err := strconv.Atoi(strings.TrimSpace(first))
+			if err != nil {
+				return nil, fmt.Errorf("invalid ID range '%s': expected a range like 10-15", part)
+			}
+			to, err := strconv.Atoi(strings.TrimSpace(last))
+			if err != nil {
+				return nil, fmt.Errorf("invalid ID range '%s': expected a range like 10-15", part)
+			}
+			if from <= 0 {
+				return nil, fmt.Errorf("invalid ID range '%s': IDs must be greater than 0", part)
+			}
+			if to < from {
+				return nil, fmt.Errorf("invalid ID range '%s': end is before start", part)
+			}
+			ranges = append(ranges, [2]int{from, to})
+		}
+	}
+
+	ids, err := parseIDList(parts)
+	if err != nil {
+		return nil, err
+	}
+	return &idSelection{ids: ids, ranges: ranges}, nil
+}
+
+// isEmpty returns true if no IDs or ranges were given
+func (s *idSelection) isEmpty() bool {
+	return len(s.ids) == 0 && len(s.ranges) == 0
+}
+
+// inRange returns true if the ID is within one of the ranges
+func (s *idSelection) inRange(id int) bool {
+	for _, r := range s.ranges {
+		if id >= r[0] && id <= r[1] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import [backup-file]",
+	Short: "Restore automatic recording rules from a JSON backup",
+	Long: `Restore automatic recording rules from a backup made with 'epgtimer list --format json'.
+
+Each rule is created with its search and recording settings preserved.
+Rules whose andKey and channel set already exist on the server are skipped,
+so importing the same backup twice does not create duplicates.
+
+Example:
+  # Back up rules on the old host
+  epgtimer list --format json -o backup.json --endpoint http://old-host:5510
+
+  # Preview the import on the new host
+  epgtimer import backup.json --dry-run --endpoint http://new-host:5510
+
+  # Restore the rules
+  epgtimer import backup.json --endpoint http://new-host:5510`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImportCommand,
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+
+	// Define flags
+	importCmd.Flags().Bool("dry-run", false, "Show which rules would be created without changing anything")
+}
+
+func runImportCommand(cmd *cobra.Command, args []string) error {
+	// Get EMWUI endpoint
+	endpoint, err := GetEMWUIEndpoint(cmd)
+	if err != nil {
+		return fmt.Errorf("configuration error: %w\n\nPlease set EMWUI_ENDPOINT environment variable:\n  export EMWUI_ENDPOINT=http://localhost:5510\n\nOr use --endpoint flag:\n  epgtimer import --endpoint http://localhost:5510 backup.json", err)
+	}
+
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	backup, err := models.LoadAutoAddRuleBackup(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to read backup: %w", err)
+	}
+
+	// Create client
+	c, err := newClient(cmd, endpoint)
+	if err != nil {
+		return err
+	}
+
+	// Fetch current rules to detect duplicates
+	response, err := c.EnumAutoAdd(cmd.Context())
+	if err != nil {
+		return formatConnectionError(err, endpoint)
+	}
+
+	plan := models.PlanImport(backup, response.Items)
+
+	fmt.Printf("Backup contains %d rules: %d to create, %d already exist\n",
+		len(backup), len(plan.Creates), len(plan.Skipped))
+
+	for _, rule := range plan.Skipped {
+		fmt.Printf("  = skip    %q (%d channels)\n", rule.SearchSettings.AndKey, rule.SearchSettings.ChannelCount())
+	}
+
+	if len(plan.Creates) == 0 {
+		return nil
+	}
+
+	if dryRun {
+		for _, req := range plan.Creates {
+			fmt.Printf("  + create  %q (%d channels)\n", req.AndKey, len(req.ServiceList))
+		}
+		fmt.Println("\nDry run: no changes were made.")
+		return nil
+	}
+
+	fmt.Println()
+
+	// Create rules, continuing past failures so one bad rule does not block the rest
+	failed := 0
+	for _, req := range plan.Creates {
+		if _, err := c.SetAutoAdd(cmd.Context(), req); err != nil {
+			fmt.Printf("✗ Failed to create %q: %v\n", req.AndKey, err)
+			failed++
+			continue
+		}
+		fmt.Printf("✓ Created %q\n", req.AndKey)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d rules failed to import", failed, len(plan.Creates))
+	}
+
+	return nil
+}
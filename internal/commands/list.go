@@ -1,7 +1,10 @@
 package commands
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
 
 	"github.com/epy0n0ff/epgtimer-cli/internal/client"
@@ -36,12 +39,18 @@ Examples:
   # List rules from a specific EMWUI server
   epgtimer list --endpoint http://192.168.1.10:5510
 
+  # Compare rules of two servers (adds a Server column)
+  epgtimer list --endpoint http://192.168.1.10:5510,http://192.168.1.20:5510
+
   # Filter by keyword
   epgtimer list --andKey ニュース
 
   # Filter by channel
   epgtimer list --channel 32736-32736-1024
 
+  # Filter by channel group (rules recording any BS channel)
+  epgtimer list --channels @bs
+
   # Show only enabled rules
   epgtimer list --enabled
 
@@ -59,6 +68,16 @@ Examples:
 
   # Backup all rules to JSON
   epgtimer list --format json -o backup-$(date +%Y%m%d).json
+
+  # Show the programs each drama rule would record in the current EPG
+  epgtimer list --andKey ドラマ --preview
+
+Preview:
+  --preview evaluates each listed rule against the current EPG of its channels
+  (andKey/notKey, regex, title-only, channels, day/time, duration and free-CA
+  filters; genre filters are not evaluated) and prints the matching programs.
+  The EPG is retrieved and cached as with 'epgtimer epg'. It needs a single
+  server and the table format.
 `,
 	RunE: runList,
 }
@@ -66,64 +85,61 @@ Examples:
 func init() {
 	// Filter flags (Phase 4)
 	listCmd.Flags().String("andKey", "", "Filter by search keyword (substring match, case-insensitive)")
-	listCmd.Flags().String("channel", "", "Filter by channel (ONID-TSID-SID format, e.g., 32736-32736-1024)")
+	listCmd.Flags().String("channel", "", "Filter by channel (ONID-TSID-SID, key:N or channel name, e.g., 32736-32736-1024)")
+	listCmd.Flags().StringSlice("channels", nil, "Filter by any of these channels or channel groups (comma-separated, e.g., @bs,key:1)")
 	listCmd.Flags().Bool("enabled", false, "Show only enabled rules")
 	listCmd.Flags().Bool("disabled", false, "Show only disabled rules")
 	listCmd.Flags().Bool("regex", false, "Show only regex-enabled rules")
 
+	// Preview flags; the EPG retrieval and cache flags are shared with epg
+	listCmd.Flags().Bool("preview", false, "List the programs in the current EPG that each rule would record")
+	addEPGSourceFlags(listCmd)
+
 	// Export flags (Phase 5)
 	listCmd.Flags().String("format", "table", "Output format: table, json, csv, tsv")
 	listCmd.Flags().StringP("output", "o", "", "Output file path (default: stdout)")
 }
 
 func runList(cmd *cobra.Command, args []string) error {
-	// Get EMWUI endpoint from root command flag or environment variable
-	endpoint, err := cmd.Flags().GetString("endpoint")
+	// Get EMWUI endpoints from flag, environment variables or config profile
+	endpoints, err := GetEMWUIEndpoints(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to get endpoint flag: %w", err)
+		return fmt.Errorf("EMWUI endpoint not configured\n\nPlease set the endpoint using:\n  1. --endpoint flag: epgtimer list --endpoint http://192.168.1.10:5510\n  2. EMWUI_ENDPOINT environment variable: export EMWUI_ENDPOINT=http://192.168.1.10:5510\n  3. a profile in ~/.config/epgtimer/config.yaml: epgtimer list --profile living-room")
 	}
 
-	if endpoint == "" {
-		endpoint = os.Getenv("EMWUI_ENDPOINT")
-	}
-
-	if endpoint == "" {
-		return fmt.Errorf("EMWUI endpoint not configured\n\nPlease set the endpoint using:\n  1. --endpoint flag: epgtimer list --endpoint http://192.168.1.10:5510\n  2. EMWUI_ENDPOINT environment variable: export EMWUI_ENDPOINT=http://192.168.1.10:5510")
+	// Resolve channel names, key:N and groups given to --channel/--channels against each server's channel list
+	selections, err := resolveChannelSelections(cmd, endpoints)
+	if err != nil {
+		return err
 	}
 
-	// Create API client
-	apiClient := client.NewClient(endpoint)
-
-	// Retrieve rules
-	response, err := apiClient.EnumAutoAdd()
+	// Retrieve rules from every server
+	items, failed, err := fetchFromServers(cmd, endpoints,
+		func(ctx context.Context, c *client.Client) ([]models.AutoAddRule, error) {
+			response, err := c.EnumAutoAdd(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return response.Items, nil
+		},
+		func(rule *models.AutoAddRule, server string) { rule.Server = server })
 	if err != nil {
-		return formatConnectionError(err, endpoint)
+		return err
 	}
 
 	// Build filter options from flags
-	filterOpts := models.FilterOptions{}
-
-	andKey, _ := cmd.Flags().GetString("andKey")
-	filterOpts.AndKeyFilter = andKey
-
-	channel, _ := cmd.Flags().GetString("channel")
-	filterOpts.ChannelFilter = channel
-
-	enabled, _ := cmd.Flags().GetBool("enabled")
-	filterOpts.EnabledOnly = enabled
-
-	disabled, _ := cmd.Flags().GetBool("disabled")
-	filterOpts.DisabledOnly = disabled
-
-	regex, _ := cmd.Flags().GetBool("regex")
-	filterOpts.RegexOnly = regex
+	filterOpts := ruleFilterOptions(cmd)
 
 	// Apply filters if any are active
-	filteredRules := response.Items
+	filteredRules := items
 	if filterOpts.HasFilters() {
 		filteredRules = make([]models.AutoAddRule, 0)
-		for _, rule := range response.Items {
-			if filterOpts.Matches(&rule) {
+		for _, rule := range items {
+			// Channel filters use the channel IDs of the rule's server
+			opts := filterOpts
+			opts.ChannelFilter = selections[rule.Server].channel
+			opts.ChannelsFilter = selections[rule.Server].channels
+			if opts.Matches(&rule) {
 				filteredRules = append(filteredRules, rule)
 			}
 		}
@@ -131,12 +147,22 @@ func runList(cmd *cobra.Command, args []string) error {
 		// Handle empty results
 		if len(filteredRules) == 0 {
 			fmt.Println("No automatic recording rules match the specified filters.")
-			return nil
+			return serversError(failed, len(endpoints))
 		}
 	}
 
 	// Get format flag
-	format, _ := cmd.Flags().GetString("format")
+	format := outputFormat(cmd)
+
+	if preview, _ := cmd.Flags().GetBool("preview"); preview {
+		if len(endpoints) > 1 {
+			return fmt.Errorf("--preview can only be used with a single server")
+		}
+		if outputPath, _ := cmd.Flags().GetString("output"); format != "table" || outputPath != "" {
+			return fmt.Errorf("--preview is only available in table format on stdout")
+		}
+		return previewRules(cmd, endpoints[0], filteredRules)
+	}
 
 	// Select appropriate formatter
 	var formatter interface {
@@ -177,17 +203,113 @@ func runList(cmd *cobra.Command, args []string) error {
 		fmt.Print(output)
 	}
 
-	return nil
+	return serversError(failed, len(endpoints))
 }
 
-// formatConnectionError formats connection errors with troubleshooting guidance
+// ruleFilterOptions builds the rule filter from the filter flags of the command
+// Flags the command does not define are left unset.
+func ruleFilterOptions(cmd *cobra.Command) models.FilterOptions {
+	filterOpts := models.FilterOptions{}
+
+	andKey, _ := cmd.Flags().GetString("andKey")
+	filterOpts.AndKeyFilter = andKey
+
+	channel, _ := cmd.Flags().GetString("channel")
+	filterOpts.ChannelFilter = channel
+
+	channels, _ := cmd.Flags().GetStringSlice("channels")
+	filterOpts.ChannelsFilter = channels
+
+	enabled, _ := cmd.Flags().GetBool("enabled")
+	filterOpts.EnabledOnly = enabled
+
+	disabled, _ := cmd.Flags().GetBool("disabled")
+	filterOpts.DisabledOnly = disabled
+
+	regex, _ := cmd.Flags().GetBool("regex")
+	filterOpts.RegexOnly = regex
+
+	return filterOpts
+}
+
+// formatConnectionError formats errors of read requests with troubleshooting guidance
+// Only network failures are reported as connection failures.
 func formatConnectionError(err error, endpoint string) error {
+	var timeoutErr *client.ErrTimeout
+	var unexpectedErr *client.ErrUnexpectedResponse
+	var notFoundErr *client.ErrNotFound
+	var apiErr *client.ErrAPI
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return fmt.Errorf("cancelled: %w", err)
+
+	case errors.As(err, &timeoutErr):
+		return timeoutError(err, endpoint)
+
+	case errors.As(err, &apiErr) && apiErr.Status == http.StatusUnauthorized:
+		return authError(err)
+
+	case errors.As(err, &apiErr):
+		return fmt.Errorf("EMWUI server error: %w\n\nThe EMWUI server at %s could not handle the request.\nCheck that EpgTimer is running and see its log for details", err, endpoint)
+
+	case errors.As(err, &unexpectedErr):
+		return unexpectedResponseError(err)
+
+	case errors.As(err, &notFoundErr):
+		return err
+	}
+
 	return fmt.Errorf(`Failed to connect to EMWUI service at %s
 Error: %v
 
 Troubleshooting:
 1. Check that EpgTimer is running
-2. Verify EMWUI_ENDPOINT environment variable or --endpoint flag
+2. Verify EMWUI_ENDPOINT environment variable, --endpoint flag or config profile
 3. Confirm network connectivity to the EMWUI server
 4. Ensure the EMWUI web interface is accessible`, endpoint, err)
 }
+
+// previewRules prints the programs of the current EPG that each rule would record
+func previewRules(cmd *cobra.Command, endpoint string, rules []models.AutoAddRule) error {
+	c, err := newClient(cmd, endpoint)
+	if err != nil {
+		return err
+	}
+
+	settings := make([]*models.SearchSettings, len(rules))
+	for i := range rules {
+		settings[i] = &rules[i].SearchSettings
+	}
+
+	epg, err := fetchPreviewEPG(cmd, c, endpoint, settings)
+	if err != nil {
+		return err
+	}
+
+	for i, rule := range rules {
+		if i > 0 {
+			fmt.Println()
+		}
+
+		fmt.Printf("Rule %d: %s", rule.ID, rule.SearchSettings.AndKey)
+		if rule.SearchSettings.NotKey != "" {
+			fmt.Printf(" (not: %s)", rule.SearchSettings.NotKey)
+		}
+		if !rule.SearchSettings.IsEnabled() {
+			fmt.Print(" [disabled]")
+		}
+		fmt.Printf("\n\n")
+
+		matched, err := epg.matches(settings[i])
+		if err != nil {
+			fmt.Printf("Cannot preview the rule: %v\n", err)
+			continue
+		}
+		if err := printRulePreview(matched); err != nil {
+			return err
+		}
+	}
+
+	return epgChannelsError(epg.failed, epg.channels)
+}
@@ -0,0 +1,339 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/client"
+	"github.com/epy0n0ff/epgtimer-cli/internal/formatters"
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+	"github.com/spf13/cobra"
+)
+
+var manualRulesCmd = &cobra.Command{
+	Use:   "manual-rules",
+	Short: "Manage program-based (manual) automatic recording rules",
+	Long: `Manage EpgTimer's program-based automatic recording rules.
+
+Unlike the keyword rules of 'epgtimer list' and 'epgtimer add', a program-based
+rule does not use the EPG: it records a channel at a fixed time on the selected
+days of the week (e.g., a radio show without usable EPG data).`,
+}
+
+var manualRulesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List program-based automatic recording rules",
+	Long: `Retrieve and display program-based automatic recording rules.
+
+Each rule shows its ID, enabled status, days of week, recording time, duration,
+channel and title.
+
+Output Formats:
+  table  - Human-readable table format (default)
+  json   - JSON format with full rule structure
+  csv    - Comma-separated values
+  tsv    - Tab-separated values
+
+Examples:
+  # List all program-based rules
+  epgtimer manual-rules list
+
+  # Compare the rules of two servers (adds a Server column)
+  epgtimer manual-rules list --endpoint http://192.168.1.10:5510,http://192.168.1.20:5510
+
+  # Filter by title and channel
+  epgtimer manual-rules list --title "深夜便" --channel "NHKラジオ第1"
+
+  # Export to JSON file
+  epgtimer manual-rules list --format json -o manual-rules.json`,
+	RunE: runManualRulesList,
+}
+
+var manualRulesAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Create a program-based automatic recording rule",
+	Long: `Create a program-based automatic recording rule that records a channel
+at a fixed time on the selected days of the week.
+
+The recording length is given with --duration (minutes) or --end-time.
+An end time before the start time is on the next day (e.g., 23:30-01:00).
+
+Example:
+  # Record NHKラジオ第1 on weekdays from 5:30 for 30 minutes
+  epgtimer manual-rules add --title "ラジオ英会話" --channel "NHKラジオ第1" --days weekdays --start-time 05:30 --duration 30
+
+  # Record every Saturday from 23:30 to 1:00 with a high priority
+  epgtimer manual-rules add --title "週末特番" --channel 32736-32736-1024 --days sat --start-time 23:30 --end-time 01:00 --priority 4
+
+The recording option flags are the same as for 'epgtimer add'.`,
+	RunE: runManualRulesAdd,
+}
+
+var manualRulesDeleteCmd = &cobra.Command{
+	Use:   "delete [rule-id...]",
+	Short: "Delete program-based automatic recording rules",
+	Long: `Delete one or more program-based automatic recording rules by ID.
+
+To find the rule ID, use 'epgtimer manual-rules list'.
+
+Example:
+  epgtimer manual-rules delete 1
+  epgtimer manual-rules delete --id 1,2`,
+	RunE: runManualRulesDelete,
+}
+
+func init() {
+	rootCmd.AddCommand(manualRulesCmd)
+	manualRulesCmd.AddCommand(manualRulesListCmd)
+	manualRulesCmd.AddCommand(manualRulesAddCmd)
+	manualRulesCmd.AddCommand(manualRulesDeleteCmd)
+
+	// List filter and export flags
+	manualRulesListCmd.Flags().String("title", "", "Filter by title (substring match, case-insensitive)")
+	manualRulesListCmd.Flags().String("channel", "", "Filter by channel (ONID-TSID-SID, key:N or channel name)")
+	manualRulesListCmd.Flags().Bool("enabled", false, "Show only enabled rules")
+	manualRulesListCmd.Flags().Bool("disabled", false, "Show only disabled rules")
+	manualRulesListCmd.Flags().String("format", "table", "Output format: table, json, csv, tsv")
+	manualRulesListCmd.Flags().StringP("output", "o", "", "Output file path (default: stdout)")
+	manualRulesListCmd.MarkFlagsMutuallyExclusive("enabled", "disabled")
+
+	// Schedule flags
+	manualRulesAddCmd.Flags().String("title", "", "Title of the recordings (required)")
+	manualRulesAddCmd.Flags().String("channel", "", "Channel: ONID-TSID-SID, key:N or channel name (required)")
+	manualRulesAddCmd.Flags().StringSlice("days", []string{}, "Days of week to record (e.g., 月,火 or mon,tue or weekdays) (required)")
+	manualRulesAddCmd.Flags().String("start-time", "", "Start time (HH:MM) (required)")
+	manualRulesAddCmd.Flags().Int("duration", 0, "Recording length in minutes")
+	manualRulesAddCmd.Flags().String("end-time", "", "End time (HH:MM); before the start time means the next day")
+	manualRulesAddCmd.Flags().Bool("disabled", false, "Create the rule disabled")
+
+	// Recording option flags (shared with add)
+	addRecSettingFlags(manualRulesAddCmd)
+
+	for _, name := range []string{"title", "channel", "days", "start-time"} {
+		manualRulesAddCmd.MarkFlagRequired(name)
+	}
+	manualRulesAddCmd.MarkFlagsOneRequired("duration", "end-time")
+	manualRulesAddCmd.MarkFlagsMutuallyExclusive("duration", "end-time")
+
+	manualRulesDeleteCmd.Flags().StringSlice("id", []string{}, "Rule IDs (comma-separated)")
+}
+
+// manualRulesClient returns an API client for the configured endpoint
+func manualRulesClient(cmd *cobra.Command) (*client.Client, string, error) {
+	endpoint, err := GetEMWUIEndpoint(cmd)
+	if err != nil {
+		return nil, "", fmt.Errorf("configuration error: %w\n\nPlease set EMWUI_ENDPOINT environment variable:\n  export EMWUI_ENDPOINT=http://localhost:5510\n\nOr use --endpoint flag:\n  epgtimer manual-rules %s --endpoint http://localhost:5510 ...", err, cmd.Name())
+	}
+	c, err := newClient(cmd, endpoint)
+	if err != nil {
+		return nil, "", err
+	}
+	return c, endpoint, nil
+}
+
+func runManualRulesList(cmd *cobra.Command, args []string) error {
+	// Get EMWUI endpoints from flag, environment variables or config profile
+	endpoints, err := GetEMWUIEndpoints(cmd)
+	if err != nil {
+		return fmt.Errorf("EMWUI endpoint not configured\n\nPlease set the endpoint using:\n  1. --endpoint flag: epgtimer manual-rules list --endpoint http://192.168.1.10:5510\n  2. EMWUI_ENDPOINT environment variable: export EMWUI_ENDPOINT=http://192.168.1.10:5510\n  3. a profile in ~/.config/epgtimer/config.yaml: epgtimer manual-rules list --profile living-room")
+	}
+
+	// Resolve a channel name or key:N given to --channel against each server's channel list
+	selections, err := resolveChannelSelections(cmd, endpoints)
+	if err != nil {
+		return err
+	}
+
+	// Retrieve rules from every server
+	items, failed, err := fetchFromServers(cmd, endpoints,
+		func(ctx context.Context, c *client.Client) ([]models.ManualAutoAddRule, error) {
+			response, err := c.EnumManuAdd(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return response.Items, nil
+		},
+		func(rule *models.ManualAutoAddRule, server string) { rule.Server = server })
+	if err != nil {
+		return err
+	}
+
+	rules := applyManualRuleFilters(cmd, items, selections)
+	if len(rules) == 0 && len(items) > 0 {
+		fmt.Println("No program-based recording rules match the specified filters.")
+		return serversError(failed, len(endpoints))
+	}
+
+	// Select appropriate formatter
+	var formatter interface {
+		Format([]models.ManualAutoAddRule) (string, error)
+	}
+
+	format := outputFormat(cmd)
+	switch format {
+	case "json":
+		formatter = &formatters.ManualRulesJSONFormatter{}
+	case "csv":
+		formatter = &formatters.ManualRulesCSVFormatter{}
+	case "tsv":
+		formatter = &formatters.ManualRulesTSVFormatter{}
+	case "table":
+		formatter = &formatters.ManualRulesTableFormatter{}
+	default:
+		return fmt.Errorf("unsupported format '%s'. Supported formats: table, json, csv, tsv", format)
+	}
+
+	output, err := formatter.Format(rules)
+	if err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	// Write to file or stdout
+	outputPath, _ := cmd.Flags().GetString("output")
+	if outputPath != "" {
+		if err := os.WriteFile(outputPath, []byte(output), 0644); err != nil {
+			return fmt.Errorf("failed to write output to file '%s': %w", outputPath, err)
+		}
+		fmt.Printf("Successfully exported %d rules to %s\n", len(rules), outputPath)
+	} else {
+		fmt.Print(output)
+	}
+
+	return serversError(failed, len(endpoints))
+}
+
+// applyManualRuleFilters returns the rules matching the list filter flags
+func applyManualRuleFilters(cmd *cobra.Command, rules []models.ManualAutoAddRule, selections channelSelections) []models.ManualAutoAddRule {
+	title, _ := cmd.Flags().GetString("title")
+	enabled, _ := cmd.Flags().GetBool("enabled")
+	disabled, _ := cmd.Flags().GetBool("disabled")
+
+	filtered := make([]models.ManualAutoAddRule, 0, len(rules))
+	for _, rule := range rules {
+		if title != "" && !strings.Contains(strings.ToLower(rule.Title), strings.ToLower(title)) {
+			continue
+		}
+		if !selections.matches(rule.Server, rule.ChannelID()) {
+			continue
+		}
+		if (enabled && !rule.IsEnabled()) || (disabled && rule.IsEnabled()) {
+			continue
+		}
+		filtered = append(filtered, rule)
+	}
+	return filtered
+}
+
+func runManualRulesAdd(cmd *cobra.Command, args []string) error {
+	title, _ := cmd.Flags().GetString("title")
+	channel, _ := cmd.Flags().GetString("channel")
+	dayNames, _ := cmd.Flags().GetStringSlice("days")
+	startTime, _ := cmd.Flags().GetString("start-time")
+
+	days, err := models.ParseDaysOfWeek(dayNames)
+	if err != nil {
+		return fmt.Errorf("invalid --days: %w", err)
+	}
+	if len(days) == 0 {
+		return fmt.Errorf("--days must list at least one day of week")
+	}
+
+	start, err := models.ParseTimeOfDay(startTime)
+	if err != nil {
+		return fmt.Errorf("invalid --start-time: %w", err)
+	}
+
+	var duration int
+	if cmd.Flags().Changed("end-time") {
+		endTime, _ := cmd.Flags().GetString("end-time")
+		end, err := models.ParseTimeOfDay(endTime)
+		if err != nil {
+			return fmt.Errorf("invalid --end-time: %w", err)
+		}
+		duration = (end - start + 24*60*60) % (24 * 60 * 60)
+		if duration == 0 {
+			return fmt.Errorf("--end-time must differ from --start-time")
+		}
+	} else {
+		minutes, _ := cmd.Flags().GetInt("duration")
+		duration = minutes * 60
+	}
+
+	c, endpoint, err := manualRulesClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	id, err := resolveChannelRef(cmd, c, endpoint, channel)
+	if err != nil {
+		return err
+	}
+	ch, err := models.ParseServiceListEntry(id)
+	if err != nil {
+		return fmt.Errorf("invalid channel format: %w\n\nExpected format: ONID-TSID-SID (e.g., \"32736-32736-1024\")", err)
+	}
+
+	// Create request
+	req := models.NewManualAutoAddRuleRequest(title, ch.ONID, ch.TSID, ch.SID, days, start, duration)
+	if err := applyRecSettingFlags(cmd, &req.RecSettingRequest); err != nil {
+		return err
+	}
+	if disabled, _ := cmd.Flags().GetBool("disabled"); disabled {
+		req.SetDisabled(true)
+	}
+
+	if err := req.Validate(); err != nil {
+		return fmt.Errorf("validation error: %w", err)
+	}
+
+	// Call API
+	if _, err := c.SetManuAdd(cmd.Context(), req); err != nil {
+		return apiError(err, endpoint, "create program-based rule")
+	}
+
+	// Success
+	rule := models.ManualAutoAddRule{DayOfWeekFlag: req.DayOfWeekFlag, StartTime: req.StartTime, DurationSecond: req.DurationSecond}
+	fmt.Println("✓ Program-based recording rule created successfully")
+	fmt.Printf("\nTitle:   %s\n", req.Title)
+	fmt.Printf("Days:    %s\n", rule.DaysString())
+	fmt.Printf("Time:    %s-%s (%d min)\n", rule.StartTimeString(), rule.EndTimeString(), rule.DurationMinutes())
+	fmt.Printf("Channel: %s\n", req.ChannelID())
+
+	return nil
+}
+
+func runManualRulesDelete(cmd *cobra.Command, args []string) error {
+	flagIDs, _ := cmd.Flags().GetStringSlice("id")
+	ids, err := parseIDList(append(append([]string{}, args...), flagIDs...))
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return fmt.Errorf("rule ID is required\n\nUsage:\n  epgtimer manual-rules delete [rule-id...]\n  epgtimer manual-rules delete --id 1,2\n\nTo find rule IDs, run:\n  epgtimer manual-rules list")
+	}
+
+	c, endpoint, err := manualRulesClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, id := range ids {
+		if _, err := c.DeleteManuAdd(cmd.Context(), id); err != nil {
+			if len(ids) == 1 {
+				return apiError(err, endpoint, "delete program-based rule")
+			}
+			fmt.Printf("✗ Failed to delete rule %d: %v\n", id, err)
+			failed++
+			continue
+		}
+		fmt.Printf("✓ Program-based recording rule (ID: %d) deleted successfully\n", id)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d rules could not be deleted", failed, len(ids))
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/client"
+	"github.com/epy0n0ff/epgtimer-cli/internal/formatters"
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+	"github.com/spf13/cobra"
+)
+
+var presetsCmd = &cobra.Command{
+	Use:   "presets",
+	Short: "List recording presets",
+	Long: `Retrieve and display the recording presets configured in EpgTimer.
+
+A preset is a named set of recording settings (recording mode, priority, margins,
+recording folders, ...). Use its name or ID with 'epgtimer add --preset' to create
+a rule with these settings. Preset 0 holds EpgTimer's default settings.
+
+Output Formats:
+  table  - Human-readable table format (default)
+  json   - JSON format with the full recording settings
+  csv    - Comma-separated values
+  tsv    - Tab-separated values
+
+Examples:
+  # List presets
+  epgtimer presets
+
+  # Show every setting of the presets
+  epgtimer presets --format json`,
+	RunE: runPresets,
+}
+
+func init() {
+	rootCmd.AddCommand(presetsCmd)
+
+	// Export flags
+	presetsCmd.Flags().String("format", "table", "Output format: table, json, csv, tsv")
+	presetsCmd.Flags().StringP("output", "o", "", "Output file path (default: stdout)")
+}
+
+func runPresets(cmd *cobra.Command, args []string) error {
+	// Get EMWUI endpoint
+	endpoint, err := GetEMWUIEndpoint(cmd)
+	if err != nil {
+		return fmt.Errorf("configuration error: %w\n\nPlease set EMWUI_ENDPOINT environment variable:\n  export EMWUI_ENDPOINT=http://localhost:5510\n\nOr use --endpoint flag:\n  epgtimer presets --endpoint http://localhost:5510", err)
+	}
+
+	c, err := newClient(cmd, endpoint)
+	if err != nil {
+		return err
+	}
+
+	response, err := c.EnumRecPreset(cmd.Context())
+	if err != nil {
+		return formatConnectionError(err, endpoint)
+	}
+
+	// Select appropriate formatter
+	var formatter interface {
+		Format([]models.RecPreset) (string, error)
+	}
+
+	format := outputFormat(cmd)
+	switch format {
+	case "json":
+		formatter = &formatters.PresetsJSONFormatter{}
+	case "csv":
+		formatter = &formatters.PresetsCSVFormatter{}
+	case "tsv":
+		formatter = &formatters.PresetsTSVFormatter{}
+	case "table":
+		formatter = &formatters.PresetsTableFormatter{}
+	default:
+		return fmt.Errorf("unsupported format '%s'. Supported formats: table, json, csv, tsv", format)
+	}
+
+	output, err := formatter.Format(response.Items)
+	if err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	// Write to file or stdout
+	outputPath, _ := cmd.Flags().GetString("output")
+	if outputPath != "" {
+		if err := os.WriteFile(outputPath, []byte(output), 0644); err != nil {
+			return fmt.Errorf("failed to write output to file '%s': %w", outputPath, err)
+		}
+		fmt.Printf("Successfully exported %d presets to %s\n", len(response.Items), outputPath)
+	} else {
+		fmt.Print(output)
+	}
+
+	return nil
+}
+
+// resolveRecPreset looks up the preset given by name or ID on the server
+func resolveRecPreset(cmd *cobra.Command, c *client.Client, endpoint string, ref string) (*models.RecPreset, error) {
+	response, err := c.EnumRecPreset(cmd.Context())
+	if err != nil {
+		return nil, formatConnectionError(err, endpoint)
+	}
+
+	preset, err := models.FindRecPreset(response.Items, ref)
+	if err != nil {
+		names := make([]string, 0, len(response.Items))
+		for _, p := range response.Items {
+			names = append(names, fmt.Sprintf("  %d  %s", p.ID, p.Name))
+		}
+		return nil, fmt.Errorf("invalid --preset: %w\n\nAvailable presets:\n%s", err, strings.Join(names, "\n"))
+	}
+	return preset, nil
+}
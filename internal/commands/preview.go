@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/client"
+	"github.com/epy0n0ff/epgtimer-cli/internal/formatters"
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// previewEPG is the EPG of the channels of one or more rules, used to preview their matches
+type previewEPG struct {
+	events   []models.EventInfo
+	channels int // Channels whose EPG was requested
+	failed   int // Channels whose EPG could not be retrieved
+}
+
+// fetchPreviewEPG retrieves the EPG of every channel in the search settings (through the EPG cache)
+func fetchPreviewEPG(cmd *cobra.Command, c *client.Client, endpoint string, settings []*models.SearchSettings) (*previewEPG, error) {
+	seen := make(map[string]bool)
+	var channels []*models.ServiceListEntry
+	for _, s := range settings {
+		for _, service := range s.ServiceList {
+			if seen[service.String()] {
+				continue
+			}
+			seen[service.String()] = true
+			channels = append(channels, &models.ServiceListEntry{ONID: service.ONID, TSID: service.TSID, SID: service.SID})
+		}
+	}
+
+	preview := &previewEPG{channels: len(channels)}
+	if len(channels) == 0 {
+		return preview, nil
+	}
+
+	fetchOpts, err := epgBatchOptions(cmd)
+	if err != nil {
+		return nil, err
+	}
+	source, err := newEPGSource(cmd, c, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	results := source.epg(cmd, channels, fetchOpts)
+	preview.events, preview.failed = mergeEPGResults(results)
+	if preview.failed == len(channels) {
+		return nil, epgRetrievalError(results[0], endpoint)
+	}
+	return preview, nil
+}
+
+// matches returns the events matching the search settings in chronological order
+func (p *previewEPG) matches(settings *models.SearchSettings) ([]models.EventInfo, error) {
+	matcher, err := models.NewEventMatcher(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []models.EventInfo
+	for i := range p.events {
+		if matcher.Matches(&p.events[i]) {
+			matched = append(matched, p.events[i])
+		}
+	}
+	sortEventsByStart(matched)
+	return matched, nil
+}
+
+// printRulePreview prints the programs a rule matches as a table with a channel column
+func printRulePreview(events []models.EventInfo) error {
+	if len(events) == 0 {
+		fmt.Println("No programs in the current EPG match the rule.")
+		return nil
+	}
+
+	formatter := &formatters.EPGTableFormatter{ShowChannel: true}
+	output, err := formatter.Format(events)
+	if err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+	fmt.Print(output)
+	return nil
+}
+
+// sortEventsByStart sorts events by start time; events starting at the same time keep their order
+func sortEventsByStart(events []models.EventInfo) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].StartDate+events[i].StartTime < events[j].StartDate+events[j].StartTime
+	})
+}
@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// profile is the configuration profile selected for the running command
+// It is set by loadProfile before the command runs and is never nil.
+var profile = &models.Profile{}
+
+// profileSelected is true if the profile was chosen with --profile or EPGTIMER_PROFILE
+// The settings of such a profile override the environment variables; the settings of
+// the defaultProfile do not.
+var profileSelected bool
+
+// channelGroups are the channel groups ("@name") available to the running command
+// It is set by loadProfile: the built-in groups plus the groups of the config file and profile.
+var channelGroups = models.BuiltinChannelGroups
+
+// loadProfile reads the config file and selects the profile given by --profile,
+// EPGTIMER_PROFILE or the file's defaultProfile
+// A missing config file is not an error unless it was given explicitly or a profile was requested.
+func loadProfile(cmd *cobra.Command, args []string) error {
+	configPath := stringFlagOrEnv(cmd, "config", "EPGTIMER_CONFIG")
+	explicit := configPath != ""
+	if !explicit {
+		var err error
+		configPath, err = models.DefaultConfigPath()
+		if err != nil {
+			return fmt.Errorf("configuration error: %w", err)
+		}
+	}
+
+	name := stringFlagOrEnv(cmd, "profile", "EPGTIMER_PROFILE")
+	profileSelected = name != ""
+
+	config, err := models.LoadConfig(configPath)
+	if errors.Is(err, fs.ErrNotExist) && !explicit && name == "" {
+		profile = &models.Profile{}
+		channelGroups = models.BuiltinChannelGroups
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	selected, err := config.Profile(name)
+	if err != nil {
+		return fmt.Errorf("configuration error: %w\n\nProfiles are defined in %s", err, configPath)
+	}
+	channelGroups = config.ChannelGroupsFor(selected)
+	if selected == nil {
+		selected = &models.Profile{}
+	}
+	profile = selected
+
+	return nil
+}
+
+// outputFormat returns the --format flag, or the profile's default format if the flag is not given
+func outputFormat(cmd *cobra.Command) string {
+	format, _ := cmd.Flags().GetString("format")
+	if !cmd.Flags().Changed("format") && profile.Format != "" {
+		return profile.Format
+	}
+	return format
+}
+
+// stringSetting returns the flag value, falling back to the environment variable and then the profile value
+// The value of a profile selected with --profile or EPGTIMER_PROFILE takes precedence over the environment variable.
+func stringSetting(cmd *cobra.Command, name string, envVar string, profileValue string) string {
+	if value, err := cmd.Flags().GetString(name); err == nil && value != "" {
+		return value
+	}
+	if profileSelected && profileValue != "" {
+		return profileValue
+	}
+	if value := os.Getenv(envVar); value != "" {
+		return value
+	}
+	return profileValue
+}
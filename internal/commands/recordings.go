@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -40,6 +41,9 @@ Examples:
   # List recordings from a specific EMWUI server
   epgtimer recordings --endpoint http://192.168.1.10:5510
 
+  # Compare recordings of two servers (adds a Server column)
+  epgtimer recordings --endpoint http://192.168.1.10:5510,http://192.168.1.20:5510
+
   # Filter by title
   epgtimer recordings --title "ニュース"
 
@@ -62,7 +66,7 @@ func init() {
 	// Filter flags
 	recordingsCmd.Flags().String("title", "", "Filter by title (substring match, case-insensitive)")
 	recordingsCmd.Flags().String("station", "", "Filter by station name (substring match, case-insensitive)")
-	recordingsCmd.Flags().String("channel", "", "Filter by channel ID (exact match, format: ONID-TSID-SID)")
+	recordingsCmd.Flags().String("channel", "", "Filter by channel (ONID-TSID-SID, key:N or channel name)")
 	recordingsCmd.Flags().Bool("protected", false, "Show only protected recordings")
 
 	// Export flags
@@ -71,40 +75,43 @@ func init() {
 }
 
 func runRecordings(cmd *cobra.Command, args []string) error {
-	// Get EMWUI endpoint
-	endpoint, err := cmd.Flags().GetString("endpoint")
+	// Get EMWUI endpoints from flag, environment variables or config profile
+	endpoints, err := GetEMWUIEndpoints(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to get endpoint flag: %w", err)
-	}
-
-	if endpoint == "" {
-		endpoint = os.Getenv("EMWUI_ENDPOINT")
+		return fmt.Errorf("EMWUI endpoint not configured\n\nPlease set the endpoint using:\n  1. --endpoint flag: epgtimer recordings --endpoint http://192.168.1.10:5510\n  2. EMWUI_ENDPOINT environment variable: export EMWUI_ENDPOINT=http://192.168.1.10:5510\n  3. a profile in ~/.config/epgtimer/config.yaml: epgtimer recordings --profile living-room")
 	}
 
-	if endpoint == "" {
-		return fmt.Errorf("EMWUI endpoint not configured\n\nPlease set the endpoint using:\n  1. --endpoint flag: epgtimer recordings --endpoint http://192.168.1.10:5510\n  2. EMWUI_ENDPOINT environment variable: export EMWUI_ENDPOINT=http://192.168.1.10:5510")
+	// Resolve a channel name or key:N given to --channel against each server's channel list
+	selections, err := resolveChannelSelections(cmd, endpoints)
+	if err != nil {
+		return err
 	}
 
-	// Create API client
-	apiClient := client.NewClient(endpoint)
-
-	// Retrieve recordings
-	response, err := apiClient.EnumRecInfo()
+	// Retrieve recordings from every server
+	items, failed, err := fetchFromServers(cmd, endpoints,
+		func(ctx context.Context, c *client.Client) ([]models.RecordingInfo, error) {
+			response, err := c.EnumRecInfo(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return response.Items, nil
+		},
+		func(rec *models.RecordingInfo, server string) { rec.Server = server })
 	if err != nil {
-		return formatConnectionError(err, endpoint)
+		return err
 	}
 
 	// Apply filters
-	filteredRecordings := applyRecordingFilters(cmd, response.Items)
+	filteredRecordings := applyRecordingFilters(cmd, items, selections)
 
 	// Handle empty results
 	if len(filteredRecordings) == 0 {
 		fmt.Println("No recordings match the specified filters.")
-		return nil
+		return serversError(failed, len(endpoints))
 	}
 
 	// Get format flag
-	format, _ := cmd.Flags().GetString("format")
+	format := outputFormat(cmd)
 
 	// Format recordings
 	var output string
@@ -139,13 +146,12 @@ func runRecordings(cmd *cobra.Command, args []string) error {
 		fmt.Print(output)
 	}
 
-	return nil
+	return serversError(failed, len(endpoints))
 }
 
-func applyRecordingFilters(cmd *cobra.Command, recordings []models.RecordingInfo) []models.RecordingInfo {
+func applyRecordingFilters(cmd *cobra.Command, recordings []models.RecordingInfo, selections channelSelections) []models.RecordingInfo {
 	title, _ := cmd.Flags().GetString("title")
 	station, _ := cmd.Flags().GetString("station")
-	channel, _ := cmd.Flags().GetString("channel")
 	protected, _ := cmd.Flags().GetBool("protected")
 
 	var filtered []models.RecordingInfo
@@ -169,10 +175,8 @@ func applyRecordingFilters(cmd *cobra.Command, recordings []models.RecordingInfo
 		}
 
 		// Channel filter
-		if channel != "" {
-			if rec.ChannelID() != channel {
-				continue
-			}
+		if !selections.matches(rec.Server, rec.ChannelID()) {
+			continue
 		}
 
 		// Protected filter
@@ -191,8 +195,11 @@ func formatRecordingsAsJSON(recordings []models.RecordingInfo) string {
 	var sb strings.Builder
 	sb.WriteString("[\n")
 	for i, rec := range recordings {
-		sb.WriteString(fmt.Sprintf(`  {
-    "id": %d,
+		sb.WriteString("  {\n")
+		if rec.Server != "" {
+			sb.WriteString(fmt.Sprintf("    \"server\": \"%s\",\n", escapeJSON(rec.Server)))
+		}
+		sb.WriteString(fmt.Sprintf(`    "id": %d,
     "title": "%s",
     "start_date": "%s",
     "start_time": "%s",
@@ -237,8 +244,15 @@ func formatRecordingsAsJSON(recordings []models.RecordingInfo) string {
 // Temporary CSV formatter for recordings
 func formatRecordingsAsCSV(recordings []models.RecordingInfo) string {
 	var sb strings.Builder
+	showServer := len(recordings) > 0 && recordings[0].Server != ""
+	if showServer {
+		sb.WriteString("Server,")
+	}
 	sb.WriteString("ID,Title,StartDate,StartTime,DurationMinutes,StationName,ChannelID,ONID,TSID,SID,EventID,Comment,RecFilePath,Protected\n")
 	for _, rec := range recordings {
+		if showServer {
+			sb.WriteString(rec.Server + ",")
+		}
 		sb.WriteString(fmt.Sprintf("%d,%s,%s,%s,%d,%s,%s,%d,%d,%d,%d,%s,%s,%t\n",
 			rec.ID,
 			escapeCSV(rec.Title),
@@ -262,8 +276,15 @@ func formatRecordingsAsCSV(recordings []models.RecordingInfo) string {
 // Temporary TSV formatter for recordings
 func formatRecordingsAsTSV(recordings []models.RecordingInfo) string {
 	var sb strings.Builder
+	showServer := len(recordings) > 0 && recordings[0].Server != ""
+	if showServer {
+		sb.WriteString("Server\t")
+	}
 	sb.WriteString("ID\tTitle\tStartDate\tStartTime\tDurationMinutes\tStationName\tChannelID\tONID\tTSID\tSID\tEventID\tComment\tRecFilePath\tProtected\n")
 	for _, rec := range recordings {
+		if showServer {
+			sb.WriteString(rec.Server + "\t")
+		}
 		sb.WriteString(fmt.Sprintf("%d\t%s\t%s\t%s\t%d\t%s\t%s\t%d\t%d\t%d\t%d\t%s\t%s\t%t\n",
 			rec.ID,
 			escapeTSV(rec.Title),
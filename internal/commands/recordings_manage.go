@@ -0,0 +1,286 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/client"
+	"github.com/epy0n0ff/epgtimer-cli/internal/formatters"
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+	"github.com/spf13/cobra"
+)
+
+var recordingsProtectCmd = &cobra.Command{
+	Use:   "protect [recording-id...]",
+	Short: "Protect recordings from deletion",
+	Long: `Protect one or more recorded programs from deletion.
+
+Recordings can be selected by ID, by the same filters as 'epgtimer recordings',
+or both (filters then narrow down the given IDs).
+
+Example:
+  epgtimer recordings protect 2001
+  epgtimer recordings protect --title "ブラタモリ"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRecordingsSetProtect(cmd, args, true)
+	},
+}
+
+var recordingsUnprotectCmd = &cobra.Command{
+	Use:   "unprotect [recording-id...]",
+	Short: "Remove deletion protection from recordings",
+	Long: `Remove deletion protection from one or more recorded programs.
+
+Example:
+  epgtimer recordings unprotect 2002
+  epgtimer recordings unprotect --id 2001,2002`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRecordingsSetProtect(cmd, args, false)
+	},
+}
+
+var recordingsDeleteCmd = &cobra.Command{
+	Use:   "delete [recording-id...]",
+	Short: "Delete recordings",
+	Long: `Delete one or more recorded programs.
+
+Recordings can be selected by ID, by the same filters as 'epgtimer recordings',
+or both. Protected recordings are never deleted; unprotect them first.
+
+The matching recordings are listed and you are asked for confirmation.
+Use --yes to skip the prompt (e.g., from cron) and --dry-run to only list them.
+
+Whether the recorded file itself is removed depends on EpgTimer's settings.
+
+Example:
+  # Delete a single recording
+  epgtimer recordings delete 2001
+
+  # Delete news recordings older than two weeks without prompting
+  epgtimer recordings delete --title "ニュース" --older-than 14d --yes
+
+  # Show what would be deleted
+  epgtimer recordings delete --station "NHK" --older-than 30d --dry-run`,
+	RunE: runRecordingsDelete,
+}
+
+func init() {
+	recordingsCmd.AddCommand(recordingsProtectCmd)
+	recordingsCmd.AddCommand(recordingsUnprotectCmd)
+	recordingsCmd.AddCommand(recordingsDeleteCmd)
+
+	for _, cmd := range []*cobra.Command{recordingsProtectCmd, recordingsUnprotectCmd, recordingsDeleteCmd} {
+		cmd.Flags().StringSlice("id", []string{}, "Recording IDs (comma-separated)")
+		cmd.Flags().String("title", "", "Select by title (substring match, case-insensitive)")
+		cmd.Flags().String("station", "", "Select by station name (substring match, case-insensitive)")
+		cmd.Flags().String("channel", "", "Select by channel (ONID-TSID-SID, key:N or channel name)")
+		cmd.Flags().String("older-than", "", "Select recordings that started before this age (e.g., 14d, 2w, 36h)")
+	}
+
+	recordingsDeleteCmd.Flags().Bool("dry-run", false, "List the recordings that would be deleted without deleting them")
+	recordingsDeleteCmd.Flags().BoolP("yes", "y", false, "Delete without asking for confirmation")
+}
+
+// selectRecordings returns the recordings chosen by positional IDs, --id and the filter flags
+func selectRecordings(cmd *cobra.Command, args []string, c *client.Client, endpoint string) ([]models.RecordingInfo, error) {
+	flagIDs, _ := cmd.Flags().GetStringSlice("id")
+	ids, err := parseIDList(append(append([]string{}, args...), flagIDs...))
+	if err != nil {
+		return nil, err
+	}
+
+	var olderThan time.Duration
+	if value, _ := cmd.Flags().GetString("older-than"); value != "" {
+		olderThan, err = models.ParseAge(value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	hasFilters := olderThan > 0
+	for _, name := range []string{"title", "station", "channel"} {
+		if value, _ := cmd.Flags().GetString(name); value != "" {
+			hasFilters = true
+		}
+	}
+
+	if len(ids) == 0 && !hasFilters {
+		return nil, fmt.Errorf("no recordings selected\n\nUsage:\n  epgtimer recordings %s [recording-id...]\n  epgtimer recordings %s --id 2001,2002\n  epgtimer recordings %s --title \"ニュース\" --older-than 14d\n\nTo find recording IDs, run:\n  epgtimer recordings", cmd.Name(), cmd.Name(), cmd.Name())
+	}
+
+	selections, err := resolveChannelSelections(cmd, []string{endpoint})
+	if err != nil {
+		return nil, err
+	}
+
+	all, err := c.EnumAllRecInfo(cmd.Context())
+	if err != nil {
+		return nil, formatConnectionError(err, endpoint)
+	}
+
+	recordings := all
+	if len(ids) > 0 {
+		byID := make(map[int]models.RecordingInfo)
+		for _, rec := range all {
+			byID[rec.ID] = rec
+		}
+
+		recordings = nil
+		var missing []string
+		for _, id := range ids {
+			rec, ok := byID[id]
+			if !ok {
+				missing = append(missing, fmt.Sprintf("%d", id))
+				continue
+			}
+			recordings = append(recordings, rec)
+		}
+		if len(missing) > 0 {
+			return nil, fmt.Errorf("recording ID %s not found\n\nTo find recording IDs, run:\n  epgtimer recordings", strings.Join(missing, ", "))
+		}
+	}
+
+	recordings = applyRecordingFilters(cmd, recordings, selections)
+
+	if olderThan > 0 {
+		now := time.Now()
+		var old []models.RecordingInfo
+		for _, rec := range recordings {
+			if rec.IsOlderThan(olderThan, now) {
+				old = append(old, rec)
+			}
+		}
+		recordings = old
+	}
+
+	return recordings, nil
+}
+
+// recordingsManageClient returns an API client for the configured endpoint
+func recordingsManageClient(cmd *cobra.Command) (*client.Client, string, error) {
+	endpoint, err := GetEMWUIEndpoint(cmd)
+	if err != nil {
+		return nil, "", fmt.Errorf("configuration error: %w\n\nPlease set EMWUI_ENDPOINT environment variable:\n  export EMWUI_ENDPOINT=http://localhost:5510\n\nOr use --endpoint flag:\n  epgtimer recordings %s --endpoint http://localhost:5510 2001", err, cmd.Name())
+	}
+	c, err := newClient(cmd, endpoint)
+	if err != nil {
+		return nil, "", err
+	}
+	return c, endpoint, nil
+}
+
+func runRecordingsSetProtect(cmd *cobra.Command, args []string, protect bool) error {
+	c, endpoint, err := recordingsManageClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	recordings, err := selectRecordings(cmd, args, c, endpoint)
+	if err != nil {
+		return err
+	}
+
+	if len(recordings) == 0 {
+		fmt.Println("No recordings match the specified filters.")
+		return nil
+	}
+
+	action := "unprotect"
+	if protect {
+		action = "protect"
+	}
+
+	failed := 0
+	for _, rec := range recordings {
+		if rec.IsProtected() == protect {
+			fmt.Printf("- Recording (ID: %d) is already %sed: %s\n", rec.ID, action, rec.Title)
+			continue
+		}
+
+		if _, err := c.SetRecProtect(cmd.Context(), rec.ID, protect); err != nil {
+			if len(recordings) == 1 {
+				return apiError(err, endpoint, action+" recording")
+			}
+			fmt.Printf("✗ Failed to %s recording %d: %v\n", action, rec.ID, err)
+			failed++
+			continue
+		}
+		fmt.Printf("✓ Recording (ID: %d) %sed: %s\n", rec.ID, action, rec.Title)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d recordings could not be %sed", failed, len(recordings), action)
+	}
+	return nil
+}
+
+func runRecordingsDelete(cmd *cobra.Command, args []string) error {
+	c, endpoint, err := recordingsManageClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	selected, err := selectRecordings(cmd, args, c, endpoint)
+	if err != nil {
+		return err
+	}
+
+	// Protected recordings are never deleted
+	var recordings []models.RecordingInfo
+	for _, rec := range selected {
+		if rec.IsProtected() {
+			fmt.Printf("- Skipping protected recording (ID: %d): %s\n", rec.ID, rec.Title)
+			continue
+		}
+		recordings = append(recordings, rec)
+	}
+
+	if len(recordings) == 0 {
+		fmt.Println("No recordings to delete.")
+		return nil
+	}
+
+	formatter := &formatters.RecordingsTableFormatter{}
+	preview, err := formatter.Format(recordings)
+	if err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+	fmt.Print(preview)
+
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if dryRun {
+		fmt.Println("\nDry run: no recordings were deleted.")
+		return nil
+	}
+
+	yes, _ := cmd.Flags().GetBool("yes")
+	if !yes {
+		ok, err := confirm(cmd.InOrStdin(), fmt.Sprintf("\nDelete %d recordings?", len(recordings)))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	failed := 0
+	for _, rec := range recordings {
+		if _, err := c.DeleteRecInfo(cmd.Context(), rec.ID); err != nil {
+			if len(recordings) == 1 {
+				return apiError(err, endpoint, "delete recording")
+			}
+			fmt.Printf("✗ Failed to delete recording %d: %v\n", rec.ID, err)
+			failed++
+			continue
+		}
+		fmt.Printf("✓ Recording (ID: %d) deleted: %s\n", rec.ID, rec.Title)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d recordings could not be deleted", failed, len(recordings))
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -37,17 +38,34 @@ Examples:
   # List reservations from a specific EMWUI server
   epgtimer reservations --endpoint http://192.168.1.10:5510
 
+  # Compare reservations of two servers (adds a Server column)
+  epgtimer reservations --endpoint http://192.168.1.10:5510,http://192.168.1.20:5510
+
   # Filter by title
   epgtimer reservations --title "ニュース"
 
   # Filter by station
   epgtimer reservations --station "NHK"
 
+  # Filter by channel group
+  epgtimer reservations --channels @terrestrial
+
   # Export to JSON file
   epgtimer reservations --format json --output reservations.json
 
   # Export to CSV
   epgtimer reservations --format csv -o reservations.csv
+
+  # Which rule reserved this program?
+  epgtimer reservations --title "再放送" --explain
+
+Explaining Reservations:
+  --explain adds a Source column: the automatic recording rules whose search settings
+  match the reservation's title, channel, day/time and duration ("#ID andKey"), or
+  "manual". A reservation EpgTimer created automatically that no current rule matches
+  (e.g., the rule was changed or deleted) is shown as "auto-add (no matching rule)".
+  Reservations only carry the title, so rules matching program descriptions (--full-text)
+  may not be found.
 `,
 	RunE: runReservations,
 }
@@ -56,7 +74,11 @@ func init() {
 	// Filter flags
 	reservationsCmd.Flags().String("title", "", "Filter by title (substring match, case-insensitive)")
 	reservationsCmd.Flags().String("station", "", "Filter by station name (substring match, case-insensitive)")
-	reservationsCmd.Flags().String("channel", "", "Filter by channel ID (exact match, format: ONID-TSID-SID)")
+	reservationsCmd.Flags().String("channel", "", "Filter by channel (ONID-TSID-SID, key:N or channel name)")
+	reservationsCmd.Flags().StringSlice("channels", nil, "Filter by any of these channels or channel groups (comma-separated, e.g., @bs,key:1)")
+
+	// Explain flags
+	reservationsCmd.Flags().Bool("explain", false, "Show the automatic recording rules that match each reservation (table format)")
 
 	// Export flags
 	reservationsCmd.Flags().String("format", "table", "Output format: table, json, csv, tsv")
@@ -64,46 +86,63 @@ func init() {
 }
 
 func runReservations(cmd *cobra.Command, args []string) error {
-	// Get EMWUI endpoint
-	endpoint, err := cmd.Flags().GetString("endpoint")
+	// Get EMWUI endpoints from flag, environment variables or config profile
+	endpoints, err := GetEMWUIEndpoints(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to get endpoint flag: %w", err)
+		return fmt.Errorf("EMWUI endpoint not configured\n\nPlease set the endpoint using:\n  1. --endpoint flag: epgtimer reservations --endpoint http://192.168.1.10:5510\n  2. EMWUI_ENDPOINT environment variable: export EMWUI_ENDPOINT=http://192.168.1.10:5510\n  3. a profile in ~/.config/epgtimer/config.yaml: epgtimer reservations --profile living-room")
 	}
 
-	if endpoint == "" {
-		endpoint = os.Getenv("EMWUI_ENDPOINT")
-	}
-
-	if endpoint == "" {
-		return fmt.Errorf("EMWUI endpoint not configured\n\nPlease set the endpoint using:\n  1. --endpoint flag: epgtimer reservations --endpoint http://192.168.1.10:5510\n  2. EMWUI_ENDPOINT environment variable: export EMWUI_ENDPOINT=http://192.168.1.10:5510")
+	// Resolve channel names, key:N and groups given to --channel/--channels against each server's channel list
+	selections, err := resolveChannelSelections(cmd, endpoints)
+	if err != nil {
+		return err
 	}
 
-	// Create API client
-	apiClient := client.NewClient(endpoint)
-
-	// Retrieve reservations
-	response, err := apiClient.EnumReserveInfo()
+	// Retrieve reservations from every server
+	items, failed, err := fetchFromServers(cmd, endpoints,
+		func(ctx context.Context, c *client.Client) ([]models.ReservationInfo, error) {
+			response, err := c.EnumReserveInfo(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return response.Items, nil
+		},
+		func(res *models.ReservationInfo, server string) { res.Server = server })
 	if err != nil {
-		return formatConnectionError(err, endpoint)
+		return err
 	}
 
 	// Apply filters
-	filteredReservations := applyReservationFilters(cmd, response.Items)
+	filteredReservations := applyReservationFilters(cmd, items, selections)
 
 	// Handle empty results
 	if len(filteredReservations) == 0 {
 		fmt.Println("No reservations match the specified filters.")
-		return nil
+		return serversError(failed, len(endpoints))
 	}
 
 	// Get format flag
-	format, _ := cmd.Flags().GetString("format")
+	format := outputFormat(cmd)
+
+	// Describe why each reservation exists
+	var sources []string
+	if explain, _ := cmd.Flags().GetBool("explain"); explain {
+		if format != "table" {
+			return fmt.Errorf("--explain is only available in table format")
+		}
+		var rulesFailed int
+		sources, rulesFailed, err = explainReservations(cmd, endpoints, filteredReservations)
+		if err != nil {
+			return err
+		}
+		failed = max(failed, rulesFailed)
+	}
 
 	// Format reservations
 	var output string
 	switch format {
 	case "table":
-		formatter := &formatters.ReservationsTableFormatter{}
+		formatter := &formatters.ReservationsTableFormatter{Sources: sources}
 		output, err = formatter.Format(filteredReservations)
 	case "json":
 		output = formatReservationsAsJSON(filteredReservations)
@@ -132,13 +171,12 @@ func runReservations(cmd *cobra.Command, args []string) error {
 		fmt.Print(output)
 	}
 
-	return nil
+	return serversError(failed, len(endpoints))
 }
 
-func applyReservationFilters(cmd *cobra.Command, reservations []models.ReservationInfo) []models.ReservationInfo {
+func applyReservationFilters(cmd *cobra.Command, reservations []models.ReservationInfo, selections channelSelections) []models.ReservationInfo {
 	title, _ := cmd.Flags().GetString("title")
 	station, _ := cmd.Flags().GetString("station")
-	channel, _ := cmd.Flags().GetString("channel")
 
 	var filtered []models.ReservationInfo
 	for _, res := range reservations {
@@ -160,11 +198,9 @@ func applyReservationFilters(cmd *cobra.Command, reservations []models.Reservati
 			}
 		}
 
-		// Channel filter
-		if channel != "" {
-			if res.ChannelID() != channel {
-				continue
-			}
+		// Channel and channel set filters
+		if !selections.matches(res.Server, res.ChannelID()) {
+			continue
 		}
 
 		filtered = append(filtered, res)
@@ -178,8 +214,11 @@ func formatReservationsAsJSON(reservations []models.ReservationInfo) string {
 	var sb strings.Builder
 	sb.WriteString("[\n")
 	for i, res := range reservations {
-		sb.WriteString(fmt.Sprintf(`  {
-    "id": %d,
+		sb.WriteString("  {\n")
+		if res.Server != "" {
+			sb.WriteString(fmt.Sprintf("    \"server\": \"%s\",\n", escapeJSON(res.Server)))
+		}
+		sb.WriteString(fmt.Sprintf(`    "id": %d,
     "title": "%s",
     "start_date": "%s",
     "start_time": "%s",
@@ -220,8 +259,15 @@ func formatReservationsAsJSON(reservations []models.ReservationInfo) string {
 // Temporary CSV formatter for reservations
 func formatReservationsAsCSV(reservations []models.ReservationInfo) string {
 	var sb strings.Builder
+	showServer := len(reservations) > 0 && reservations[0].Server != ""
+	if showServer {
+		sb.WriteString("Server,")
+	}
 	sb.WriteString("ID,Title,StartDate,StartTime,DurationMinutes,StationName,ChannelID,ONID,TSID,SID,EventID,Comment\n")
 	for _, res := range reservations {
+		if showServer {
+			sb.WriteString(res.Server + ",")
+		}
 		sb.WriteString(fmt.Sprintf("%d,%s,%s,%s,%d,%s,%s,%d,%d,%d,%d,%s\n",
 			res.ID,
 			escapeCSV(res.Title),
@@ -243,8 +289,15 @@ func formatReservationsAsCSV(reservations []models.ReservationInfo) string {
 // Temporary TSV formatter for reservations
 func formatReservationsAsTSV(reservations []models.ReservationInfo) string {
 	var sb strings.Builder
+	showServer := len(reservations) > 0 && reservations[0].Server != ""
+	if showServer {
+		sb.WriteString("Server\t")
+	}
 	sb.WriteString("ID\tTitle\tStartDate\tStartTime\tDurationMinutes\tStationName\tChannelID\tONID\tTSID\tSID\tEventID\tComment\n")
 	for _, res := range reservations {
+		if showServer {
+			sb.WriteString(res.Server + "\t")
+		}
 		sb.WriteString(fmt.Sprintf("%d\t%s\t%s\t%s\t%d\t%s\t%s\t%d\t%d\t%d\t%d\t%s\n",
 			res.ID,
 			escapeTSV(res.Title),
@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/client"
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// explainReservations reads the rules of every server and describes why each reservation exists:
+// the rules that match it, or whether it was reserved manually
+// It returns the descriptions in reservation order and the number of servers whose rules could not be read.
+func explainReservations(cmd *cobra.Command, endpoints []string, reservations []models.ReservationInfo) ([]string, int, error) {
+	rules, failed, err := fetchFromServers(cmd, endpoints,
+		func(ctx context.Context, c *client.Client) ([]models.AutoAddRule, error) {
+			response, err := c.EnumAutoAdd(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return response.Items, nil
+		},
+		func(rule *models.AutoAddRule, server string) { rule.Server = server })
+	if err != nil {
+		return nil, 0, err
+	}
+
+	explainer := models.NewRuleExplainer(rules)
+	for _, err := range explainer.Errors() {
+		fmt.Fprintf(os.Stderr, "Warning: cannot evaluate %v\n", err)
+	}
+
+	sources := make([]string, len(reservations))
+	for i := range reservations {
+		sources[i] = reservationSource(&reservations[i], explainer.Explain(&reservations[i]))
+	}
+	return sources, failed, nil
+}
+
+// reservationSource describes the origin of a reservation for the Source column
+func reservationSource(res *models.ReservationInfo, rules []models.AutoAddRule) string {
+	if len(rules) == 0 {
+		if res.IsAutoReserved() {
+			return "auto-add (no matching rule)"
+		}
+		return "manual"
+	}
+
+	names := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		name := fmt.Sprintf("#%d %s", rule.ID, rule.SearchSettings.AndKey)
+		if !rule.SearchSettings.IsEnabled() {
+			name += " (disabled)"
+		}
+		names = append(names, name)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,292 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/client"
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+	"github.com/spf13/cobra"
+)
+
+var reservationsDeleteCmd = &cobra.Command{
+	Use:   "delete [reservation-id...]",
+	Short: "Delete reservations",
+	Long: `Delete one or more reservations by ID.
+
+Example:
+  # Delete a single reservation
+  epgtimer reservations delete 1001
+
+  # Delete several reservations
+  epgtimer reservations delete --id 1001,1002`,
+	RunE: runReservationsDelete,
+}
+
+var reservationsDisableCmd = &cobra.Command{
+	Use:   "disable [reservation-id...]",
+	Short: "Disable reservations without deleting them",
+	Long: `Disable one or more reservations. Disabled reservations stay in the list
+but are not recorded; 'epgtimer reservations enable' restores the original mode.
+
+Example:
+  epgtimer reservations disable 1001
+  epgtimer reservations disable --id 1001,1002`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runReservationsSetDisabled(cmd, args, true)
+	},
+}
+
+var reservationsEnableCmd = &cobra.Command{
+	Use:   "enable [reservation-id...]",
+	Short: "Re-enable disabled reservations",
+	Long: `Re-enable one or more reservations disabled with 'epgtimer reservations disable'.
+
+Example:
+  epgtimer reservations enable 1001`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runReservationsSetDisabled(cmd, args, false)
+	},
+}
+
+var reservationsSetCmd = &cobra.Command{
+	Use:   "set [reservation-id...]",
+	Short: "Change the recording settings of reservations",
+	Long: `Change the recording settings of one or more reservations.
+
+Only the flags you pass are changed; all other settings are kept.
+
+Example:
+  # Lower the priority of a reservation to resolve a tuner conflict
+  epgtimer reservations set 1001 --priority 1
+
+  # Record a minute earlier and later on a specific tuner
+  epgtimer reservations set --id 1001,1002 --margin 60 --tuner 2`,
+	RunE: runReservationsSet,
+}
+
+func init() {
+	reservationsCmd.AddCommand(reservationsDeleteCmd)
+	reservationsCmd.AddCommand(reservationsDisableCmd)
+	reservationsCmd.AddCommand(reservationsEnableCmd)
+	reservationsCmd.AddCommand(reservationsSetCmd)
+
+	for _, cmd := range []*cobra.Command{reservationsDeleteCmd, reservationsDisableCmd, reservationsEnableCmd, reservationsSetCmd} {
+		cmd.Flags().StringSlice("id", []string{}, "Reservation IDs (comma-separated)")
+	}
+
+	// Recording option flags (shared with add)
+	addRecSettingFlags(reservationsSetCmd)
+	reservationsSetCmd.Flags().Int("margin", 0, "Set both start and end margins in seconds")
+	reservationsSetCmd.MarkFlagsMutuallyExclusive("margin", "start-margin")
+	reservationsSetCmd.MarkFlagsMutuallyExclusive("margin", "end-margin")
+}
+
+// reservationIDs collects reservation IDs from positional arguments and the --id flag
+func reservationIDs(cmd *cobra.Command, args []string) ([]int, error) {
+	flagIDs, _ := cmd.Flags().GetStringSlice("id")
+
+	ids, err := parseIDList(append(append([]string{}, args...), flagIDs...))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("reservation ID is required\n\nUsage:\n  epgtimer reservations %s [reservation-id...]\n  epgtimer reservations %s --id 1001,1002\n\nTo find reservation IDs, run:\n  epgtimer reservations", cmd.Name(), cmd.Name())
+	}
+
+	return ids, nil
+}
+
+// reservationsClient returns an API client for the configured endpoint
+func reservationsClient(cmd *cobra.Command) (*client.Client, string, error) {
+	endpoint, err := GetEMWUIEndpoint(cmd)
+	if err != nil {
+		return nil, "", fmt.Errorf("configuration error: %w\n\nPlease set EMWUI_ENDPOINT environment variable:\n  export EMWUI_ENDPOINT=http://localhost:5510\n\nOr use --endpoint flag:\n  epgtimer reservations %s --endpoint http://localhost:5510 1001", err, cmd.Name())
+	}
+	c, err := newClient(cmd, endpoint)
+	if err != nil {
+		return nil, "", err
+	}
+	return c, endpoint, nil
+}
+
+// fetchReservations returns the current reservations for the given IDs
+func fetchReservations(ctx context.Context, c *client.Client, endpoint string, ids []int) (map[int]*models.ReservationInfo, error) {
+	response, err := c.EnumReserveInfo(ctx)
+	if err != nil {
+		return nil, formatConnectionError(err, endpoint)
+	}
+
+	byID := make(map[int]*models.ReservationInfo)
+	for i := range response.Items {
+		byID[response.Items[i].ID] = &response.Items[i]
+	}
+
+	var missing []string
+	for _, id := range ids {
+		if byID[id] == nil {
+			missing = append(missing, fmt.Sprintf("%d", id))
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("reservation ID %s not found\n\nTo find reservation IDs, run:\n  epgtimer reservations", strings.Join(missing, ", "))
+	}
+
+	return byID, nil
+}
+
+func runReservationsDelete(cmd *cobra.Command, args []string) error {
+	ids, err := reservationIDs(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	c, endpoint, err := reservationsClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, id := range ids {
+		if _, err := c.DeleteReserve(cmd.Context(), id); err != nil {
+			if len(ids) == 1 {
+				return apiError(err, endpoint, "delete reservation")
+			}
+			fmt.Printf("✗ Failed to delete reservation %d: %v\n", id, err)
+			failed++
+			continue
+		}
+		fmt.Printf("✓ Reservation (ID: %d) deleted successfully\n", id)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d reservations could not be deleted", failed, len(ids))
+	}
+	return nil
+}
+
+func runReservationsSetDisabled(cmd *cobra.Command, args []string, disabled bool) error {
+	ids, err := reservationIDs(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	c, endpoint, err := reservationsClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	reservations, err := fetchReservations(cmd.Context(), c, endpoint, ids)
+	if err != nil {
+		return err
+	}
+
+	action := "enable"
+	if disabled {
+		action = "disable"
+	}
+
+	failed := 0
+	for _, id := range ids {
+		res := reservations[id]
+		if res.IsDisabled() == disabled {
+			fmt.Printf("- Reservation (ID: %d) is already %sd: %s\n", id, action, res.Title)
+			continue
+		}
+
+		req := models.NewReserveRequestFromReservation(res)
+		req.SetDisabled(disabled)
+
+		if _, err := c.UpdateReserve(cmd.Context(), id, req); err != nil {
+			if len(ids) == 1 {
+				return apiError(err, endpoint, action+" reservation")
+			}
+			fmt.Printf("✗ Failed to %s reservation %d: %v\n", action, id, err)
+			failed++
+			continue
+		}
+		fmt.Printf("✓ Reservation (ID: %d) %sd: %s\n", id, action, res.Title)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d reservations could not be %sd", failed, len(ids), action)
+	}
+	return nil
+}
+
+func runReservationsSet(cmd *cobra.Command, args []string) error {
+	ids, err := reservationIDs(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	flags := cmd.Flags()
+	changed := false
+	for _, name := range []string{"priority", "rec-mode", "tuner", "start-margin", "end-margin", "margin", "suspend-mode", "bat-file", "rec-folder", "partial-rec-folder"} {
+		if flags.Changed(name) {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return fmt.Errorf("no settings to change\n\nSpecify at least one of --priority, --margin, --start-margin, --end-margin, --tuner, --rec-mode, --suspend-mode, --bat-file, --rec-folder, --partial-rec-folder")
+	}
+
+	c, endpoint, err := reservationsClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	reservations, err := fetchReservations(cmd.Context(), c, endpoint, ids)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, id := range ids {
+		res := reservations[id]
+		current := models.NewReserveRequestFromReservation(res)
+		req := models.NewReserveRequestFromReservation(res)
+
+		if err := applyRecSettingFlags(cmd, &req.RecSettingRequest); err != nil {
+			return err
+		}
+		if flags.Changed("margin") {
+			margin, _ := flags.GetInt("margin")
+			req.StartMargin = margin
+			req.EndMargin = margin
+			req.UseDefMarginFlag = 0
+		}
+
+		if err := req.RecSettingRequest.Validate(); err != nil {
+			return fmt.Errorf("validation error: %w", err)
+		}
+
+		changes := current.Diff(&req.RecSettingRequest)
+		if len(changes) == 0 {
+			fmt.Printf("- No changes to reservation (ID: %d): %s\n", id, res.Title)
+			continue
+		}
+
+		if _, err := c.UpdateReserve(cmd.Context(), id, req); err != nil {
+			if len(ids) == 1 {
+				return apiError(err, endpoint, "update reservation")
+			}
+			fmt.Printf("✗ Failed to update reservation %d: %v\n", id, err)
+			failed++
+			continue
+		}
+
+		fmt.Printf("✓ Reservation (ID: %d) updated: %s\n", id, res.Title)
+		for _, change := range changes {
+			fmt.Printf("    %s\n", change)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d reservations could not be updated", failed, len(ids))
+	}
+	return nil
+}
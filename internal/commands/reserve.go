@@ -0,0 +1,168 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// reserveCmd represents the reserve command
+var reserveCmd = &cobra.Command{
+	Use:   "reserve",
+	Short: "Reserve a single program from the EPG",
+	Long: `Reserve a single program (one-off recording) from the EPG.
+
+Use 'epgtimer epg --channel ... --format json' to find the event ID of a program,
+or use --interactive to pick the program from a numbered list.
+
+Example:
+  # Reserve event 12345 on NHK総合
+  epgtimer reserve --channel "32736-32736-1024" --event-id 12345
+
+  # Pick a program interactively, filtered by title
+  epgtimer reserve --channel "32736-32736-1024" --interactive --title "特集"
+
+  # Reserve with high priority and custom margins
+  epgtimer reserve --channel "32736-32736-1024" --event-id 12345 --priority 5 --start-margin 60
+
+The recording option flags are the same as for 'epgtimer add'.`,
+	RunE: runReserveCommand,
+}
+
+func init() {
+	rootCmd.AddCommand(reserveCmd)
+
+	// Define flags
+	reserveCmd.Flags().String("channel", "", "Channel: ONID-TSID-SID, key:N or channel name (e.g., 32736-32736-1024, key:1, \"NHK総合\")")
+	reserveCmd.Flags().Int("event-id", 0, "Event ID of the program to reserve")
+	reserveCmd.Flags().BoolP("interactive", "i", false, "Select the program from the channel's EPG")
+	reserveCmd.Flags().String("title", "", "Filter programs by title in interactive mode (substring match, case-insensitive)")
+	reserveCmd.Flags().String("genre", "", "Filter programs by genre in interactive mode (substring match, case-insensitive)")
+
+	// Recording option flags (shared with add)
+	addRecSettingFlags(reserveCmd)
+
+	reserveCmd.MarkFlagRequired("channel")
+	reserveCmd.MarkFlagsMutuallyExclusive("event-id", "interactive")
+}
+
+func runReserveCommand(cmd *cobra.Command, args []string) error {
+	// Get EMWUI endpoint
+	endpoint, err := GetEMWUIEndpoint(cmd)
+	if err != nil {
+		return fmt.Errorf("configuration error: %w\n\nPlease set EMWUI_ENDPOINT environment variable:\n  export EMWUI_ENDPOINT=http://localhost:5510\n\nOr use --endpoint flag:\n  epgtimer reserve --endpoint http://localhost:5510 --channel \"...\" --event-id ...", err)
+	}
+
+	channel, _ := cmd.Flags().GetString("channel")
+	eventID, _ := cmd.Flags().GetInt("event-id")
+	interactive, _ := cmd.Flags().GetBool("interactive")
+
+	if !interactive && eventID <= 0 {
+		return fmt.Errorf("must specify either --event-id or --interactive\n\nTo find event IDs, run:\n  epgtimer epg --channel %q --format json", channel)
+	}
+
+	// Create client
+	c, err := newClient(cmd, endpoint)
+	if err != nil {
+		return err
+	}
+
+	id, err := resolveChannelRef(cmd, c, endpoint, channel)
+	if err != nil {
+		return err
+	}
+	ch, err := models.ParseServiceListEntry(id)
+	if err != nil {
+		return fmt.Errorf("invalid channel format: %w\n\nExpected format: ONID-TSID-SID (e.g., \"32736-32736-1024\")", err)
+	}
+
+	// Look up the event so the user can see what is being reserved
+	response, err := c.EnumEventInfo(cmd.Context(), ch.ONID, ch.TSID, ch.SID)
+	if err != nil {
+		return formatConnectionError(err, endpoint)
+	}
+
+	var event *models.EventInfo
+	if interactive {
+		events := applyEPGFilters(cmd, response.Items)
+		if len(events) == 0 {
+			fmt.Println("No programs match the specified filters.")
+			return nil
+		}
+
+		event, err = selectEvent(cmd.InOrStdin(), events)
+		if err != nil {
+			return err
+		}
+		if event == nil {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	} else {
+		for i := range response.Items {
+			if response.Items[i].EventID == eventID {
+				event = &response.Items[i]
+				break
+			}
+		}
+		if event == nil {
+			return fmt.Errorf("event ID %d not found in the EPG of %s\n\nTo find event IDs, run:\n  epgtimer epg --channel %q --format json", eventID, channel, channel)
+		}
+	}
+
+	// Create request
+	req := models.NewReserveRequestFromEvent(event)
+	if err := applyRecSettingFlags(cmd, &req.RecSettingRequest); err != nil {
+		return err
+	}
+
+	if err := req.Validate(); err != nil {
+		return fmt.Errorf("validation error: %w", err)
+	}
+
+	// Call API
+	if _, err := c.AddReserve(cmd.Context(), req); err != nil {
+		return apiError(err, endpoint, "add reservation")
+	}
+
+	// Success
+	fmt.Println("✓ Reservation added successfully")
+	fmt.Printf("\nProgram: %s\n", event.EventName)
+	fmt.Printf("Start:   %s %s (%d min)\n", event.StartDate, event.StartTime, event.DurationMinutes())
+	fmt.Printf("Channel: %s (event ID %d)\n", event.ChannelID(), event.EventID)
+
+	return nil
+}
+
+// selectEvent prints a numbered list of events and reads the user's choice
+// Returns nil without error when the user enters nothing
+func selectEvent(in io.Reader, events []models.EventInfo) (*models.EventInfo, error) {
+	for i, event := range events {
+		fmt.Printf("%4d) %s %s  %3d min  %s\n",
+			i+1, event.StartDate, event.StartTime, event.DurationMinutes(), event.EventName)
+	}
+
+	fmt.Printf("\nSelect a program to reserve [1-%d] (empty to cancel): ", len(events))
+
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read selection: %w", err)
+	}
+
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return nil, nil
+	}
+
+	n, err := strconv.Atoi(line)
+	if err != nil || n < 1 || n > len(events) {
+		return nil, fmt.Errorf("invalid selection '%s': enter a number between 1 and %d", line, len(events))
+	}
+
+	return &events[n-1], nil
+}
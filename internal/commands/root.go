@@ -1,9 +1,15 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
 
+	"github.com/epy0n0ff/epgtimer-cli/internal/client"
 	"github.com/spf13/cobra"
 )
 
@@ -22,14 +28,25 @@ It allows you to manage automatic recording rules via keyword-based searches.
 Set the EMWUI_ENDPOINT environment variable to your EMWUI server URL:
   export EMWUI_ENDPOINT=http://localhost:5510
 
+Or define named server profiles in ~/.config/epgtimer/config.yaml and
+select one with --profile (see README).
+
 Example:
   epgtimer add --andKey "ニュース" --serviceList "32736-32736-1024"`,
 	Version: Version,
+
+	// Load the config file profile before any command runs
+	PersistentPreRunE: loadProfile,
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
+// Ctrl-C (or SIGTERM) cancels the command's context, aborting in-flight requests.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := rootCmd.ExecuteContext(ctx)
+	stop()
+
+	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
@@ -37,7 +54,18 @@ func Execute() {
 
 func init() {
 	// Global flags can be added here
-	rootCmd.PersistentFlags().StringP("endpoint", "e", "", "EMWUI server endpoint (overrides EMWUI_ENDPOINT env var)")
+	rootCmd.PersistentFlags().String("config", "", "Config file (default: ~/.config/epgtimer/config.yaml, overrides EPGTIMER_CONFIG env var)")
+	rootCmd.PersistentFlags().StringP("profile", "p", "", "Server profile from the config file (overrides EPGTIMER_PROFILE env var and defaultProfile; its settings override the EMWUI_* env vars)")
+	rootCmd.PersistentFlags().StringSliceP("endpoint", "e", nil, "EMWUI server endpoint (overrides EMWUI_ENDPOINT env var); list, manual-rules list, reservations, recordings and channels accept several")
+	rootCmd.PersistentFlags().Duration("timeout", client.DefaultTimeout, "Timeout for each request to the EMWUI server (0 = no timeout)")
+	rootCmd.PersistentFlags().Int("retries", 2, "Retries for failed read requests, e.g., while the EpgTimer host wakes up (0 = no retry)")
+	rootCmd.PersistentFlags().Duration("retry-wait", time.Second, "Wait before the first retry, doubled for each further retry")
+
+	// Authentication and TLS flags
+	rootCmd.PersistentFlags().String("user", "", "User name for HTTP Basic/Digest authentication (overrides EMWUI_USER env var)")
+	rootCmd.PersistentFlags().String("password", "", "Password for HTTP Basic/Digest authentication (overrides EMWUI_PASSWORD env var)")
+	rootCmd.PersistentFlags().String("ca-cert", "", "PEM file with a CA certificate to trust for https endpoints (overrides EMWUI_CA_CERT env var)")
+	rootCmd.PersistentFlags().Bool("insecure-skip-verify", false, "Skip TLS certificate verification (overrides EMWUI_INSECURE_SKIP_VERIFY env var)")
 
 	// Register subcommands
 	rootCmd.AddCommand(listCmd)
@@ -47,19 +75,85 @@ func init() {
 	rootCmd.AddCommand(epgCmd)
 }
 
-// GetEMWUIEndpoint returns the EMWUI endpoint from flag or environment variable
+// GetEMWUIEndpoint returns the EMWUI endpoint from flag, environment variable or config profile
 func GetEMWUIEndpoint(cmd *cobra.Command) (string, error) {
-	// First try flag
-	endpoint, err := cmd.Flags().GetString("endpoint")
-	if err == nil && endpoint != "" {
+	endpoints, _ := cmd.Flags().GetStringSlice("endpoint")
+	switch len(endpoints) {
+	case 0:
+		endpoint := envOrProfileEndpoint()
+		if endpoint == "" {
+			return "", fmt.Errorf("EMWUI_ENDPOINT is not set. Please set the environment variable, use --endpoint flag or select a profile with --profile")
+		}
 		return endpoint, nil
+	case 1:
+		return endpoints[0], nil
+	default:
+		return "", fmt.Errorf("'epgtimer %s' works with a single endpoint, got %d (only list, reservations, recordings and channels read from several servers)", cmd.Name(), len(endpoints))
 	}
+}
+
+// maxRetryWait caps the exponential backoff between retries
+const maxRetryWait = 30 * time.Second
 
-	// Then try environment variable
-	endpoint = os.Getenv("EMWUI_ENDPOINT")
-	if endpoint == "" {
-		return "", fmt.Errorf("EMWUI_ENDPOINT is not set. Please set the environment variable or use --endpoint flag")
+// newClient creates an API client for the endpoint, honoring the timeout, retry,
+// authentication and TLS flags and the settings of the config profile
+func newClient(cmd *cobra.Command, endpoint string) (*client.Client, error) {
+	timeout, err := cmd.Flags().GetDuration("timeout")
+	if err != nil {
+		timeout = client.DefaultTimeout
 	}
+	retries, _ := cmd.Flags().GetInt("retries")
+	retryWait, _ := cmd.Flags().GetDuration("retry-wait")
 
-	return endpoint, nil
+	// Profile settings apply unless the flag is given
+	if profile.Timeout != nil && !cmd.Flags().Changed("timeout") {
+		timeout = *profile.Timeout
+	}
+	if profile.Retries != nil && !cmd.Flags().Changed("retries") {
+		retries = *profile.Retries
+	}
+	if profile.RetryWait != nil && !cmd.Flags().Changed("retry-wait") {
+		retryWait = *profile.RetryWait
+	}
+
+	opts := []client.Option{
+		client.WithTimeout(timeout),
+		client.WithRetry(client.RetryPolicy{
+			MaxRetries:     retries,
+			InitialBackoff: retryWait,
+			MaxBackoff:     maxRetryWait,
+		}),
+	}
+
+	if user := stringSetting(cmd, "user", "EMWUI_USER", profile.User); user != "" {
+		opts = append(opts, client.WithAuth(user, stringSetting(cmd, "password", "EMWUI_PASSWORD", profile.Password)))
+	}
+
+	insecure, _ := cmd.Flags().GetBool("insecure-skip-verify")
+	if !cmd.Flags().Changed("insecure-skip-verify") {
+		if profileSelected && profile.InsecureSkipVerify != nil {
+			insecure = *profile.InsecureSkipVerify
+		} else if env, ok := os.LookupEnv("EMWUI_INSECURE_SKIP_VERIFY"); ok {
+			insecure, _ = strconv.ParseBool(env)
+		} else if profile.InsecureSkipVerify != nil {
+			insecure = *profile.InsecureSkipVerify
+		}
+	}
+	tlsConfig, err := client.LoadTLSConfig(stringSetting(cmd, "ca-cert", "EMWUI_CA_CERT", profile.CACert), insecure)
+	if err != nil {
+		return nil, fmt.Errorf("configuration error: %w", err)
+	}
+	if tlsConfig != nil {
+		opts = append(opts, client.WithTLSConfig(tlsConfig))
+	}
+
+	return client.NewClient(endpoint, opts...), nil
+}
+
+// stringFlagOrEnv returns the flag value, falling back to the environment variable
+func stringFlagOrEnv(cmd *cobra.Command, name string, envVar string) string {
+	if value, err := cmd.Flags().GetString(name); err == nil && value != "" {
+		return value
+	}
+	return os.Getenv(envVar)
 }
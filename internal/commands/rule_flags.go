@@ -0,0 +1,148 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// addSearchSettingFlags registers the search option flags shared by add and edit
+func addSearchSettingFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("regex", false, "Treat andKey/notKey as regular expressions")
+	cmd.Flags().Bool("case-sensitive", false, "Match keywords case-sensitively")
+	cmd.Flags().Bool("fuzzy", false, "Use fuzzy (aimai) keyword matching")
+	cmd.Flags().Bool("full-text", false, "Search program descriptions as well as titles (default: title only)")
+	cmd.Flags().StringSlice("days", []string{}, "Days of week to match (e.g., 月,火 or mon,tue or weekdays)")
+	cmd.Flags().String("start-time", "", "Only match programs starting at or after this time (HH:MM)")
+	cmd.Flags().String("end-time", "", "Only match programs starting before this time (HH:MM)")
+	cmd.Flags().Int("duration-min", 0, "Minimum program duration in minutes (0 = no limit)")
+	cmd.Flags().Int("duration-max", 0, "Maximum program duration in minutes (0 = no limit)")
+	cmd.Flags().Bool("free-ca-only", false, "Only match free-to-air programs")
+}
+
+// addRecSettingFlags registers the recording setting flags shared by add and edit
+func addRecSettingFlags(cmd *cobra.Command) {
+	cmd.Flags().Int("priority", 2, "Recording priority (1-5)")
+	cmd.Flags().Int("rec-mode", models.RecModeSpecified, "Recording mode: 0=all services, 1=specified service, 2=all (no descramble), 3=specified (no descramble), 4=view, 5-9=disabled")
+	cmd.Flags().Int("tuner", 0, "Tuner ID to use (0 = auto)")
+	cmd.Flags().Int("start-margin", 0, "Start margin in seconds (overrides the default margin)")
+	cmd.Flags().Int("end-margin", 0, "End margin in seconds (overrides the default margin)")
+	cmd.Flags().Int("suspend-mode", 0, "Action after recording: 0=default, 1=standby, 2=hibernate, 3=shutdown, 4=do nothing")
+	cmd.Flags().String("bat-file", "", "Batch file to run after recording")
+	cmd.Flags().StringArray("rec-folder", []string{}, "Recording folder as PATH[:writePlugin[:namePlugin]] (repeatable; \"\" = default folder)")
+	cmd.Flags().StringArray("partial-rec-folder", []string{}, "Recording folder of the partial reception (1seg) service, same format as --rec-folder (repeatable)")
+}
+
+// applySearchSettingFlags copies explicitly set search option flags into the request
+func applySearchSettingFlags(cmd *cobra.Command, req *models.AutoAddRuleRequest) error {
+	flags := cmd.Flags()
+
+	if flags.Changed("regex") {
+		req.RegExpFlag = boolFlagValue(cmd, "regex")
+	}
+	if flags.Changed("case-sensitive") {
+		req.CaseFlag = boolFlagValue(cmd, "case-sensitive")
+	}
+	if flags.Changed("fuzzy") {
+		req.AimaiFlag = boolFlagValue(cmd, "fuzzy")
+	}
+	if flags.Changed("full-text") {
+		req.TitleOnlyFlag = 1 - boolFlagValue(cmd, "full-text")
+	}
+	if flags.Changed("free-ca-only") {
+		req.FreeCAFlag = boolFlagValue(cmd, "free-ca-only")
+	}
+
+	// Day-of-week and time window replace the whole date filter
+	if flags.Changed("days") || flags.Changed("start-time") || flags.Changed("end-time") {
+		dayNames, _ := flags.GetStringSlice("days")
+		startTime, _ := flags.GetString("start-time")
+		endTime, _ := flags.GetString("end-time")
+
+		days, err := models.ParseDaysOfWeek(dayNames)
+		if err != nil {
+			return fmt.Errorf("invalid --days: %w", err)
+		}
+
+		dates, err := models.BuildDateList(days, startTime, endTime)
+		if err != nil {
+			return fmt.Errorf("invalid --start-time/--end-time: %w", err)
+		}
+		req.DateList = models.FormatDateList(dates)
+		if startTime != "" {
+			req.StartTime = startTime
+		}
+		if endTime != "" {
+			req.EndTime = endTime
+		}
+	}
+
+	if flags.Changed("duration-min") {
+		req.ChkDurationMin, _ = flags.GetInt("duration-min")
+	}
+	if flags.Changed("duration-max") {
+		req.ChkDurationMax, _ = flags.GetInt("duration-max")
+	}
+
+	return nil
+}
+
+// applyRecSettingFlags copies explicitly set recording setting flags into the request
+func applyRecSettingFlags(cmd *cobra.Command, rec *models.RecSettingRequest) error {
+	flags := cmd.Flags()
+
+	if flags.Changed("priority") {
+		rec.Priority, _ = flags.GetInt("priority")
+	}
+	if flags.Changed("rec-mode") {
+		rec.RecMode, _ = flags.GetInt("rec-mode")
+	}
+	if flags.Changed("tuner") {
+		rec.TunerID, _ = flags.GetInt("tuner")
+	}
+	if flags.Changed("start-margin") || flags.Changed("end-margin") {
+		// Custom margins replace the server default; an unset side keeps its current value
+		if flags.Changed("start-margin") {
+			rec.StartMargin, _ = flags.GetInt("start-margin")
+		}
+		if flags.Changed("end-margin") {
+			rec.EndMargin, _ = flags.GetInt("end-margin")
+		}
+		rec.UseDefMarginFlag = 0
+	}
+	if flags.Changed("suspend-mode") {
+		rec.SuspendMode, _ = flags.GetInt("suspend-mode")
+	}
+	if flags.Changed("bat-file") {
+		rec.BatFilePath, _ = flags.GetString("bat-file")
+	}
+
+	// Folder flags replace the whole folder list
+	if flags.Changed("rec-folder") {
+		values, _ := flags.GetStringArray("rec-folder")
+		folders, err := models.ParseRecFolders(values)
+		if err != nil {
+			return fmt.Errorf("invalid --rec-folder: %w", err)
+		}
+		rec.RecFolders = folders
+	}
+	if flags.Changed("partial-rec-folder") {
+		values, _ := flags.GetStringArray("partial-rec-folder")
+		folders, err := models.ParseRecFolders(values)
+		if err != nil {
+			return fmt.Errorf("invalid --partial-rec-folder: %w", err)
+		}
+		rec.PartialRecFolders = folders
+	}
+
+	return nil
+}
+
+// boolFlagValue returns a boolean flag as EpgTimer's 0/1 flag value
+func boolFlagValue(cmd *cobra.Command, name string) int {
+	if value, _ := cmd.Flags().GetBool(name); value {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,239 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/client"
+	"github.com/epy0n0ff/epgtimer-cli/internal/formatters"
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+	"github.com/spf13/cobra"
+)
+
+var rulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "Manage several automatic recording rules at once",
+	Long: `Change automatic recording rules in bulk.
+
+Rules are selected by ID, by the same filters as 'epgtimer list', or both
+(filters then narrow down the given IDs).`,
+}
+
+var rulesDisableCmd = &cobra.Command{
+	Use:   "disable [rule-id...]",
+	Short: "Disable automatic recording rules without deleting them",
+	Long: `Disable automatic recording rules. Disabled rules are kept with all their
+settings but do not add reservations until they are enabled again.
+
+Rules can be selected by ID, by the same filters as 'epgtimer list', or both.
+The matching rules are listed and you are asked for confirmation; use --yes
+to skip the prompt.
+
+Example:
+  # Pause all anime rules
+  epgtimer rules disable --andKey アニメ
+
+  # Disable the regex rules recording NHK総合 without prompting
+  epgtimer rules disable --regex --channel 32736-32736-1024 --yes
+
+  # Disable rules by ID
+  epgtimer rules disable 1 2
+  epgtimer rules disable --id 1,2`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRulesSetDisabled(cmd, args, true)
+	},
+}
+
+var rulesEnableCmd = &cobra.Command{
+	Use:   "enable [rule-id...]",
+	Short: "Re-enable disabled automatic recording rules",
+	Long: `Re-enable automatic recording rules disabled with 'epgtimer rules disable'
+or 'epgtimer edit --disable'.
+
+Example:
+  # Resume all anime rules
+  epgtimer rules enable --andKey アニメ
+
+  epgtimer rules enable --id 1,2 --yes`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRulesSetDisabled(cmd, args, false)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(rulesCmd)
+	rulesCmd.AddCommand(rulesDisableCmd)
+	rulesCmd.AddCommand(rulesEnableCmd)
+
+	for _, cmd := range []*cobra.Command{rulesDisableCmd, rulesEnableCmd} {
+		addRuleSelectionFlags(cmd)
+		cmd.Flags().BoolP("yes", "y", false, "Apply without asking for confirmation")
+	}
+}
+
+// addRuleSelectionFlags registers the --id flag and the filter flags of list used to select rules
+func addRuleSelectionFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSlice("id", []string{}, "Rule IDs or ID ranges (comma-separated, e.g., 1,2,10-15)")
+	cmd.Flags().String("andKey", "", "Select by search keyword (substring match, case-insensitive)")
+	cmd.Flags().String("channel", "", "Select by channel (ONID-TSID-SID, key:N or channel name)")
+	cmd.Flags().StringSlice("channels", nil, "Select by any of these channels or channel groups (comma-separated, e.g., @bs,key:1)")
+	cmd.Flags().Bool("regex", false, "Select only regex-enabled rules")
+}
+
+// selectRules returns the rules chosen by positional IDs, --id and the filter flags
+// Every ID given singly must exist; an ID range selects the existing rules within it.
+func selectRules(cmd *cobra.Command, args []string, c *client.Client, endpoint string) ([]models.AutoAddRule, error) {
+	flagIDs, _ := cmd.Flags().GetStringSlice("id")
+	selection, err := parseIDSelection(append(append([]string{}, args...), flagIDs...))
+	if err != nil {
+		return nil, err
+	}
+
+	filterOpts := ruleFilterOptions(cmd)
+	if selection.isEmpty() && !filterOpts.HasFilters() {
+		return nil, fmt.Errorf("no rules selected\n\nUsage:\n  %[1]s [rule-id...]\n  %[1]s --id 1,2\n  %[1]s --andKey \"アニメ\"\n\nTo find rule IDs, run:\n  epgtimer list", cmd.CommandPath())
+	}
+
+	if err := resolveChannelFlag(cmd, endpoint); err != nil {
+		return nil, err
+	}
+	filterOpts = ruleFilterOptions(cmd)
+
+	response, err := c.EnumAutoAdd(cmd.Context())
+	if err != nil {
+		return nil, formatConnectionError(err, endpoint)
+	}
+
+	rules := response.Items
+	if !selection.isEmpty() {
+		byID := make(map[int]models.AutoAddRule)
+		for _, rule := range response.Items {
+			byID[rule.ID] = rule
+		}
+
+		rules = nil
+		seen := make(map[int]bool)
+		var missing []string
+		for _, id := range selection.ids {
+			rule, ok := byID[id]
+			if !ok {
+				missing = append(missing, fmt.Sprintf("%d", id))
+				continue
+			}
+			seen[id] = true
+			rules = append(rules, rule)
+		}
+		if len(missing) > 0 {
+			return nil, fmt.Errorf("rule ID %s not found\n\nTo find rule IDs, run:\n  epgtimer list", strings.Join(missing, ", "))
+		}
+
+		for _, rule := range response.Items {
+			if !seen[rule.ID] && selection.inRange(rule.ID) {
+				seen[rule.ID] = true
+				rules = append(rules, rule)
+			}
+		}
+	}
+
+	var selected []models.AutoAddRule
+	for _, rule := range rules {
+		if filterOpts.Matches(&rule) {
+			selected = append(selected, rule)
+		}
+	}
+	return selected, nil
+}
+
+// rulesClient returns an API client for the configured endpoint
+func rulesClient(cmd *cobra.Command) (*client.Client, string, error) {
+	endpoint, err := GetEMWUIEndpoint(cmd)
+	if err != nil {
+		return nil, "", fmt.Errorf("configuration error: %w\n\nPlease set EMWUI_ENDPOINT environment variable:\n  export EMWUI_ENDPOINT=http://localhost:5510\n\nOr use --endpoint flag:\n  %s --endpoint http://localhost:5510 ...", err, cmd.CommandPath())
+	}
+	c, err := newClient(cmd, endpoint)
+	if err != nil {
+		return nil, "", err
+	}
+	return c, endpoint, nil
+}
+
+func runRulesSetDisabled(cmd *cobra.Command, args []string, disabled bool) error {
+	c, endpoint, err := rulesClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	selected, err := selectRules(cmd, args, c, endpoint)
+	if err != nil {
+		return err
+	}
+
+	if len(selected) == 0 {
+		fmt.Println("No automatic recording rules match the specified filters.")
+		return nil
+	}
+
+	action := "enable"
+	if disabled {
+		action = "disable"
+	}
+
+	// Rules already in the requested state are left alone
+	var rules []models.AutoAddRule
+	for _, rule := range selected {
+		if rule.SearchSettings.IsEnabled() != disabled {
+			fmt.Printf("- Rule (ID: %d) is already %sd: %s\n", rule.ID, action, rule.SearchSettings.AndKey)
+			continue
+		}
+		rules = append(rules, rule)
+	}
+
+	if len(rules) == 0 {
+		fmt.Printf("No rules to %s.\n", action)
+		return nil
+	}
+
+	formatter := &formatters.TableFormatter{}
+	preview, err := formatter.Format(rules)
+	if err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+	fmt.Print(preview)
+
+	yes, _ := cmd.Flags().GetBool("yes")
+	if !yes {
+		ok, err := confirm(cmd.InOrStdin(), fmt.Sprintf("\n%s %d rules?", strings.ToUpper(action[:1])+action[1:], len(rules)))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	failed := 0
+	for _, rule := range rules {
+		// Send every other setting back unchanged
+		req := models.NewAutoAddRuleRequestFromRule(&rule)
+		req.DisableFlag = 0
+		if disabled {
+			req.DisableFlag = 1
+		}
+
+		if _, err := c.UpdateAutoAdd(cmd.Context(), rule.ID, req); err != nil {
+			if len(rules) == 1 {
+				return apiError(err, endpoint, action+" recording rule")
+			}
+			fmt.Printf("✗ Failed to %s rule %d: %v\n", action, rule.ID, err)
+			failed++
+			continue
+		}
+		fmt.Printf("✓ Rule (ID: %d) %sd: %s\n", rule.ID, action, rule.SearchSettings.AndKey)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d rules could not be %sd", failed, len(rules), action)
+	}
+	return nil
+}
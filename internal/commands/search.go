@@ -0,0 +1,284 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/formatters"
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+	"github.com/spf13/cobra"
+)
+
+var searchCmd = &cobra.Command{
+	Use:   "search KEYWORD...",
+	Short: "Search the EPG of all channels like an automatic recording rule",
+	Long: `Search the EPG (Electronic Program Guide) of all channels for programs.
+
+The keywords are matched like the andKey/notKey of an automatic recording rule,
+so the same flags as 'epgtimer add' show which programs a rule would record:
+  - Every keyword must be found (space-separated keywords, in any order)
+  - Programs containing any --notKey keyword are excluded
+  - Matching ignores case and full-width/half-width differences, unless --case-sensitive
+  - With --regex, the keywords and --notKey are each one regular expression (Go RE2 syntax)
+  - With --fuzzy, a keyword matches if all its characters appear in the text
+
+Unlike 'add', search looks in the program title, description and extended description
+by default; use --title-only to search titles only (a rule without --full-text).
+
+The programs can also be limited by channel (--channels, default @all), date (--from/--to),
+weekday (--days), time of day (--start-time/--end-time, by start time), duration and
+free-to-air broadcasts. Results are sorted by start time.
+
+The EPG is retrieved and cached as with 'epgtimer epg' (--concurrency, --rate, --refresh,
+--offline, --no-cache, --cache-ttl).
+
+Examples:
+  # Search all TV channels
+  epgtimer search "ドキュメント 72時間"
+
+  # Exclude reruns, only weekday evenings
+  epgtimer search "ドラマ" --notKey "再放送" --days weekdays --start-time 19:00 --end-time 23:00
+
+  # Regular expression, case-sensitive, on the BS channels
+  epgtimer search "^NHK.*(特集|ドキュメント)" --regex --case-sensitive --channels @bs
+
+  # Movies of at least 90 minutes this week, as JSON
+  epgtimer search "映画" --duration-min 90 --from 2025-12-22 --to 2025-12-28 --format json
+
+  # Search the cached guide while the recorder is asleep
+  epgtimer search "ニュース" --offline`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runSearch,
+}
+
+func init() {
+	rootCmd.AddCommand(searchCmd)
+
+	searchCmd.Flags().String("notKey", "", "Exclusion keywords (programs containing any of them are excluded)")
+	searchCmd.Flags().Bool("title-only", false, "Search program titles only (default: title and descriptions)")
+
+	// Search option flags (shared with add and edit)
+	addSearchSettingFlags(searchCmd)
+
+	searchCmd.Flags().StringSlice("channels", nil, "Channels and channel groups to search (comma-separated, e.g., @bs,key:1; default: @all)")
+	searchCmd.Flags().String("from", "", "Only programs starting on or after this date (YYYY-MM-DD)")
+	searchCmd.Flags().String("to", "", "Only programs starting on or before this date (YYYY-MM-DD)")
+
+	// Retrieval and cache flags (shared with epg)
+	addEPGSourceFlags(searchCmd)
+
+	// Export flags
+	searchCmd.Flags().String("format", "table", "Output format: table, json, csv, tsv")
+	searchCmd.Flags().StringP("output", "o", "", "Output file path (default: stdout)")
+
+	// Full-text search is the default here
+	searchCmd.Flags().MarkHidden("full-text")
+	searchCmd.MarkFlagsMutuallyExclusive("title-only", "full-text")
+}
+
+func runSearch(cmd *cobra.Command, args []string) error {
+	// Get EMWUI endpoint from flag, environment variable or config profile
+	endpoint, err := GetEMWUIEndpoint(cmd)
+	if err != nil {
+		return fmt.Errorf("EMWUI endpoint not configured\n\nPlease set the endpoint using:\n  1. --endpoint flag: epgtimer search KEYWORD --endpoint http://192.168.1.10:5510\n  2. EMWUI_ENDPOINT environment variable: export EMWUI_ENDPOINT=http://192.168.1.10:5510\n  3. a profile in ~/.config/epgtimer/config.yaml: epgtimer search KEYWORD --profile living-room")
+	}
+
+	matcher, err := searchMatcherFromFlags(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	from, to, err := searchDateRangeFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	channelRefs, _ := cmd.Flags().GetStringSlice("channels")
+	if len(channelRefs) == 0 {
+		channelRefs = []string{"@all"}
+	}
+
+	fetchOpts, err := epgBatchOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Create API client
+	apiClient, err := newClient(cmd, endpoint)
+	if err != nil {
+		return err
+	}
+
+	// Channel lists and EPG data come from the cache when possible
+	source, err := newEPGSource(cmd, apiClient, endpoint)
+	if err != nil {
+		return err
+	}
+
+	serverChannels, err := source.channels(cmd)
+	if err != nil {
+		if errors.Is(err, errNotCached) {
+			return err
+		}
+		return formatConnectionError(err, endpoint)
+	}
+	resolver := &models.ChannelResolver{Channels: serverChannels, Groups: channelGroups}
+	ids, err := resolver.ResolveAll(channelRefs)
+	if err != nil {
+		return err
+	}
+
+	channels := make([]*models.ServiceListEntry, 0, len(ids))
+	for _, id := range ids {
+		ch, err := models.ParseServiceListEntry(id)
+		if err != nil {
+			return fmt.Errorf("invalid channel format: %w", err)
+		}
+		channels = append(channels, ch)
+	}
+
+	// Retrieve EPG for the channels in parallel
+	results := source.epg(cmd, channels, fetchOpts)
+	allEvents, failed := mergeEPGResults(results)
+	if failed == len(channels) {
+		return epgRetrievalError(results[0], endpoint)
+	}
+
+	// Progress goes to stderr so that json/csv/tsv output can be piped
+	fmt.Fprintf(os.Stderr, "Searched %d programs on %d channels", len(allEvents), len(channels)-failed)
+	if source.cached > 0 {
+		fmt.Fprintf(os.Stderr, " (%d from cache)", source.cached)
+	}
+	fmt.Fprintln(os.Stderr)
+
+	var matched []models.EventInfo
+	for i := range allEvents {
+		event := &allEvents[i]
+		if matcher.Matches(event) && searchDateRangeMatches(event, from, to) {
+			matched = append(matched, *event)
+		}
+	}
+
+	// Handle empty results
+	if len(matched) == 0 {
+		fmt.Println("No programs match the search.")
+		return epgChannelsError(failed, len(channels))
+	}
+
+	// Chronological order; programs starting at the same time keep the channel order
+	sortEventsByStart(matched)
+
+	// Get format flag
+	format := outputFormat(cmd)
+
+	// Format events
+	var output string
+	switch format {
+	case "table":
+		formatter := &formatters.EPGTableFormatter{ShowChannel: true}
+		output, err = formatter.Format(matched)
+	case "json":
+		output = formatEPGAsJSON(matched)
+	case "csv":
+		output = formatEPGAsCSV(matched)
+	case "tsv":
+		output = formatEPGAsTSV(matched)
+	default:
+		return fmt.Errorf("unsupported format '%s'. Supported formats: table, json, csv, tsv", format)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	// Get output flag
+	outputPath, _ := cmd.Flags().GetString("output")
+
+	// Write to file or stdout
+	if outputPath != "" {
+		if err := os.WriteFile(outputPath, []byte(output), 0644); err != nil {
+			return fmt.Errorf("failed to write output to file '%s': %w", outputPath, err)
+		}
+		fmt.Printf("Successfully exported %d programs to %s\n", len(matched), outputPath)
+	} else {
+		fmt.Print(output)
+	}
+
+	return epgChannelsError(failed, len(channels))
+}
+
+// searchMatcherFromFlags builds the event matcher of the keywords and search option flags,
+// from the same search settings as a rule created by add
+func searchMatcherFromFlags(cmd *cobra.Command, args []string) (*models.EventMatcher, error) {
+	notKey, _ := cmd.Flags().GetString("notKey")
+	req := models.NewAutoAddRuleRequest(strings.Join(args, " "), notKey, nil)
+
+	// Full-text search by default
+	req.TitleOnlyFlag = boolFlagValue(cmd, "title-only")
+	if err := applySearchSettingFlags(cmd, req); err != nil {
+		return nil, err
+	}
+
+	if req.ChkDurationMin < 0 || req.ChkDurationMax < 0 {
+		return nil, fmt.Errorf("duration limits cannot be negative")
+	}
+	if req.ChkDurationMax > 0 && req.ChkDurationMin > req.ChkDurationMax {
+		return nil, fmt.Errorf("minimum duration (%d) is greater than maximum duration (%d)", req.ChkDurationMin, req.ChkDurationMax)
+	}
+
+	settings, err := req.SearchSettings()
+	if err != nil {
+		return nil, err
+	}
+	return models.NewEventMatcher(settings)
+}
+
+// searchDateRangeFromFlags parses --from and --to; an unset date is the zero time
+func searchDateRangeFromFlags(cmd *cobra.Command) (time.Time, time.Time, error) {
+	var dates [2]time.Time
+	for i, name := range []string{"from", "to"} {
+		value, _ := cmd.Flags().GetString(name)
+		if value == "" {
+			continue
+		}
+		date, err := parseSearchDate(value)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid --%s: %w", name, err)
+		}
+		dates[i] = date
+	}
+
+	if !dates[0].IsZero() && !dates[1].IsZero() && dates[1].Before(dates[0]) {
+		return time.Time{}, time.Time{}, fmt.Errorf("--to date is before --from date")
+	}
+	return dates[0], dates[1], nil
+}
+
+// parseSearchDate parses a date as YYYY-MM-DD or YYYY/MM/DD (the EPG's own format)
+func parseSearchDate(s string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02", "2006/01/02"} {
+		if date, err := time.Parse(layout, s); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("'%s' is not a date (expected YYYY-MM-DD)", s)
+}
+
+// searchDateRangeMatches checks that the event starts between the from and to dates (inclusive)
+func searchDateRangeMatches(event *models.EventInfo, from, to time.Time) bool {
+	if from.IsZero() && to.IsZero() {
+		return true
+	}
+
+	start, err := event.StartDateTime()
+	if err != nil {
+		return false
+	}
+	if !from.IsZero() && start.Before(from) {
+		return false
+	}
+	return to.IsZero() || start.Before(to.AddDate(0, 0, 1))
+}
@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/client"
+	"github.com/spf13/cobra"
+)
+
+// GetEMWUIEndpoints returns the EMWUI endpoints for commands that can read from several servers
+// Endpoints come from --endpoint (repeated or comma-separated), EMWUI_ENDPOINTS (comma- or
+// space-separated), EMWUI_ENDPOINT or the config profile, in that order; the endpoint of a
+// profile selected with --profile or EPGTIMER_PROFILE comes right after --endpoint.
+func GetEMWUIEndpoints(cmd *cobra.Command) ([]string, error) {
+	endpoints, _ := cmd.Flags().GetStringSlice("endpoint")
+	if len(endpoints) == 0 && profileSelected && profile.Endpoint != "" {
+		endpoints = []string{profile.Endpoint}
+	}
+	if len(endpoints) == 0 {
+		endpoints = strings.FieldsFunc(os.Getenv("EMWUI_ENDPOINTS"), func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t' || r == '\n'
+		})
+	}
+	if len(endpoints) == 0 {
+		if endpoint := envOrProfileEndpoint(); endpoint != "" {
+			endpoints = []string{endpoint}
+		}
+	}
+
+	var cleaned []string
+	seen := make(map[string]bool)
+	for _, endpoint := range endpoints {
+		endpoint = strings.TrimSpace(endpoint)
+		if endpoint == "" || seen[endpoint] {
+			continue
+		}
+		seen[endpoint] = true
+		cleaned = append(cleaned, endpoint)
+	}
+
+	if len(cleaned) == 0 {
+		return nil, fmt.Errorf("EMWUI_ENDPOINT is not set. Please set the environment variable, use --endpoint flag or select a profile with --profile")
+	}
+	return cleaned, nil
+}
+
+// envOrProfileEndpoint returns EMWUI_ENDPOINT, falling back to the endpoint of the config profile
+// The endpoint of a profile selected with --profile or EPGTIMER_PROFILE takes precedence.
+func envOrProfileEndpoint() string {
+	if profileSelected && profile.Endpoint != "" {
+		return profile.Endpoint
+	}
+	if endpoint := os.Getenv("EMWUI_ENDPOINT"); endpoint != "" {
+		return endpoint
+	}
+	return profile.Endpoint
+}
+
+// serverName returns the label of an endpoint in merged output (host:port)
+func serverName(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return endpoint
+	}
+	return u.Host
+}
+
+// fetchFromServers runs fetch against every endpoint concurrently and merges the items in endpoint order
+// With several endpoints, each item is tagged with its server via setServer, and servers that
+// cannot be read are reported on stderr; the number of failed servers is returned so that the
+// command can exit with an error after printing the results of the others.
+// With a single endpoint, its error is returned as is.
+func fetchFromServers[T any](cmd *cobra.Command, endpoints []string, fetch func(context.Context, *client.Client) ([]T, error), setServer func(*T, string)) ([]T, int, error) {
+	if len(endpoints) == 1 {
+		c, err := newClient(cmd, endpoints[0])
+		if err != nil {
+			return nil, 0, err
+		}
+		items, err := fetch(cmd.Context(), c)
+		if err != nil {
+			return nil, 0, formatConnectionError(err, endpoints[0])
+		}
+		return items, 0, nil
+	}
+
+	results := make([][]T, len(endpoints))
+	errs := make([]error, len(endpoints))
+
+	var wg sync.WaitGroup
+	for i, endpoint := range endpoints {
+		c, err := newClient(cmd, endpoint)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = fetch(cmd.Context(), c)
+		}()
+	}
+	wg.Wait()
+
+	var merged []T
+	failed := 0
+	for i, endpoint := range endpoints {
+		if errs[i] != nil {
+			fmt.Fprintf(os.Stderr, "✗ %s: %v\n", serverName(endpoint), errs[i])
+			failed++
+			continue
+		}
+		for j := range results[i] {
+			setServer(&results[i][j], serverName(endpoint))
+		}
+		merged = append(merged, results[i]...)
+	}
+
+	if failed == len(endpoints) {
+		return nil, failed, fmt.Errorf("failed to connect to any of the %d EMWUI servers\n\nTroubleshooting:\n1. Check that EpgTimer is running on each server\n2. Verify the --endpoint flag, EMWUI_ENDPOINTS environment variable or config profile\n3. Confirm network connectivity to the EMWUI servers", len(endpoints))
+	}
+	return merged, failed, nil
+}
+
+// serversError returns the error reported after the output when some servers could not be read
+func serversError(failed int, total int) error {
+	if failed == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d of %d EMWUI servers could not be read", failed, total)
+}
@@ -18,10 +18,16 @@ func (t *ChannelsTableFormatter) Format(channels []models.ChannelInfo) (string,
 
 	var output strings.Builder
 
+	servers := make([]string, len(channels))
+	for i, ch := range channels {
+		servers[i] = ch.Server
+	}
+	server := newServerColumn(servers)
+
 	// Header
-	output.WriteString(fmt.Sprintf("%-20s %-12s %-6s %-40s %-20s\n",
+	output.WriteString(server.header(" ") + fmt.Sprintf("%-20s %-12s %-6s %-40s %-20s\n",
 		"Channel ID", "Type", "Key", "Channel Name", "Network"))
-	output.WriteString(strings.Repeat("-", 100) + "\n")
+	output.WriteString(strings.Repeat("-", 100+server.rule(" ")) + "\n")
 
 	// Data rows
 	for _, ch := range channels {
@@ -34,7 +40,7 @@ func (t *ChannelsTableFormatter) Format(channels []models.ChannelInfo) (string,
 		channelName := truncate(ch.ServiceName, 40)
 		networkName := truncate(ch.NetworkName, 20)
 
-		output.WriteString(fmt.Sprintf("%-20s %-12s %-6s %-40s %-20s\n",
+		output.WriteString(server.cell(ch.Server, " ") + fmt.Sprintf("%-20s %-12s %-6s %-40s %-20s\n",
 			channelID, serviceType, keyID, channelName, networkName))
 	}
 
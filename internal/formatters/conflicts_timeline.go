@@ -0,0 +1,65 @@
+package formatters
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+)
+
+// ConflictsTimelineFormatter formats reservation conflicts as a timeline per overlap window
+type ConflictsTimelineFormatter struct{}
+
+// Format converts conflict groups to timeline format
+func (t *ConflictsTimelineFormatter) Format(groups []models.ConflictGroup) (string, error) {
+	if len(groups) == 0 {
+		return "No overlapping reservations found.\n", nil
+	}
+
+	var output strings.Builder
+	failing := 0
+
+	for i, group := range groups {
+		if i > 0 {
+			output.WriteString("\n")
+		}
+
+		output.WriteString(fmt.Sprintf("%s - %s  %d reservations, %d tuners needed",
+			group.Start.Format("2006/01/02 15:04"), group.End.Format("15:04"),
+			len(group.Reservations), group.TunersNeeded))
+		if group.Failing > 0 {
+			output.WriteString(fmt.Sprintf(", %d not recorded in full", group.Failing))
+		}
+		output.WriteString("\n")
+
+		// Header
+		output.WriteString(fmt.Sprintf("  %-6s %-6s %-6s %-4s %-10s %-8s %-50s %-20s\n",
+			"ID", "Start", "End", "Pri", "Tuner", "Status", "Title", "Station"))
+		output.WriteString("  " + strings.Repeat("-", 115) + "\n")
+
+		// Data rows
+		for _, entry := range group.Reservations {
+			tuner := "auto"
+			if entry.TunerID > 0 {
+				tuner = fmt.Sprintf("%d", entry.TunerID)
+			} else if entry.AssignedTuner > 0 {
+				tuner = fmt.Sprintf("#%d", entry.AssignedTuner)
+			} else if entry.Status == models.ConflictStatusFail {
+				tuner = "-"
+			}
+
+			title := truncate(entry.Title, 50)
+			station := truncate(entry.StationName, 20)
+
+			output.WriteString(fmt.Sprintf("  %-6d %-6s %-6s %-4d %-10s %-8s %-50s %-20s\n",
+				entry.ID, entry.Start.Format("15:04"), entry.End.Format("15:04"),
+				entry.Priority, tuner, strings.ToUpper(entry.Status), title, station))
+		}
+
+		failing += group.Failing
+	}
+
+	output.WriteString(fmt.Sprintf("\nTotal: %d overlap windows, %d reservations not recorded in full\n", len(groups), failing))
+
+	return output.String(), nil
+}
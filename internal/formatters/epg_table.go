@@ -8,7 +8,9 @@ import (
 )
 
 // EPGTableFormatter formats EPG events as a human-readable table
-type EPGTableFormatter struct{}
+type EPGTableFormatter struct {
+	ShowChannel bool // Add a channel column (for events of several channels)
+}
 
 // Format converts EPG events to table format
 func (t *EPGTableFormatter) Format(events []models.EventInfo) (string, error) {
@@ -19,9 +21,16 @@ func (t *EPGTableFormatter) Format(events []models.EventInfo) (string, error) {
 	var output strings.Builder
 
 	// Header
+	if t.ShowChannel {
+		output.WriteString(fmt.Sprintf("%-20s ", "Channel"))
+	}
 	output.WriteString(fmt.Sprintf("%-12s %-6s %-5s %-50s %-20s\n",
 		"Date", "Time", "Mins", "Title", "Genre"))
-	output.WriteString(strings.Repeat("-", 100) + "\n")
+	width := 100
+	if t.ShowChannel {
+		width += 21
+	}
+	output.WriteString(strings.Repeat("-", width) + "\n")
 
 	// Data rows
 	for _, event := range events {
@@ -36,6 +45,9 @@ func (t *EPGTableFormatter) Format(events []models.EventInfo) (string, error) {
 		title := truncate(event.EventName, 50)
 		genre := truncate(event.GenreString(), 20)
 
+		if t.ShowChannel {
+			output.WriteString(fmt.Sprintf("%-20s ", truncate(event.ServiceName, 20)))
+		}
 		output.WriteString(fmt.Sprintf("%-12s %-6s %-5d %-50s %-20s\n",
 			shortDate, shortTime, event.DurationMinutes(), title, genre))
 	}
@@ -0,0 +1,145 @@
+package formatters
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+)
+
+// ManualRulesTableFormatter formats program-based rules as a human-readable table
+type ManualRulesTableFormatter struct{}
+
+// Format converts program-based rules to table format
+func (t *ManualRulesTableFormatter) Format(rules []models.ManualAutoAddRule) (string, error) {
+	if len(rules) == 0 {
+		return "No program-based recording rules found.\n", nil
+	}
+
+	var sb strings.Builder
+
+	servers := make([]string, len(rules))
+	for i, rule := range rules {
+		servers[i] = rule.Server
+	}
+	server := newServerColumn(servers)
+
+	// Header
+	sb.WriteString(server.header("  ") + fmt.Sprintf("%-4s  %-8s  %-21s  %-11s  %-8s  %-20s  %s\n",
+		"ID", "Enabled", "Days", "Time", "Duration", "Channel", "Title"))
+	sb.WriteString(strings.Repeat("-", 110+server.rule("  ")) + "\n")
+
+	// Data rows
+	for _, rule := range rules {
+		enabled := "Yes"
+		if !rule.IsEnabled() {
+			enabled = "No"
+		}
+
+		channel := rule.StationName
+		if channel == "" {
+			channel = rule.ChannelID()
+		}
+
+		sb.WriteString(server.cell(rule.Server, "  ") + fmt.Sprintf("%-4d  %-8s  %-21s  %-11s  %-8s  %-20s  %s\n",
+			rule.ID, enabled, rule.DaysString(), rule.StartTimeString()+"-"+rule.EndTimeString(),
+			fmt.Sprintf("%d min", rule.DurationMinutes()), truncate(channel, 20), rule.Title))
+	}
+
+	sb.WriteString(fmt.Sprintf("\nTotal: %d rules\n", len(rules)))
+
+	return sb.String(), nil
+}
+
+// ManualRulesJSONFormatter formats program-based rules as JSON
+type ManualRulesJSONFormatter struct{}
+
+// Format converts program-based rules to JSON format
+func (j *ManualRulesJSONFormatter) Format(rules []models.ManualAutoAddRule) (string, error) {
+	data, err := json.MarshalIndent(rules, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// ManualRulesCSVFormatter formats program-based rules as CSV
+type ManualRulesCSVFormatter struct{}
+
+// Format converts program-based rules to CSV format
+func (c *ManualRulesCSVFormatter) Format(rules []models.ManualAutoAddRule) (string, error) {
+	return formatManualRulesDelimited(rules, ',', "CSV")
+}
+
+// ManualRulesTSVFormatter formats program-based rules as TSV (Tab-Separated Values)
+type ManualRulesTSVFormatter struct{}
+
+// Format converts program-based rules to TSV format
+func (t *ManualRulesTSVFormatter) Format(rules []models.ManualAutoAddRule) (string, error) {
+	return formatManualRulesDelimited(rules, '\t', "TSV")
+}
+
+// formatManualRulesDelimited writes program-based rules with the given field delimiter
+func formatManualRulesDelimited(rules []models.ManualAutoAddRule, comma rune, name string) (string, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Comma = comma
+
+	// Write header
+	header := []string{
+		"ID",
+		"Enabled",
+		"Days",
+		"DayOfWeekFlag",
+		"StartTime",
+		"EndTime",
+		"DurationMinutes",
+		"Title",
+		"StationName",
+		"ChannelID",
+		"Priority",
+		"RecMode",
+	}
+	showServer := len(rules) > 0 && rules[0].Server != ""
+	if showServer {
+		header = append([]string{"Server"}, header...)
+	}
+	if err := writer.Write(header); err != nil {
+		return "", fmt.Errorf("failed to write %s header: %w", name, err)
+	}
+
+	// Write data rows
+	for _, rule := range rules {
+		row := []string{
+			strconv.Itoa(rule.ID),
+			strconv.FormatBool(rule.IsEnabled()),
+			rule.DaysString(),
+			strconv.Itoa(rule.DayOfWeekFlag),
+			rule.StartTimeString(),
+			rule.EndTimeString(),
+			strconv.Itoa(rule.DurationMinutes()),
+			rule.Title,
+			rule.StationName,
+			rule.ChannelID(),
+			strconv.Itoa(rule.RecordingSettings.Priority),
+			strconv.Itoa(rule.RecordingSettings.RecMode),
+		}
+		if showServer {
+			row = append([]string{rule.Server}, row...)
+		}
+		if err := writer.Write(row); err != nil {
+			return "", fmt.Errorf("failed to write %s row: %w", name, err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return "", fmt.Errorf("%s writer error: %w", name, err)
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,123 @@
+package formatters
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+)
+
+// PresetsTableFormatter formats recording presets as a human-readable table
+type PresetsTableFormatter struct{}
+
+// Format converts recording presets to table format
+func (t *PresetsTableFormatter) Format(presets []models.RecPreset) (string, error) {
+	if len(presets) == 0 {
+		return "No recording presets found.\n", nil
+	}
+
+	var sb strings.Builder
+
+	// Header
+	sb.WriteString(fmt.Sprintf("%-5s  %-20s  %-25s  %-8s  %-9s  %s\n",
+		"ID", "Name", "Rec Mode", "Priority", "Margins", "Folders"))
+	sb.WriteString(strings.Repeat("-", 100) + "\n")
+
+	// Data rows
+	for _, preset := range presets {
+		rec := &preset.RecordingSettings
+		sb.WriteString(fmt.Sprintf("%-5d  %-20s  %-25s  %-8d  %-9s  %s\n",
+			preset.ID, truncate(preset.Name, 20), models.RecModeName(rec.RecMode), rec.Priority,
+			preset.MarginsString(), rec.RecFolderList.String()))
+	}
+
+	sb.WriteString(fmt.Sprintf("\nTotal: %d presets\n", len(presets)))
+
+	return sb.String(), nil
+}
+
+// PresetsJSONFormatter formats recording presets as JSON
+type PresetsJSONFormatter struct{}
+
+// Format converts recording presets to JSON format
+func (j *PresetsJSONFormatter) Format(presets []models.RecPreset) (string, error) {
+	data, err := json.MarshalIndent(presets, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// PresetsCSVFormatter formats recording presets as CSV
+type PresetsCSVFormatter struct{}
+
+// Format converts recording presets to CSV format
+func (c *PresetsCSVFormatter) Format(presets []models.RecPreset) (string, error) {
+	return formatPresetsDelimited(presets, ',', "CSV")
+}
+
+// PresetsTSVFormatter formats recording presets as TSV (Tab-Separated Values)
+type PresetsTSVFormatter struct{}
+
+// Format converts recording presets to TSV format
+func (t *PresetsTSVFormatter) Format(presets []models.RecPreset) (string, error) {
+	return formatPresetsDelimited(presets, '\t', "TSV")
+}
+
+// formatPresetsDelimited writes recording presets with the given field delimiter
+// Several recording folders are separated by ";".
+func formatPresetsDelimited(presets []models.RecPreset, comma rune, name string) (string, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Comma = comma
+
+	// Write header
+	header := []string{
+		"ID",
+		"Name",
+		"RecMode",
+		"Priority",
+		"UseMargin",
+		"StartMargin",
+		"EndMargin",
+		"RecFolders",
+	}
+	if err := writer.Write(header); err != nil {
+		return "", fmt.Errorf("failed to write %s header: %w", name, err)
+	}
+
+	// Write data rows
+	for _, preset := range presets {
+		rec := &preset.RecordingSettings
+
+		folders := make([]string, 0, len(rec.RecFolderList))
+		for _, folder := range rec.RecFolderList {
+			folders = append(folders, folder.RecFolder)
+		}
+
+		row := []string{
+			strconv.Itoa(preset.ID),
+			preset.Name,
+			strconv.Itoa(rec.RecMode),
+			strconv.Itoa(rec.Priority),
+			strconv.FormatBool(rec.HasMargins()),
+			strconv.Itoa(rec.StartMargine),
+			strconv.Itoa(rec.EndMargine),
+			strings.Join(folders, ";"),
+		}
+		if err := writer.Write(row); err != nil {
+			return "", fmt.Errorf("failed to write %s row: %w", name, err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return "", fmt.Errorf("%s writer error: %w", name, err)
+	}
+
+	return buf.String(), nil
+}
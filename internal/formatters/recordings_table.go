@@ -18,10 +18,16 @@ func (t *RecordingsTableFormatter) Format(recordings []models.RecordingInfo) (st
 
 	var output strings.Builder
 
+	servers := make([]string, len(recordings))
+	for i, rec := range recordings {
+		servers[i] = rec.Server
+	}
+	server := newServerColumn(servers)
+
 	// Header
-	output.WriteString(fmt.Sprintf("%-6s %-12s %-6s %-50s %-20s\n",
+	output.WriteString(server.header(" ") + fmt.Sprintf("%-6s %-12s %-6s %-50s %-20s\n",
 		"ID", "Date", "Time", "Title", "Station"))
-	output.WriteString(strings.Repeat("-", 100) + "\n")
+	output.WriteString(strings.Repeat("-", 100+server.rule(" ")) + "\n")
 
 	// Data rows
 	for _, rec := range recordings {
@@ -36,7 +42,7 @@ func (t *RecordingsTableFormatter) Format(recordings []models.RecordingInfo) (st
 		title := truncate(rec.Title, 50)
 		station := truncate(rec.StationName, 20)
 
-		output.WriteString(fmt.Sprintf("%-6d %-12s %-6s %-50s %-20s\n",
+		output.WriteString(server.cell(rec.Server, " ") + fmt.Sprintf("%-6d %-12s %-6s %-50s %-20s\n",
 			rec.ID, shortDate, shortTime, title, station))
 	}
 
@@ -8,7 +8,9 @@ import (
 )
 
 // ReservationsTableFormatter formats reservations as a human-readable table
-type ReservationsTableFormatter struct{}
+type ReservationsTableFormatter struct {
+	Sources []string // If set, a Source column describing why each reservation exists (same order as the reservations)
+}
 
 // Format converts reservations to table format
 func (t *ReservationsTableFormatter) Format(reservations []models.ReservationInfo) (string, error) {
@@ -18,13 +20,25 @@ func (t *ReservationsTableFormatter) Format(reservations []models.ReservationInf
 
 	var output strings.Builder
 
+	servers := make([]string, len(reservations))
+	for i, res := range reservations {
+		servers[i] = res.Server
+	}
+	server := newServerColumn(servers)
+
 	// Header
-	output.WriteString(fmt.Sprintf("%-6s %-12s %-6s %-50s %-20s\n",
-		"ID", "Date", "Time", "Title", "Station"))
-	output.WriteString(strings.Repeat("-", 100) + "\n")
+	output.WriteString(server.header(" ") + fmt.Sprintf("%-6s %-8s %-12s %-6s %-50s %-20s",
+		"ID", "Enabled", "Date", "Time", "Title", "Station"))
+	width := 109
+	if t.Sources != nil {
+		output.WriteString(" Source")
+		width += 31
+	}
+	output.WriteString("\n")
+	output.WriteString(strings.Repeat("-", width+server.rule(" ")) + "\n")
 
 	// Data rows
-	for _, res := range reservations {
+	for i, res := range reservations {
 		// Format date: 2025/12/22 -> 12/22
 		dateParts := strings.Split(res.StartDate, "/")
 		shortDate := fmt.Sprintf("%s/%s", dateParts[1], dateParts[2])
@@ -33,11 +47,20 @@ func (t *ReservationsTableFormatter) Format(reservations []models.ReservationInf
 		timeParts := strings.Split(res.StartTime, ":")
 		shortTime := fmt.Sprintf("%s:%s", timeParts[0], timeParts[1])
 
+		enabled := "Yes"
+		if res.IsDisabled() {
+			enabled = "No"
+		}
+
 		title := truncate(res.Title, 50)
 		station := truncate(res.StationName, 20)
 
-		output.WriteString(fmt.Sprintf("%-6d %-12s %-6s %-50s %-20s\n",
-			res.ID, shortDate, shortTime, title, station))
+		output.WriteString(server.cell(res.Server, " ") + fmt.Sprintf("%-6d %-8s %-12s %-6s %-50s %-20s",
+			res.ID, enabled, shortDate, shortTime, title, station))
+		if t.Sources != nil {
+			output.WriteString(" " + t.Sources[i])
+		}
+		output.WriteString("\n")
 	}
 
 	output.WriteString(fmt.Sprintf("\nTotal: %d reservations\n", len(reservations)))
@@ -0,0 +1,42 @@
+package formatters
+
+import "fmt"
+
+// serverColumn is the leading Server column of tables merged from several EMWUI servers
+// It is empty (zero width) when the items were read from a single server.
+type serverColumn struct {
+	width int
+}
+
+// newServerColumn sizes the Server column for the given server names
+func newServerColumn(names []string) serverColumn {
+	width := 0
+	for _, name := range names {
+		width = max(width, len(name))
+	}
+	if width > 0 {
+		width = max(width, len("Server"))
+	}
+	return serverColumn{width: width}
+}
+
+// cell returns the padded value followed by sep, or "" if the column is empty
+func (c serverColumn) cell(value string, sep string) string {
+	if c.width == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%-*s%s", c.width, value, sep)
+}
+
+// header returns the column header followed by sep, or "" if the column is empty
+func (c serverColumn) header(sep string) string {
+	return c.cell("Server", sep)
+}
+
+// rule returns the length the column adds to a separator line
+func (c serverColumn) rule(sep string) int {
+	if c.width == 0 {
+		return 0
+	}
+	return c.width + len(sep)
+}
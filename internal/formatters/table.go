@@ -18,8 +18,14 @@ func (t *TableFormatter) Format(rules []models.AutoAddRule) (string, error) {
 
 	var sb strings.Builder
 
+	servers := make([]string, len(rules))
+	for i, rule := range rules {
+		servers[i] = rule.Server
+	}
+	server := newServerColumn(servers)
+
 	// Header row
-	sb.WriteString(fmt.Sprintf("%-4s  %-8s  %-30s  %-30s  %s\n",
+	sb.WriteString(server.header("  ") + fmt.Sprintf("%-4s  %-8s  %-30s  %-30s  %s\n",
 		"ID", "Enabled", "Keywords", "Exclusions", "Channels"))
 
 	// Data rows
@@ -37,7 +43,7 @@ func (t *TableFormatter) Format(rules []models.AutoAddRule) (string, error) {
 		// Format channel count
 		channelCount := fmt.Sprintf("%d channels", rule.SearchSettings.ChannelCount())
 
-		sb.WriteString(fmt.Sprintf("%-4s  %-8s  %-30s  %-30s  %s\n",
+		sb.WriteString(server.cell(rule.Server, "  ") + fmt.Sprintf("%-4s  %-8s  %-30s  %-30s  %s\n",
 			id, enabled, keywords, exclusions, channelCount))
 	}
 
@@ -31,6 +31,10 @@ func (t *TSVFormatter) Format(rules []models.AutoAddRule) (string, error) {
 		"Priority",
 		"RecMode",
 	}
+	showServer := len(rules) > 0 && rules[0].Server != ""
+	if showServer {
+		header = append([]string{"Server"}, header...)
+	}
 	if err := writer.Write(header); err != nil {
 		return "", fmt.Errorf("failed to write TSV header: %w", err)
 	}
@@ -55,6 +59,9 @@ func (t *TSVFormatter) Format(rules []models.AutoAddRule) (string, error) {
 			strconv.Itoa(rule.RecordingSettings.Priority),
 			strconv.Itoa(rule.RecordingSettings.RecMode),
 		}
+		if showServer {
+			row = append([]string{rule.Server}, row...)
+		}
 		if err := writer.Write(row); err != nil {
 			return "", fmt.Errorf("failed to write TSV row: %w", err)
 		}
@@ -13,32 +13,37 @@ type AutoAddRuleRequest struct {
 	NotKey      string   `json:"not_key"`      // Exclusion keywords (title must not contain)
 	ServiceList []string `json:"service_list"` // Channel list in "ONID-TSID-SID" format
 
+	// Search flags (sent as checkboxes, only when set)
+	DisableFlag     int `json:"disable_flag"`       // 1 = rule is disabled
+	CaseFlag        int `json:"case_flag"`          // 1 = case-sensitive matching
+	RegExpFlag      int `json:"reg_exp_flag"`       // 1 = andKey/notKey are regular expressions
+	AimaiFlag       int `json:"aimai_flag"`         // 1 = fuzzy matching
+	NotContetFlag   int `json:"not_contet_flag"`    // 1 = invert genre filter
+	NotDateFlag     int `json:"not_date_flag"`      // 1 = invert date filter
+	ChkRecEnd       int `json:"chk_rec_end"`        // 1 = skip programs already recorded
+	ChkRecNoService int `json:"chk_rec_no_service"` // 1 = ignore channel when checking recorded programs
+
 	// Default parameters (from curl sample - user should not modify these)
-	AddChg           int    `json:"addchg"`              // 1 = add/change mode
-	TitleOnlyFlag    int    `json:"title_only_flag"`     // 1 = search title only
-	DayList          string `json:"day_list"`            // "on" = all days
-	StartTime        string `json:"start_time"`          // Time filter start "HH:MM"
-	EndTime          string `json:"end_time"`            // Time filter end "HH:MM"
-	DateList         string `json:"date_list"`           // Date filter (empty = all dates)
-	FreeCAFlag       int    `json:"free_ca_flag"`        // 0 = include pay channels
-	ChkDurationMin   int    `json:"chk_duration_min"`    // Minimum duration filter (0 = no limit)
-	ChkDurationMax   int    `json:"chk_duration_max"`    // Maximum duration filter (0 = no limit)
-	ChkRecDay        int    `json:"chk_rec_day"`         // Recording days mask
-	PresetID         int    `json:"preset_id"`           // Preset ID (65535 = custom)
-	ONID             string `json:"onid"`                // Original Network ID filter (empty = all)
-	TSID             string `json:"tsid"`                // Transport Stream ID filter (empty = all)
-	SID              string `json:"sid"`                 // Service ID filter (empty = all)
-	EID              string `json:"eid"`                 // Event ID filter (empty = all)
-	CToken           string `json:"ctok"`                // CSRF token (fetched from HTML page)
-	RecMode          int    `json:"rec_mode"`            // Recording mode (1 = standard)
-	TuijyuuFlag      int    `json:"tuijyuu_flag"`        // Auto-follow flag (1 = enabled)
-	Priority         int    `json:"priority"`            // Recording priority (2 = normal)
-	UseDefMarginFlag int    `json:"use_def_margin_flag"` // Use default margins (1 = yes)
-	ServiceMode      int    `json:"service_mode"`        // Service mode (1 = enabled)
-	TunerID          int    `json:"tuner_id"`            // Tuner ID (0 = auto)
-	SuspendMode      int    `json:"suspend_mode"`        // Suspend mode (0 = disabled)
-	BatFilePath      string `json:"bat_file_path"`       // Batch file path (empty)
-	BatFileTag       string `json:"bat_file_tag"`        // Batch file tag (empty)
+	AddChg         int    `json:"addchg"`           // 1 = add/change mode
+	TitleOnlyFlag  int    `json:"title_only_flag"`  // 1 = search title only
+	DayList        string `json:"day_list"`         // "on" = all days
+	StartTime      string `json:"start_time"`       // Time filter start "HH:MM"
+	EndTime        string `json:"end_time"`         // Time filter end "HH:MM"
+	DateList       string `json:"date_list"`        // Date filter (empty = all dates)
+	FreeCAFlag     int    `json:"free_ca_flag"`     // 0 = all, 1 = free only, 2 = pay only
+	ChkDurationMin int    `json:"chk_duration_min"` // Minimum duration filter in minutes (0 = no limit)
+	ChkDurationMax int    `json:"chk_duration_max"` // Maximum duration filter in minutes (0 = no limit)
+	ChkRecDay      int    `json:"chk_rec_day"`      // Recording days mask
+	ContentList    []int  `json:"content_list"`     // Genre filter as content nibbles (empty = all genres)
+	PresetID       int    `json:"preset_id"`        // Preset ID (RecPresetIDCustom = custom)
+	ONID           string `json:"onid"`             // Original Network ID filter (empty = all)
+	TSID           string `json:"tsid"`             // Transport Stream ID filter (empty = all)
+	SID            string `json:"sid"`              // Service ID filter (empty = all)
+	EID            string `json:"eid"`              // Event ID filter (empty = all)
+	CToken         string `json:"ctok"`             // CSRF token (fetched from HTML page)
+
+	// Recording settings
+	RecSettingRequest
 }
 
 // NewAutoAddRuleRequest creates a new request with default values from curl sample
@@ -50,34 +55,109 @@ func NewAutoAddRuleRequest(andKey string, notKey string, serviceList []string) *
 		ServiceList: serviceList,
 
 		// Defaults from curl sample
-		AddChg:           1,
-		TitleOnlyFlag:    1,
-		DayList:          "on",
-		StartTime:        "00:00",
-		EndTime:          "01:00",
-		DateList:         "",
-		FreeCAFlag:       0,
-		ChkDurationMin:   0,
-		ChkDurationMax:   0,
-		ChkRecDay:        6,
-		PresetID:         0, // 0 = use current settings (from curl sample)
-		ONID:             "",
-		TSID:             "",
-		SID:              "",
-		EID:              "",
-		CToken:           "", // Will be fetched dynamically from HTML page
-		RecMode:          1,
-		TuijyuuFlag:      1,
-		Priority:         2,
-		UseDefMarginFlag: 1,
-		ServiceMode:      1,
-		TunerID:          0,
-		SuspendMode:      0,
-		BatFilePath:      "",
-		BatFileTag:       "",
+		AddChg:         1,
+		TitleOnlyFlag:  1,
+		DayList:        "on",
+		StartTime:      "00:00",
+		EndTime:        "01:00",
+		DateList:       "",
+		FreeCAFlag:     0,
+		ChkDurationMin: 0,
+		ChkDurationMax: 0,
+		ChkRecDay:      6,
+		PresetID:       RecPresetIDCustom, // Use the recording settings posted with the request
+		ONID:           "",
+		TSID:           "",
+		SID:            "",
+		EID:            "",
+		CToken:         "", // Will be fetched dynamically from HTML page
+
+		RecSettingRequest: NewRecSettingRequest(),
 	}
 }
 
+// NewAutoAddRuleRequestFromRule creates a request that reproduces an existing rule,
+// so that posting it back with UpdateAutoAdd keeps every setting unchanged
+func NewAutoAddRuleRequestFromRule(rule *AutoAddRule) *AutoAddRuleRequest {
+	s := &rule.SearchSettings
+
+	serviceList := make([]string, 0, len(s.ServiceList))
+	for _, service := range s.ServiceList {
+		serviceList = append(serviceList, service.String())
+	}
+
+	req := NewAutoAddRuleRequest(s.AndKey, s.NotKey, serviceList)
+
+	// Search settings
+	req.DisableFlag = s.DisableFlag
+	req.CaseFlag = s.CaseFlag
+	req.RegExpFlag = s.RegExpFlag
+	req.TitleOnlyFlag = s.TitleOnlyFlag
+	req.AimaiFlag = s.AimaiFlag
+	req.NotContetFlag = s.NotContetFlag
+	req.NotDateFlag = s.NotDateFlag
+	req.FreeCAFlag = s.FreeCAFlag
+	req.ChkRecEnd = s.ChkRecEnd
+	req.ChkRecDay = s.ChkRecDay
+	req.ChkRecNoService = s.ChkRecNoService
+	req.ChkDurationMin = s.ChkDurationMin
+	req.ChkDurationMax = s.ChkDurationMax
+	req.DateList = FormatDateList(s.DateList)
+	for _, content := range s.ContentList {
+		req.ContentList = append(req.ContentList, content.ContentNibble)
+	}
+
+	// Recording settings, posted as they are rather than taken from a preset
+	req.PresetID = RecPresetIDCustom
+	req.RecSettingRequest = NewRecSettingRequestFromRecordingSettings(&rule.RecordingSettings)
+
+	return req
+}
+
+// SearchSettings returns the search settings of the rule the request creates,
+// as EnumAutoAdd reports them (the inverse of NewAutoAddRuleRequestFromRule)
+func (r *AutoAddRuleRequest) SearchSettings() (*SearchSettings, error) {
+	dates, err := ParseDateList(r.DateList)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dateList: %w", err)
+	}
+
+	serviceList := make([]ServiceInfo, 0, len(r.ServiceList))
+	for _, service := range r.ServiceList {
+		entry, err := ParseServiceListEntry(service)
+		if err != nil {
+			return nil, err
+		}
+		serviceList = append(serviceList, ServiceInfo{ONID: entry.ONID, TSID: entry.TSID, SID: entry.SID})
+	}
+
+	var contentList []ContentData
+	for _, nibble := range r.ContentList {
+		contentList = append(contentList, ContentData{ContentNibble: nibble})
+	}
+
+	return &SearchSettings{
+		DisableFlag:     r.DisableFlag,
+		CaseFlag:        r.CaseFlag,
+		AndKey:          r.AndKey,
+		NotKey:          r.NotKey,
+		RegExpFlag:      r.RegExpFlag,
+		TitleOnlyFlag:   r.TitleOnlyFlag,
+		AimaiFlag:       r.AimaiFlag,
+		NotContetFlag:   r.NotContetFlag,
+		NotDateFlag:     r.NotDateFlag,
+		FreeCAFlag:      r.FreeCAFlag,
+		ChkRecEnd:       r.ChkRecEnd,
+		ChkRecDay:       r.ChkRecDay,
+		ChkRecNoService: r.ChkRecNoService,
+		ChkDurationMin:  r.ChkDurationMin,
+		ChkDurationMax:  r.ChkDurationMax,
+		ContentList:     contentList,
+		DateList:        dates,
+		ServiceList:     serviceList,
+	}, nil
+}
+
 // Validate checks if the request has valid parameters
 func (r *AutoAddRuleRequest) Validate() error {
 	// Validate AndKey (required)
@@ -101,7 +181,25 @@ func (r *AutoAddRuleRequest) Validate() error {
 
 	// NotKey is optional (can be empty)
 
-	return nil
+	// Validate search options
+	if r.FreeCAFlag < 0 || r.FreeCAFlag > 2 {
+		return fmt.Errorf("freeCAFlag must be 0 (all), 1 (free only) or 2 (pay only), got %d", r.FreeCAFlag)
+	}
+
+	if r.ChkDurationMin < 0 || r.ChkDurationMax < 0 {
+		return fmt.Errorf("duration limits cannot be negative")
+	}
+
+	if r.ChkDurationMax > 0 && r.ChkDurationMin > r.ChkDurationMax {
+		return fmt.Errorf("minimum duration (%d) is greater than maximum duration (%d)", r.ChkDurationMin, r.ChkDurationMax)
+	}
+
+	if _, err := ParseDateList(r.DateList); err != nil {
+		return fmt.Errorf("invalid dateList: %w", err)
+	}
+
+	// Validate recording settings
+	return r.RecSettingRequest.Validate()
 }
 
 // ToFormData converts the request to application/x-www-form-urlencoded format
@@ -114,12 +212,25 @@ func (r *AutoAddRuleRequest) ToFormData() string {
 	v.Set("andKey", r.AndKey)
 	v.Set("notKey", r.NotKey) // Can be empty
 
+	// Checkbox flags are only sent when enabled, like the EMWUI form does
+	setFlag(v, "disableFlag", r.DisableFlag)
+	setFlag(v, "caseFlag", r.CaseFlag)
+	setFlag(v, "regExpFlag", r.RegExpFlag)
+	setFlag(v, "titleOnlyFlag", r.TitleOnlyFlag)
+	setFlag(v, "aimaiFlag", r.AimaiFlag)
+	setFlag(v, "notContetFlag", r.NotContetFlag)
+	setFlag(v, "notDateFlag", r.NotDateFlag)
+
 	// Add empty serviceList first (as in curl sample), then actual values
 	v.Add("serviceList", "")
 	for _, service := range r.ServiceList {
 		v.Add("serviceList", service)
 	}
 
+	for _, nibble := range r.ContentList {
+		v.Add("contentList", fmt.Sprintf("%d", nibble))
+	}
+
 	v.Set("dayList", r.DayList)
 	v.Set("startTime", r.StartTime)
 	v.Set("endTime", r.EndTime)
@@ -128,21 +239,56 @@ func (r *AutoAddRuleRequest) ToFormData() string {
 	v.Set("chkDurationMin", fmt.Sprintf("%d", r.ChkDurationMin))
 	v.Set("chkDurationMax", fmt.Sprintf("%d", r.ChkDurationMax))
 	v.Set("chkRecDay", fmt.Sprintf("%d", r.ChkRecDay))
+	setFlag(v, "chkRecEnd", r.ChkRecEnd)
+	setFlag(v, "chkRecNoService", r.ChkRecNoService)
 
 	// presetID appears twice in curl sample
 	v.Add("presetID", fmt.Sprintf("%d", r.PresetID))
 	v.Add("presetID", fmt.Sprintf("%d", r.PresetID))
 
 	v.Set("ctok", r.CToken)
-	v.Set("recMode", fmt.Sprintf("%d", r.RecMode))
-	v.Set("tuijyuuFlag", fmt.Sprintf("%d", r.TuijyuuFlag))
-	v.Set("priority", fmt.Sprintf("%d", r.Priority))
-	v.Set("useDefMarginFlag", fmt.Sprintf("%d", r.UseDefMarginFlag))
-	v.Set("serviceMode", fmt.Sprintf("%d", r.ServiceMode))
-	v.Set("tunerID", fmt.Sprintf("%d", r.TunerID))
-	v.Set("suspendMode", fmt.Sprintf("%d", r.SuspendMode))
-	v.Set("batFilePath", r.BatFilePath)
-	v.Set("batFileTag", r.BatFileTag)
+	r.RecSettingRequest.addFormData(v)
 
 	return v.Encode()
 }
+
+// Diff returns a human-readable list of settings that differ between r and other,
+// formatted as "name: old -> new" where old is taken from r
+func (r *AutoAddRuleRequest) Diff(other *AutoAddRuleRequest) []string {
+	return diffSettings(r.settings(), other.settings())
+}
+
+// settings lists the user-visible settings of the request in a stable order.
+// Transport-only fields (ctok, addchg, presetID) are intentionally excluded.
+func (r *AutoAddRuleRequest) settings() []requestSetting {
+	settings := []requestSetting{
+		{"andKey", r.AndKey},
+		{"notKey", r.NotKey},
+		{"serviceList", strings.Join(r.ServiceList, ",")},
+		{"disabled", fmt.Sprintf("%d", r.DisableFlag)},
+		{"caseSensitive", fmt.Sprintf("%d", r.CaseFlag)},
+		{"regex", fmt.Sprintf("%d", r.RegExpFlag)},
+		{"titleOnly", fmt.Sprintf("%d", r.TitleOnlyFlag)},
+		{"fuzzy", fmt.Sprintf("%d", r.AimaiFlag)},
+		{"contentList", formatContentList(r.ContentList)},
+		{"notContent", fmt.Sprintf("%d", r.NotContetFlag)},
+		{"notDate", fmt.Sprintf("%d", r.NotDateFlag)},
+		{"dateList", r.DateList},
+		{"freeCA", fmt.Sprintf("%d", r.FreeCAFlag)},
+		{"durationMin", fmt.Sprintf("%d", r.ChkDurationMin)},
+		{"durationMax", fmt.Sprintf("%d", r.ChkDurationMax)},
+		{"chkRecEnd", fmt.Sprintf("%d", r.ChkRecEnd)},
+		{"chkRecDay", fmt.Sprintf("%d", r.ChkRecDay)},
+		{"chkRecNoService", fmt.Sprintf("%d", r.ChkRecNoService)},
+	}
+	return append(settings, r.RecSettingRequest.settings()...)
+}
+
+// formatContentList joins content nibbles as hexadecimal values (e.g., "0x00FF,0x0200")
+func formatContentList(contentList []int) string {
+	values := make([]string, 0, len(contentList))
+	for _, nibble := range contentList {
+		values = append(values, fmt.Sprintf("0x%04X", nibble))
+	}
+	return strings.Join(values, ",")
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// ImportPlan lists the rules to create from a backup and the ones skipped as already present
+type ImportPlan struct {
+	Creates []*AutoAddRuleRequest
+	Skipped []AutoAddRule
+}
+
+// LoadAutoAddRuleBackup reads rules from a file written by 'epgtimer list --format json'
+func LoadAutoAddRuleBackup(filename string) ([]AutoAddRule, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []AutoAddRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w (expected output of 'epgtimer list --format json')", filename, err)
+	}
+
+	return rules, nil
+}
+
+// WriteAutoAddRuleBackup writes rules in the format of 'epgtimer list --format json',
+// so that they can be restored with 'epgtimer import'
+func WriteAutoAddRuleBackup(filename string, rules []AutoAddRule) error {
+	data, err := json.MarshalIndent(rules, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0644)
+}
+
+// PlanImport converts backed-up rules to requests, skipping rules whose andKey and
+// channel set already exist on the server (or appear earlier in the backup)
+func PlanImport(backup []AutoAddRule, existing []AutoAddRule) *ImportPlan {
+	plan := &ImportPlan{}
+
+	seen := make(map[string]bool)
+	for i := range existing {
+		seen[existing[i].Key()] = true
+	}
+
+	for i := range backup {
+		key := backup[i].Key()
+		if seen[key] {
+			plan.Skipped = append(plan.Skipped, backup[i])
+			continue
+		}
+		seen[key] = true
+		plan.Creates = append(plan.Creates, NewAutoAddRuleRequestFromRule(&backup[i]))
+	}
+
+	return plan
+}
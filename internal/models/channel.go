@@ -25,6 +25,7 @@ type EnumServiceResponse struct {
 
 // ChannelInfo represents a single channel/service from EnumService API
 type ChannelInfo struct {
+	Server                 string `xml:"-" json:"server,omitempty"` // EMWUI server the channel was read from (multi-server output only)
 	ONID                   int    `xml:"ONID" json:"onid"`
 	TSID                   int    `xml:"TSID" json:"tsid"`
 	SID                    int    `xml:"SID" json:"sid"`
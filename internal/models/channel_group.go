@@ -0,0 +1,198 @@
+package models
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ChannelGroup is a named set of channels, used as "@name" wherever a channel list is accepted
+//
+// A group either lists its channels (Channels and/or File) or selects them from the
+// server's channel list (EnumService) with filters:
+//
+//	channelGroups:
+//	  my-favorites: [32736-32736-1024, key:4, "BS朝日1"]
+//	  bs-all:
+//	    broadcast: bs
+//	  news:
+//	    file: news-channels.txt
+type ChannelGroup struct {
+	Channels []string `yaml:"channels,omitempty"` // Channel references (ONID-TSID-SID, key:N or channel name)
+	File     string   `yaml:"file,omitempty"`     // Channel list file, relative to the config file
+
+	// Filters applied to EnumService (all given filters must match)
+	Broadcast string `yaml:"broadcast,omitempty"` // terrestrial, bs or cs
+	Type      string `yaml:"type,omitempty"`      // tv, radio or data
+	Network   string `yaml:"network,omitempty"`   // Network name (substring match, case-insensitive)
+	Name      string `yaml:"name,omitempty"`      // Channel name (substring match, case-insensitive)
+}
+
+// BuiltinChannelGroups are available without configuration; the config file can redefine them
+var BuiltinChannelGroups = map[string]ChannelGroup{
+	"all":         {Type: "tv"},
+	"terrestrial": {Broadcast: "terrestrial", Type: "tv"},
+	"bs":          {Broadcast: "bs", Type: "tv"},
+	"cs":          {Broadcast: "cs", Type: "tv"},
+}
+
+// UnmarshalYAML accepts a plain list of channels as a shorthand for {channels: [...]}
+// Unknown fields are rejected, as the decoder's KnownFields setting does not reach custom unmarshalers.
+func (g *ChannelGroup) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.SequenceNode {
+		return value.Decode(&g.Channels)
+	}
+
+	if value.Kind == yaml.MappingNode {
+		for i := 0; i < len(value.Content); i += 2 {
+			switch key := value.Content[i]; key.Value {
+			case "channels", "file", "broadcast", "type", "network", "name":
+			default:
+				return fmt.Errorf("line %d: field %s not found in channel group", key.Line, key.Value)
+			}
+		}
+	}
+
+	type plain ChannelGroup
+	return value.Decode((*plain)(g))
+}
+
+// IsFilter reports whether the group selects channels with filters rather than listing them
+func (g *ChannelGroup) IsFilter() bool {
+	return g.Broadcast != "" || g.Type != "" || g.Network != "" || g.Name != ""
+}
+
+// Validate checks that the group is either a list or a filter with known values
+func (g *ChannelGroup) Validate() error {
+	listed := len(g.Channels) > 0 || g.File != ""
+	switch {
+	case listed && g.IsFilter():
+		return fmt.Errorf("a group either lists channels (channels, file) or selects them (broadcast, type, network, name), not both")
+	case !listed && !g.IsFilter():
+		return fmt.Errorf("group has no channels")
+	}
+
+	switch g.Broadcast {
+	case "", "terrestrial", "bs", "cs":
+	default:
+		return fmt.Errorf("invalid broadcast '%s' (expected terrestrial, bs or cs)", g.Broadcast)
+	}
+
+	switch g.Type {
+	case "", "tv", "radio", "data":
+	default:
+		return fmt.Errorf("invalid type '%s' (expected tv, radio or data)", g.Type)
+	}
+
+	for _, ref := range g.Channels {
+		if strings.HasPrefix(ref, "@") {
+			return fmt.Errorf("groups cannot contain other groups ('%s')", ref)
+		}
+	}
+
+	return nil
+}
+
+// Matches reports whether a channel passes the group's filters
+func (g *ChannelGroup) Matches(ch ChannelInfo) bool {
+	if g.Broadcast != "" && ch.Broadcast() != g.Broadcast {
+		return false
+	}
+
+	switch g.Type {
+	case "tv":
+		if !ch.IsTV() {
+			return false
+		}
+	case "radio":
+		if !ch.IsRadio() {
+			return false
+		}
+	case "data":
+		if !ch.IsData() {
+			return false
+		}
+	}
+
+	if g.Network != "" && !strings.Contains(strings.ToLower(ch.NetworkName), strings.ToLower(g.Network)) {
+		return false
+	}
+	if g.Name != "" && !strings.Contains(normalizeChannelName(ch.ServiceName), normalizeChannelName(g.Name)) {
+		return false
+	}
+
+	return true
+}
+
+// Broadcast returns the broadcast system of the channel by its original network ID:
+// "terrestrial", "bs", "cs" or "other"
+func (c *ChannelInfo) Broadcast() string {
+	switch {
+	case c.ONID == 4:
+		return "bs"
+	case c.ONID == 6 || c.ONID == 7 || c.ONID == 10:
+		return "cs"
+	case c.ONID >= 0x7880 && c.ONID <= 0x7FE8:
+		return "terrestrial"
+	default:
+		return "other"
+	}
+}
+
+// ValidateChannelGroupName checks that a group name can be referenced as "@name"
+func ValidateChannelGroupName(name string) error {
+	if name == "" || strings.ContainsAny(name, "@,# \t") {
+		return fmt.Errorf("invalid channel group name '%s' (must not be empty or contain '@', ',', '#' or spaces)", name)
+	}
+	return nil
+}
+
+// ChannelGroupNames returns the group names in alphabetical order
+func ChannelGroupNames(groups map[string]ChannelGroup) []string {
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ReadChannelFile reads a channel list file
+// File format: one channel per line (ONID-TSID-SID, key:N, channel name or @group).
+// Empty lines and comments (from # to the end of the line) are ignored.
+func ReadChannelFile(filename string) ([]string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	var channels []string
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		line = strings.TrimSpace(line)
+
+		// Skip empty lines and comments
+		if line == "" {
+			continue
+		}
+
+		channels = append(channels, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading file: %w", err)
+	}
+
+	if len(channels) == 0 {
+		return nil, fmt.Errorf("file contains no channels")
+	}
+
+	return channels, nil
+}
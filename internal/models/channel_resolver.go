@@ -0,0 +1,217 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ChannelResolver resolves channel references to channel IDs (ONID-TSID-SID)
+//
+// A reference is one of:
+//   - a channel ID, returned as is (e.g., "32736-32736-1024")
+//   - a remote-control key number (e.g., "key:1")
+//   - a service name (e.g., "NHK総合"), matched case-insensitively and ignoring spaces and
+//     full-width/half-width differences; an exact match wins over a prefix match, which
+//     wins over a substring match
+//   - a channel group (e.g., "@bs"), only in channel lists (see ResolveAll)
+type ChannelResolver struct {
+	Channels []ChannelInfo           // Channels from EnumService
+	Groups   map[string]ChannelGroup // Channel groups by name (without "@")
+}
+
+// IsChannelID reports whether ref is a channel ID in ONID-TSID-SID format
+func IsChannelID(ref string) bool {
+	_, err := ParseServiceListEntry(ref)
+	return err == nil
+}
+
+// Resolve returns the channel ID for a channel reference
+// An error listing the candidates is returned if the reference matches several channels.
+func (r *ChannelResolver) Resolve(ref string) (string, error) {
+	ref = strings.TrimSpace(ref)
+	if IsChannelID(ref) {
+		return ref, nil
+	}
+
+	if strings.HasPrefix(ref, "@") {
+		return "", fmt.Errorf("channel group '%s' cannot be used here, a single channel is expected", ref)
+	}
+
+	if key, ok := strings.CutPrefix(strings.ToLower(ref), "key:"); ok {
+		return r.resolveKey(ref, key)
+	}
+
+	return r.resolveName(ref)
+}
+
+// ResolveAll resolves every reference, expanding channel groups ("@name")
+// The order is kept and duplicate channels are removed.
+func (r *ChannelResolver) ResolveAll(refs []string) ([]string, error) {
+	ids := make([]string, 0, len(refs))
+	seen := make(map[string]bool)
+	add := func(id string) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	for _, ref := range refs {
+		ref = strings.TrimSpace(ref)
+		if name, ok := strings.CutPrefix(ref, "@"); ok {
+			groupIDs, err := r.resolveGroup(name)
+			if err != nil {
+				return nil, err
+			}
+			for _, id := range groupIDs {
+				add(id)
+			}
+			continue
+		}
+
+		id, err := r.Resolve(ref)
+		if err != nil {
+			return nil, err
+		}
+		add(id)
+	}
+	return ids, nil
+}
+
+// resolveGroup returns the channel IDs of a channel group
+func (r *ChannelResolver) resolveGroup(name string) ([]string, error) {
+	group, ok := r.Groups[name]
+	if !ok {
+		return nil, fmt.Errorf("channel group '@%s' not found (available: @%s)", name, strings.Join(ChannelGroupNames(r.Groups), ", @"))
+	}
+
+	var ids []string
+	if group.IsFilter() {
+		for _, ch := range r.Channels {
+			if group.Matches(ch) {
+				ids = append(ids, ch.ChannelID())
+			}
+		}
+		if len(ids) == 0 {
+			return nil, fmt.Errorf("channel group '@%s' matches no channels", name)
+		}
+		return ids, nil
+	}
+
+	refs := group.Channels
+	if group.File != "" {
+		fileRefs, err := ReadChannelFile(group.File)
+		if err != nil {
+			return nil, fmt.Errorf("channel group '@%s': %w", name, err)
+		}
+		refs = append(append([]string{}, refs...), fileRefs...)
+	}
+
+	for _, ref := range refs {
+		if strings.HasPrefix(ref, "@") {
+			return nil, fmt.Errorf("channel group '@%s': groups cannot contain other groups ('%s')", name, ref)
+		}
+		id, err := r.Resolve(ref)
+		if err != nil {
+			return nil, fmt.Errorf("channel group '@%s': %w", name, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// resolveKey matches the remote-control key number; TV services are preferred
+// over radio and data services on the same key
+func (r *ChannelResolver) resolveKey(ref string, key string) (string, error) {
+	keyID, err := strconv.Atoi(strings.TrimSpace(key))
+	if err != nil || keyID <= 0 {
+		return "", fmt.Errorf("invalid channel '%s': remote-control key must be a positive number (e.g., key:1)", ref)
+	}
+
+	var matches []ChannelInfo
+	for _, ch := range r.Channels {
+		if ch.RemoteControlKeyID == keyID {
+			matches = append(matches, ch)
+		}
+	}
+
+	var tv []ChannelInfo
+	for _, ch := range matches {
+		if ch.IsTV() {
+			tv = append(tv, ch)
+		}
+	}
+	if len(tv) > 0 {
+		matches = tv
+	}
+
+	return pickChannel(ref, matches)
+}
+
+// resolveName matches the service name: exact, then prefix, then substring
+func (r *ChannelResolver) resolveName(ref string) (string, error) {
+	name := normalizeChannelName(ref)
+	if name == "" {
+		return "", fmt.Errorf("invalid channel '%s': expected a channel ID (ONID-TSID-SID), key:N or a channel name", ref)
+	}
+
+	var exact, prefix, substring []ChannelInfo
+	for _, ch := range r.Channels {
+		serviceName := normalizeChannelName(ch.ServiceName)
+		switch {
+		case serviceName == name:
+			exact = append(exact, ch)
+		case strings.HasPrefix(serviceName, name):
+			prefix = append(prefix, ch)
+		case strings.Contains(serviceName, name):
+			substring = append(substring, ch)
+		}
+	}
+
+	for _, matches := range [][]ChannelInfo{exact, prefix, substring} {
+		if len(matches) > 0 {
+			return pickChannel(ref, matches)
+		}
+	}
+	return pickChannel(ref, nil)
+}
+
+// pickChannel returns the only match, or an error for no or several matches
+func pickChannel(ref string, matches []ChannelInfo) (string, error) {
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no channel matches '%s'\n\nTo list channels, run:\n  epgtimer channels --name \"...\"", ref)
+	case 1:
+		return matches[0].ChannelID(), nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "channel '%s' is ambiguous, it matches %d channels:\n", ref, len(matches))
+	for _, ch := range matches {
+		fmt.Fprintf(&sb, "  %-18s %s (%s", ch.ChannelID(), ch.ServiceName, ch.NetworkName)
+		if ch.RemoteControlKeyID > 0 {
+			fmt.Fprintf(&sb, ", key:%d", ch.RemoteControlKeyID)
+		}
+		sb.WriteString(")\n")
+	}
+	sb.WriteString("\nUse the channel ID or a more specific name.")
+	return "", errors.New(sb.String())
+}
+
+// normalizeChannelName lowercases a name, converts full-width ASCII to half-width and drops spaces
+// so that "ＮＨＫ総合　１" matches "nhk総合1"
+func normalizeChannelName(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= '！' && r <= '～':
+			r -= '！' - '!'
+		case r == '　' || r == ' ' || r == '\t':
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return strings.ToLower(sb.String())
+}
@@ -0,0 +1,213 @@
+package models
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the epgtimer configuration file with named server profiles
+//
+// Example (~/.config/epgtimer/config.yaml):
+//
+//	defaultProfile: living-room
+//	profiles:
+//	  living-room:
+//	    endpoint: http://192.168.1.10:5510
+//	    format: table
+//	    serviceList: [32736-32736-1024, 32736-32736-1025]
+//	  parents:
+//	    endpoint: https://parents.example.net:5510
+//	    user: edcb
+//	    password: secret
+//	    timeout: 30s
+//	channelGroups:
+//	  my-favorites: [32736-32736-1024, key:4]
+type Config struct {
+	DefaultProfile string                  `yaml:"defaultProfile,omitempty"`
+	Profiles       map[string]*Profile     `yaml:"profiles"`
+	ChannelGroups  map[string]ChannelGroup `yaml:"channelGroups,omitempty"` // Shared by all profiles
+}
+
+// Profile holds the settings of one EMWUI server
+// Every setting is optional; command-line flags and environment variables take precedence.
+type Profile struct {
+	Endpoint string `yaml:"endpoint,omitempty"`
+
+	// Authentication and TLS
+	User               string `yaml:"user,omitempty"`
+	Password           string `yaml:"password,omitempty"`
+	CACert             string `yaml:"caCert,omitempty"` // Relative paths are resolved against the config file
+	InsecureSkipVerify *bool  `yaml:"insecureSkipVerify,omitempty"`
+
+	// Request settings (nil = use the flag default)
+	Timeout   *time.Duration `yaml:"timeout,omitempty"`
+	Retries   *int           `yaml:"retries,omitempty"`
+	RetryWait *time.Duration `yaml:"retryWait,omitempty"`
+
+	// Command defaults
+	Format      string   `yaml:"format,omitempty"`      // Output format of list, channels, reservations, recordings and epg
+	ServiceList []string `yaml:"serviceList,omitempty"` // Channels used by add when no --serviceList is given (ONID-TSID-SID)
+
+	// Channel groups of this server, in addition to (or replacing) the shared ones
+	ChannelGroups map[string]ChannelGroup `yaml:"channelGroups,omitempty"`
+}
+
+// DefaultConfigPath returns the default location of the configuration file
+// ($XDG_CONFIG_HOME/epgtimer/config.yaml, or ~/.config/epgtimer/config.yaml)
+func DefaultConfigPath() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "epgtimer", "config.yaml"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate home directory: %w", err)
+	}
+	return filepath.Join(home, ".config", "epgtimer", "config.yaml"), nil
+}
+
+// LoadConfig reads and validates a configuration file
+func LoadConfig(filename string) (*Config, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var config Config
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&config); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", filename, err)
+	}
+
+	// CA certificate and channel list paths are relative to the config file
+	resolvePath := func(path string) string {
+		if path == "" || filepath.IsAbs(path) {
+			return path
+		}
+		return filepath.Join(filepath.Dir(filename), path)
+	}
+	resolveGroupFiles := func(groups map[string]ChannelGroup) {
+		for name, group := range groups {
+			group.File = resolvePath(group.File)
+			groups[name] = group
+		}
+	}
+
+	resolveGroupFiles(config.ChannelGroups)
+	for _, profile := range config.Profiles {
+		profile.CACert = resolvePath(profile.CACert)
+		resolveGroupFiles(profile.ChannelGroups)
+	}
+
+	return &config, nil
+}
+
+// Validate checks the default profile and the settings of every profile
+func (c *Config) Validate() error {
+	if c.DefaultProfile != "" {
+		if _, ok := c.Profiles[c.DefaultProfile]; !ok {
+			return fmt.Errorf("defaultProfile '%s' is not defined in profiles", c.DefaultProfile)
+		}
+	}
+
+	if err := validateChannelGroups(c.ChannelGroups); err != nil {
+		return err
+	}
+
+	for _, name := range c.ProfileNames() {
+		profile := c.Profiles[name]
+		if profile == nil {
+			return fmt.Errorf("profile '%s' is empty", name)
+		}
+		if err := validateChannelGroups(profile.ChannelGroups); err != nil {
+			return fmt.Errorf("profile '%s': %w", name, err)
+		}
+		if profile.Timeout != nil && *profile.Timeout < 0 {
+			return fmt.Errorf("profile '%s': timeout must not be negative", name)
+		}
+		if profile.Retries != nil && *profile.Retries < 0 {
+			return fmt.Errorf("profile '%s': retries must not be negative", name)
+		}
+		if profile.RetryWait != nil && *profile.RetryWait < 0 {
+			return fmt.Errorf("profile '%s': retryWait must not be negative", name)
+		}
+		for i, service := range profile.ServiceList {
+			if _, err := ParseServiceListEntry(service); err != nil {
+				return fmt.Errorf("profile '%s': invalid channel in serviceList[%d]: %w", name, i, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateChannelGroups checks every channel group
+func validateChannelGroups(groups map[string]ChannelGroup) error {
+	for _, name := range ChannelGroupNames(groups) {
+		group := groups[name]
+		if err := ValidateChannelGroupName(name); err != nil {
+			return err
+		}
+		if err := group.Validate(); err != nil {
+			return fmt.Errorf("channel group '%s': %w", name, err)
+		}
+	}
+	return nil
+}
+
+// ChannelGroupsFor returns the channel groups available with a profile:
+// the built-in groups, overridden by the shared groups, overridden by the profile's groups.
+// profile may be nil.
+func (c *Config) ChannelGroupsFor(profile *Profile) map[string]ChannelGroup {
+	groups := make(map[string]ChannelGroup)
+	for _, layer := range []map[string]ChannelGroup{BuiltinChannelGroups, c.ChannelGroups} {
+		for name, group := range layer {
+			groups[name] = group
+		}
+	}
+	if profile != nil {
+		for name, group := range profile.ChannelGroups {
+			groups[name] = group
+		}
+	}
+	return groups
+}
+
+// ProfileNames returns the profile names in alphabetical order
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Profile returns the named profile, or the default profile if name is empty
+// Returns nil if name is empty and no default profile is configured.
+func (c *Config) Profile(name string) (*Profile, error) {
+	if name == "" {
+		name = c.DefaultProfile
+		if name == "" {
+			return nil, nil
+		}
+	}
+
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("profile '%s' not found (available: %s)", name, strings.Join(c.ProfileNames(), ", "))
+	}
+	return profile, nil
+}
@@ -0,0 +1,246 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Conflict statuses of a reservation
+const (
+	ConflictStatusOK      = "ok"      // Recorded in full
+	ConflictStatusPartial = "partial" // Only part of the program is recorded
+	ConflictStatusFail    = "fail"    // Not recorded (no free tuner)
+)
+
+// ConflictOptions controls how reservation conflicts are computed
+type ConflictOptions struct {
+	// Tuners is the number of tuners to simulate the allocation with.
+	// 0 uses the status reported by the server (overlapMode) instead.
+	Tuners int
+	// DefaultStartMargin and DefaultEndMargin (seconds) apply to reservations
+	// without custom margins, mirroring EpgTimer's default margin setting
+	DefaultStartMargin int
+	DefaultEndMargin   int
+}
+
+// ConflictEntry is a reservation within a conflict, with its recording window
+type ConflictEntry struct {
+	ID            int       `json:"id"`
+	Title         string    `json:"title"`
+	StationName   string    `json:"station_name"`
+	ChannelID     string    `json:"channel_id"`
+	Start         time.Time `json:"start"` // Including start margin
+	End           time.Time `json:"end"`   // Including end margin
+	Priority      int       `json:"priority"`
+	TunerID       int       `json:"tuner_id"`                 // Tuner fixed in the reservation (0 = auto)
+	AssignedTuner int       `json:"assigned_tuner,omitempty"` // Simulated tuner (1-based, 0 = none)
+	Status        string    `json:"status"`
+
+	onid, tsid int
+}
+
+// ConflictGroup is a set of reservations whose recording windows overlap
+type ConflictGroup struct {
+	Start        time.Time       `json:"start"`
+	End          time.Time       `json:"end"`
+	TunersNeeded int             `json:"tuners_needed"` // Peak number of tuners needed at once
+	Failing      int             `json:"failing"`       // Reservations that will not be recorded in full
+	Reservations []ConflictEntry `json:"reservations"`
+}
+
+// StartDateTime parses and returns the start date and time in local time
+func (r *ReservationInfo) StartDateTime() (time.Time, error) {
+	return time.ParseInLocation("2006/01/02 15:04:05", r.StartDate+" "+r.StartTime, time.Local)
+}
+
+// RecordingWindow returns the time the reservation occupies a tuner, including margins
+// A positive start margin starts recording earlier; a positive end margin ends it later.
+// The default margins are used when the reservation has no custom margins.
+func (r *ReservationInfo) RecordingWindow(defaultStartMargin, defaultEndMargin int) (time.Time, time.Time, error) {
+	start, err := r.StartDateTime()
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid start time '%s %s' of reservation %d: %w", r.StartDate, r.StartTime, r.ID, err)
+	}
+	end := start.Add(time.Duration(r.DurationSecond) * time.Second)
+
+	startMargin, endMargin := defaultStartMargin, defaultEndMargin
+	if r.RecSetting.HasMargins() {
+		startMargin, endMargin = r.RecSetting.StartMargin, r.RecSetting.EndMargin
+	}
+
+	return start.Add(-time.Duration(startMargin) * time.Second), end.Add(time.Duration(endMargin) * time.Second), nil
+}
+
+// conflictStatus converts the server's overlapMode to a conflict status
+func conflictStatus(overlapMode int) string {
+	switch overlapMode {
+	case 0:
+		return ConflictStatusOK
+	case 1:
+		return ConflictStatusPartial
+	default:
+		return ConflictStatusFail
+	}
+}
+
+// overlaps reports whether two recording windows overlap
+func (e *ConflictEntry) overlaps(other *ConflictEntry) bool {
+	return e.Start.Before(other.End) && other.Start.Before(e.End)
+}
+
+// sameStream reports whether two entries are on the same transport stream,
+// which EpgTimer can record with a single tuner
+func (e *ConflictEntry) sameStream(other *ConflictEntry) bool {
+	return e.onid == other.onid && e.tsid == other.tsid
+}
+
+// FindConflicts groups enabled reservations whose recording windows overlap
+// Only groups with at least two reservations are returned, in chronological order.
+func FindConflicts(reservations []ReservationInfo, opts ConflictOptions) ([]ConflictGroup, error) {
+	var entries []ConflictEntry
+	for i := range reservations {
+		res := &reservations[i]
+		if res.IsDisabled() {
+			continue
+		}
+
+		start, end, err := res.RecordingWindow(opts.DefaultStartMargin, opts.DefaultEndMargin)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, ConflictEntry{
+			ID:          res.ID,
+			Title:       res.Title,
+			StationName: res.StationName,
+			ChannelID:   res.ChannelID(),
+			Start:       start,
+			End:         end,
+			Priority:    res.RecSetting.Priority,
+			TunerID:     res.RecSetting.TunerID,
+			Status:      conflictStatus(res.OverlapMode),
+			onid:        res.ONID,
+			tsid:        res.TSID,
+		})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].Start.Equal(entries[j].Start) {
+			return entries[i].Start.Before(entries[j].Start)
+		}
+		return entries[i].ID < entries[j].ID
+	})
+
+	// Sweep through the sorted windows, merging overlapping ones into groups
+	var groups []ConflictGroup
+	for i := 0; i < len(entries); {
+		group := ConflictGroup{Start: entries[i].Start, End: entries[i].End}
+		j := i
+		for ; j < len(entries) && entries[j].Start.Before(group.End); j++ {
+			if entries[j].End.After(group.End) {
+				group.End = entries[j].End
+			}
+		}
+
+		if j-i > 1 {
+			group.Reservations = append([]ConflictEntry{}, entries[i:j]...)
+			if opts.Tuners > 0 {
+				simulateTuners(group.Reservations, opts.Tuners)
+			}
+			group.TunersNeeded = tunersNeeded(group.Reservations)
+			for _, entry := range group.Reservations {
+				if entry.Status != ConflictStatusOK {
+					group.Failing++
+				}
+			}
+			groups = append(groups, group)
+		}
+		i = j
+	}
+
+	return groups, nil
+}
+
+// tunersNeeded returns the peak number of transport streams recorded at the same time
+func tunersNeeded(entries []ConflictEntry) int {
+	peak := 0
+	for i := range entries {
+		// The number of streams only grows when a recording starts
+		var streams []*ConflictEntry
+		for j := range entries {
+			active := !entries[j].Start.After(entries[i].Start) && entries[j].End.After(entries[i].Start)
+			if !active {
+				continue
+			}
+
+			shared := false
+			for _, s := range streams {
+				if s.sameStream(&entries[j]) {
+					shared = true
+					break
+				}
+			}
+			if !shared {
+				streams = append(streams, &entries[j])
+			}
+		}
+		if len(streams) > peak {
+			peak = len(streams)
+		}
+	}
+	return peak
+}
+
+// simulateTuners assigns tuners the way EpgTimer does: higher priority first,
+// then earlier start. Reservations on the same transport stream share a tuner.
+// Reservations with a fixed tuner only compete with others on that tuner;
+// the remaining reservations use the pool of tuners 1..tuners.
+func simulateTuners(entries []ConflictEntry, tuners int) {
+	order := make([]int, len(entries))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		ea, eb := &entries[order[a]], &entries[order[b]]
+		if ea.Priority != eb.Priority {
+			return ea.Priority > eb.Priority
+		}
+		return ea.Start.Before(eb.Start)
+	})
+
+	fixed := make(map[int][]*ConflictEntry)
+	pool := make([][]*ConflictEntry, tuners)
+
+	fits := func(assigned []*ConflictEntry, entry *ConflictEntry) bool {
+		for _, other := range assigned {
+			if entry.overlaps(other) && !entry.sameStream(other) {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, i := range order {
+		entry := &entries[i]
+		entry.AssignedTuner = 0
+		entry.Status = ConflictStatusFail
+
+		if entry.TunerID > 0 {
+			if fits(fixed[entry.TunerID], entry) {
+				fixed[entry.TunerID] = append(fixed[entry.TunerID], entry)
+				entry.Status = ConflictStatusOK
+			}
+			continue
+		}
+
+		for t := range pool {
+			if fits(pool[t], entry) {
+				pool[t] = append(pool[t], entry)
+				entry.AssignedTuner = t + 1
+				entry.Status = ConflictStatusOK
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// dayOfWeekNames maps EpgTimer day-of-week numbers (0 = Sunday) to the
+// Japanese abbreviations used in EMWUI's dateList form field
+var dayOfWeekNames = []string{"日", "月", "火", "水", "木", "金", "土"}
+
+// dayOfWeekAliases maps accepted day names to day-of-week numbers
+var dayOfWeekAliases = map[string]int{
+	"日": 0, "sun": 0, "sunday": 0, "0": 0,
+	"月": 1, "mon": 1, "monday": 1, "1": 1,
+	"火": 2, "tue": 2, "tuesday": 2, "2": 2,
+	"水": 3, "wed": 3, "wednesday": 3, "3": 3,
+	"木": 4, "thu": 4, "thursday": 4, "4": 4,
+	"金": 5, "fri": 5, "friday": 5, "5": 5,
+	"土": 6, "sat": 6, "saturday": 6, "6": 6,
+}
+
+// dateListEntryPattern matches a single dateList entry such as "月-21:00-火-1:30"
+var dateListEntryPattern = regexp.MustCompile(`^([日月火水木金土])-(\d{1,2}):(\d{2})-([日月火水木金土])-(\d{1,2}):(\d{2})$`)
+
+// dayOfWeekName returns the Japanese abbreviation for a day-of-week number
+func dayOfWeekName(day int) string {
+	if day < 0 || day >= len(dayOfWeekNames) {
+		return fmt.Sprintf("%d", day)
+	}
+	return dayOfWeekNames[day]
+}
+
+// dayOfWeekIndex returns the day-of-week number for a Japanese abbreviation
+func dayOfWeekIndex(name string) int {
+	for i, n := range dayOfWeekNames {
+		if n == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// FormatDateList converts date filter entries to EMWUI's dateList form value
+// Example: [{1, 21, 0, 1, 23, 30}] -> "月-21:00-月-23:30"
+func FormatDateList(dates []DateInfo) string {
+	entries := make([]string, 0, len(dates))
+	for _, d := range dates {
+		entries = append(entries, d.String())
+	}
+	return strings.Join(entries, ",")
+}
+
+// ParseDateList parses EMWUI's dateList form value (comma-separated entries)
+// An empty string means no date filter
+func ParseDateList(s string) ([]DateInfo, error) {
+	var dates []DateInfo
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		m := dateListEntryPattern.FindStringSubmatch(entry)
+		if m == nil {
+			return nil, fmt.Errorf("invalid entry '%s': expected format like '月-21:00-月-23:30'", entry)
+		}
+
+		d := DateInfo{StartDayOfWeek: dayOfWeekIndex(m[1]), EndDayOfWeek: dayOfWeekIndex(m[4])}
+		d.StartHour, _ = strconv.Atoi(m[2])
+		d.StartMin, _ = strconv.Atoi(m[3])
+		d.EndHour, _ = strconv.Atoi(m[5])
+		d.EndMin, _ = strconv.Atoi(m[6])
+
+		if d.StartHour > 23 || d.EndHour > 23 || d.StartMin > 59 || d.EndMin > 59 {
+			return nil, fmt.Errorf("invalid time in entry '%s'", entry)
+		}
+
+		dates = append(dates, d)
+	}
+	return dates, nil
+}
+
+// ParseDaysOfWeek converts day names to day-of-week numbers (0 = Sunday)
+// Accepts Japanese (月), English (mon, monday) and numeric (1) names,
+// plus "weekdays" and "weekends" shortcuts
+func ParseDaysOfWeek(values []string) ([]int, error) {
+	seen := make(map[int]bool)
+	var days []int
+
+	add := func(day int) {
+		if !seen[day] {
+			seen[day] = true
+			days = append(days, day)
+		}
+	}
+
+	for _, value := range values {
+		name := strings.ToLower(strings.TrimSpace(value))
+		switch name {
+		case "":
+			continue
+		case "weekdays", "平日":
+			for day := 1; day <= 5; day++ {
+				add(day)
+			}
+		case "weekends", "土日":
+			add(6)
+			add(0)
+		default:
+			day, ok := dayOfWeekAliases[name]
+			if !ok {
+				return nil, fmt.Errorf("unknown day of week '%s'", value)
+			}
+			add(day)
+		}
+	}
+
+	return days, nil
+}
+
+// parseClock parses "HH:MM" into hour and minute (24:00 is accepted as end of day)
+func parseClock(s string) (int, int, error) {
+	parts := strings.Split(strings.TrimSpace(s), ":")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid time '%s': expected HH:MM", s)
+	}
+
+	hour, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid hour in '%s'", s)
+	}
+	min, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid minute in '%s'", s)
+	}
+
+	if hour < 0 || hour > 24 || min < 0 || min > 59 || (hour == 24 && min != 0) {
+		return 0, 0, fmt.Errorf("time '%s' is out of range", s)
+	}
+	return hour, min, nil
+}
+
+// BuildDateList creates one weekly window per day between start and end ("HH:MM")
+// An empty days list means every day. A window whose end is not after its start
+// ends on the following day (e.g., 23:00-01:00).
+func BuildDateList(days []int, start, end string) ([]DateInfo, error) {
+	if start == "" {
+		start = "00:00"
+	}
+	if end == "" {
+		end = "24:00"
+	}
+
+	startHour, startMin, err := parseClock(start)
+	if err != nil {
+		return nil, err
+	}
+	if startHour == 24 {
+		return nil, fmt.Errorf("start time cannot be 24:00")
+	}
+	endHour, endMin, err := parseClock(end)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(days) == 0 {
+		days = []int{0, 1, 2, 3, 4, 5, 6}
+	}
+
+	dates := make([]DateInfo, 0, len(days))
+	for _, day := range days {
+		endDay := day
+		if endHour == 24 {
+			// 24:00 is stored as 0:00 of the next day
+			endHour, endMin = 0, 0
+		}
+		if endHour*60+endMin <= startHour*60+startMin {
+			endDay = (day + 1) % 7
+		}
+
+		dates = append(dates, DateInfo{
+			StartDayOfWeek: day,
+			StartHour:      startHour,
+			StartMin:       startMin,
+			EndDayOfWeek:   endDay,
+			EndHour:        endHour,
+			EndMin:         endMin,
+		})
+	}
+
+	return dates, nil
+}
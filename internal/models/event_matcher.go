@@ -0,0 +1,205 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// EventMatcher tests EPG events against the search settings of an automatic recording rule,
+// following EpgTimer's keyword semantics:
+//   - andKey: every space-separated keyword must be found (one regular expression with RegExpFlag)
+//   - notKey: events containing any space-separated keyword are excluded (one regular expression with RegExpFlag)
+//   - keywords are searched in the title only (TitleOnlyFlag), or in the title, description and extended description
+//   - matching ignores full-width/half-width differences of ASCII characters, and case unless CaseFlag is set
+//   - AimaiFlag (fuzzy) matches a keyword whose characters all appear in the text, in any order
+//
+// The date, duration, free-CA and channel filters of the rule are applied as well.
+// Genre filters are not supported.
+type EventMatcher struct {
+	settings SearchSettings
+	andKeys  []string
+	notKeys  []string
+	andRegex *regexp.Regexp
+	notRegex *regexp.Regexp
+	channels map[string]bool // nil = all channels
+}
+
+// NewEventMatcher compiles the keywords of the search settings
+func NewEventMatcher(s *SearchSettings) (*EventMatcher, error) {
+	m := &EventMatcher{settings: *s}
+
+	if s.IsRegex() {
+		var err error
+		if m.andRegex, err = m.compile(s.AndKey); err != nil {
+			return nil, fmt.Errorf("invalid andKey regular expression: %w", err)
+		}
+		if m.notRegex, err = m.compile(s.NotKey); err != nil {
+			return nil, fmt.Errorf("invalid notKey regular expression: %w", err)
+		}
+	} else {
+		m.andKeys = m.keywords(s.AndKey)
+		m.notKeys = m.keywords(s.NotKey)
+	}
+
+	if len(s.ServiceList) > 0 {
+		m.channels = make(map[string]bool, len(s.ServiceList))
+		for _, service := range s.ServiceList {
+			m.channels[service.String()] = true
+		}
+	}
+
+	return m, nil
+}
+
+// compile compiles a keyword regular expression; an empty expression returns nil
+func (m *EventMatcher) compile(expr string) (*regexp.Regexp, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, nil
+	}
+	expr = foldWidth(expr)
+	if m.settings.CaseFlag == 0 {
+		expr = "(?i)" + expr
+	}
+	return regexp.Compile(expr)
+}
+
+// keywords splits space-separated keywords (half-width or full-width spaces)
+func (m *EventMatcher) keywords(s string) []string {
+	return strings.Fields(m.normalize(s))
+}
+
+// normalize folds full-width ASCII characters, and case unless matching is case-sensitive
+func (m *EventMatcher) normalize(s string) string {
+	s = foldWidth(s)
+	if m.settings.CaseFlag == 0 {
+		s = strings.ToLower(s)
+	}
+	return s
+}
+
+// Matches returns true if the event matches every condition of the search settings
+func (m *EventMatcher) Matches(e *EventInfo) bool {
+	if m.channels != nil && !m.channels[e.ChannelID()] {
+		return false
+	}
+	if !m.matchesFreeCA(e) || !m.matchesDuration(e) || !m.matchesDate(e) {
+		return false
+	}
+	return m.matchesKeywords(e)
+}
+
+// matchesKeywords checks andKey and notKey against the searched text
+func (m *EventMatcher) matchesKeywords(e *EventInfo) bool {
+	text := e.EventName
+	if m.settings.TitleOnlyFlag == 0 {
+		text = strings.Join([]string{e.EventName, e.EventText, e.EventExtText}, "\n")
+	}
+
+	if m.settings.IsRegex() {
+		text = foldWidth(text)
+		if m.andRegex != nil && !m.andRegex.MatchString(text) {
+			return false
+		}
+		return m.notRegex == nil || !m.notRegex.MatchString(text)
+	}
+
+	text = m.normalize(text)
+	for _, key := range m.andKeys {
+		if !m.contains(text, key) {
+			return false
+		}
+	}
+	for _, key := range m.notKeys {
+		if m.contains(text, key) {
+			return false
+		}
+	}
+	return true
+}
+
+// contains reports whether the keyword is found in the text (fuzzy with AimaiFlag)
+func (m *EventMatcher) contains(text, key string) bool {
+	if m.settings.AimaiFlag == 0 {
+		return strings.Contains(text, key)
+	}
+	for _, r := range key {
+		if !strings.ContainsRune(text, r) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesFreeCA checks the free-CA filter (1 = free programs only, 2 = pay programs only)
+func (m *EventMatcher) matchesFreeCA(e *EventInfo) bool {
+	switch m.settings.FreeCAFlag {
+	case 1:
+		return e.IsFreeCA()
+	case 2:
+		return !e.IsFreeCA()
+	}
+	return true
+}
+
+// matchesDuration checks the duration filter (in minutes, 0 = no limit)
+func (m *EventMatcher) matchesDuration(e *EventInfo) bool {
+	minutes := e.DurationMinutes()
+	if m.settings.ChkDurationMin > 0 && minutes < m.settings.ChkDurationMin {
+		return false
+	}
+	if m.settings.ChkDurationMax > 0 && minutes > m.settings.ChkDurationMax {
+		return false
+	}
+	return true
+}
+
+// matchesDate checks whether the event starts within one of the weekly windows (inverted with NotDateFlag)
+func (m *EventMatcher) matchesDate(e *EventInfo) bool {
+	if len(m.settings.DateList) == 0 {
+		return true
+	}
+
+	start, err := e.StartDateTime()
+	if err != nil {
+		return false
+	}
+
+	inWindow := false
+	for i := range m.settings.DateList {
+		if m.settings.DateList[i].Contains(start) {
+			inWindow = true
+			break
+		}
+	}
+	return inWindow != (m.settings.NotDateFlag == 1)
+}
+
+// Contains returns true if t falls within the weekly window (the end is exclusive)
+// A window whose end is not after its start wraps around the end of the week.
+func (d *DateInfo) Contains(t time.Time) bool {
+	start := d.StartDayOfWeek*24*60 + d.StartHour*60 + d.StartMin
+	end := d.EndDayOfWeek*24*60 + d.EndHour*60 + d.EndMin
+	minute := int(t.Weekday())*24*60 + t.Hour()*60 + t.Minute()
+
+	if end > start {
+		return minute >= start && minute < end
+	}
+	// Wraps from Saturday to Sunday (or covers the whole week when end == start)
+	return minute >= start || minute < end
+}
+
+// foldWidth converts full-width ASCII characters and the ideographic space to half-width
+func foldWidth(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= '！' && r <= '～':
+			return r - '！' + '!'
+		case r == '　':
+			return ' '
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// autoReserveCommentPrefix starts the comment of reservations created by an automatic recording rule
+const autoReserveCommentPrefix = "EPG自動予約"
+
+// IsAutoReserved returns true if the reservation was created by an automatic recording rule,
+// according to the comment EpgTimer gives such reservations (e.g., "EPG自動予約(ニュース)")
+func (r *ReservationInfo) IsAutoReserved() bool {
+	return strings.HasPrefix(r.Comment, autoReserveCommentPrefix)
+}
+
+// EventInfo returns the reserved program as an EPG event
+// Only the title, channel, start time and duration are known; the descriptions are empty.
+func (r *ReservationInfo) EventInfo() EventInfo {
+	return EventInfo{
+		ONID:        r.ONID,
+		TSID:        r.TSID,
+		SID:         r.SID,
+		EventID:     r.EventID,
+		ServiceName: r.StationName,
+		StartDate:   r.StartDate,
+		StartTime:   r.StartTime,
+		Duration:    r.DurationSecond,
+		EventName:   r.Title,
+	}
+}
+
+// RuleExplainer finds the automatic recording rules that would have created a reservation,
+// by evaluating the search settings of every rule against the reserved program
+//
+// Reservations only carry the program title, so rules that matched the description
+// (full-text search) are not found, and the free-CA filter is not evaluated.
+type RuleExplainer struct {
+	rules    []AutoAddRule
+	matchers []*EventMatcher // nil for rules whose keywords cannot be evaluated
+	errs     []error
+}
+
+// NewRuleExplainer prepares the rules for Explain
+// Rules whose regular expressions cannot be compiled never match; they are reported by Errors.
+func NewRuleExplainer(rules []AutoAddRule) *RuleExplainer {
+	x := &RuleExplainer{rules: rules, matchers: make([]*EventMatcher, len(rules))}
+	for i := range rules {
+		settings := rules[i].SearchSettings
+		settings.FreeCAFlag = 0 // Unknown for reservations
+		matcher, err := NewEventMatcher(&settings)
+		if err != nil {
+			x.errs = append(x.errs, fmt.Errorf("rule %d: %w", rules[i].ID, err))
+			continue
+		}
+		x.matchers[i] = matcher
+	}
+	return x
+}
+
+// Explain returns the rules matching the reservation, in rule order
+// Only rules read from the same server as the reservation are considered.
+func (x *RuleExplainer) Explain(r *ReservationInfo) []AutoAddRule {
+	event := r.EventInfo()
+
+	var matched []AutoAddRule
+	for i, matcher := range x.matchers {
+		if matcher == nil || x.rules[i].Server != r.Server {
+			continue
+		}
+		if matcher.Matches(&event) {
+			matched = append(matched, x.rules[i])
+		}
+	}
+	return matched
+}
+
+// Errors returns why rules cannot be evaluated (e.g., unsupported regular expressions)
+func (x *RuleExplainer) Errors() []error {
+	return x.errs
+}
@@ -1,14 +1,21 @@
 package models
 
-import "strings"
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // FilterOptions defines user-specified filtering criteria for displaying rules
 type FilterOptions struct {
-	AndKeyFilter  string // Filter by AndKey substring (case-insensitive)
-	ChannelFilter string // Filter by channel "ONID-TSID-SID" format
-	EnabledOnly   bool   // Show only enabled rules (DisableFlag==0)
-	DisabledOnly  bool   // Show only disabled rules (DisableFlag==1)
-	RegexOnly     bool   // Show only regex rules (RegExpFlag==1)
+	AndKeyFilter   string   // Filter by AndKey substring (case-insensitive)
+	ChannelFilter  string   // Filter by channel "ONID-TSID-SID" format
+	ChannelsFilter []string // Filter by any of these channels (ONID-TSID-SID)
+	EnabledOnly    bool     // Show only enabled rules (DisableFlag==0)
+	DisabledOnly   bool     // Show only disabled rules (DisableFlag==1)
+	RegexOnly      bool     // Show only regex rules (RegExpFlag==1)
 }
 
 // Matches returns true if the rule passes all active filters
@@ -50,11 +57,58 @@ func (f *FilterOptions) Matches(rule *AutoAddRule) bool {
 		}
 	}
 
+	// Check channel set (any rule channel in the set)
+	if len(f.ChannelsFilter) > 0 {
+		channelMatch := false
+		for _, channel := range rule.SearchSettings.ServiceList {
+			if slices.Contains(f.ChannelsFilter, channel.String()) {
+				channelMatch = true
+				break
+			}
+		}
+		if !channelMatch {
+			return false
+		}
+	}
+
 	return true
 }
 
 // HasFilters returns true if any filter is active
 func (f *FilterOptions) HasFilters() bool {
-	return f.AndKeyFilter != "" || f.ChannelFilter != "" ||
+	return f.AndKeyFilter != "" || f.ChannelFilter != "" || len(f.ChannelsFilter) > 0 ||
 		f.EnabledOnly || f.DisabledOnly || f.RegexOnly
 }
+
+// ParseAge parses an age such as "14d", "2w" or "36h"
+// Days (d) and weeks (w) are accepted in addition to time.ParseDuration units
+func ParseAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("age cannot be empty")
+	}
+
+	var age time.Duration
+	switch unit := s[len(s)-1]; unit {
+	case 'd', 'w':
+		n, err := strconv.Atoi(s[:len(s)-1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid age '%s': expected a number followed by d, w or h (e.g., 14d)", s)
+		}
+		age = time.Duration(n) * 24 * time.Hour
+		if unit == 'w' {
+			age *= 7
+		}
+	default:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return 0, fmt.Errorf("invalid age '%s': expected a number followed by d, w or h (e.g., 14d)", s)
+		}
+		age = d
+	}
+
+	if age <= 0 {
+		return 0, fmt.Errorf("invalid age '%s': must be greater than 0", s)
+	}
+	return age, nil
+}
@@ -0,0 +1,337 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Manifest is a declarative list of automatic recording rules (used by apply)
+type Manifest struct {
+	Rules []ManifestRule `yaml:"rules" json:"rules"`
+}
+
+// ManifestRule describes one automatic recording rule in a manifest file.
+// Settings that are omitted use the same defaults as 'epgtimer add'.
+type ManifestRule struct {
+	ID          int      `yaml:"id,omitempty" json:"id,omitempty"` // Optional: pin to an existing rule ID
+	AndKey      string   `yaml:"andKey" json:"andKey"`
+	NotKey      string   `yaml:"notKey,omitempty" json:"notKey,omitempty"`
+	ServiceList []string `yaml:"serviceList" json:"serviceList"` // ONID-TSID-SID, key:N, channel name or @group
+
+	// Search settings
+	Disabled      bool     `yaml:"disabled,omitempty" json:"disabled,omitempty"`
+	Regex         bool     `yaml:"regex,omitempty" json:"regex,omitempty"`
+	CaseSensitive bool     `yaml:"caseSensitive,omitempty" json:"caseSensitive,omitempty"`
+	Fuzzy         bool     `yaml:"fuzzy,omitempty" json:"fuzzy,omitempty"`
+	FullText      bool     `yaml:"fullText,omitempty" json:"fullText,omitempty"`
+	Days          []string `yaml:"days,omitempty" json:"days,omitempty"`
+	StartTime     string   `yaml:"startTime,omitempty" json:"startTime,omitempty"`
+	EndTime       string   `yaml:"endTime,omitempty" json:"endTime,omitempty"`
+	DurationMin   int      `yaml:"durationMin,omitempty" json:"durationMin,omitempty"`
+	DurationMax   int      `yaml:"durationMax,omitempty" json:"durationMax,omitempty"`
+	FreeCAOnly    bool     `yaml:"freeCAOnly,omitempty" json:"freeCAOnly,omitempty"`
+
+	// Recording settings
+	Priority    int    `yaml:"priority,omitempty" json:"priority,omitempty"` // 0 = default (2)
+	RecMode     *int   `yaml:"recMode,omitempty" json:"recMode,omitempty"`   // nil = default (1)
+	Tuner       int    `yaml:"tuner,omitempty" json:"tuner,omitempty"`
+	StartMargin *int   `yaml:"startMargin,omitempty" json:"startMargin,omitempty"` // nil = default margin
+	EndMargin   *int   `yaml:"endMargin,omitempty" json:"endMargin,omitempty"`     // nil = default margin
+	SuspendMode int    `yaml:"suspendMode,omitempty" json:"suspendMode,omitempty"`
+	BatFile     string `yaml:"batFile,omitempty" json:"batFile,omitempty"`
+}
+
+// ManifestUpdate is a planned change to an existing rule
+type ManifestUpdate struct {
+	ID      int
+	Request *AutoAddRuleRequest
+	Changes []string // Human-readable differences from the current rule
+}
+
+// ManifestPlan lists the changes needed to make the server match a manifest
+type ManifestPlan struct {
+	Creates   []*AutoAddRuleRequest
+	Updates   []ManifestUpdate
+	Deletes   []AutoAddRule
+	Unchanged int
+}
+
+// IsEmpty reports whether the plan has no changes
+func (p *ManifestPlan) IsEmpty() bool {
+	return len(p.Creates) == 0 && len(p.Updates) == 0 && len(p.Deletes) == 0
+}
+
+// LoadManifest reads and validates a manifest from a YAML or JSON file (by extension, YAML otherwise)
+// Channels must be given as channel IDs; manifests with other channel references are read
+// with ParseManifest, resolved with ResolveChannels and then validated.
+func LoadManifest(filename string) (*Manifest, error) {
+	manifest, err := ParseManifest(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := manifest.Validate(); err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// ParseManifest reads a manifest from a YAML or JSON file without validating its rules
+func ParseManifest(filename string) (*Manifest, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest Manifest
+	if strings.EqualFold(filepath.Ext(filename), ".json") {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&manifest)
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(&manifest)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+
+	return &manifest, nil
+}
+
+// NeedsChannelLookup reports whether some channel of the manifest is not a channel ID
+func (m *Manifest) NeedsChannelLookup() bool {
+	for i := range m.Rules {
+		for _, ref := range m.Rules[i].ServiceList {
+			if !IsChannelID(ref) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ResolveChannels replaces the channel names, key:N references and channel groups (@name)
+// in the serviceList of every rule by their channel IDs
+func (m *Manifest) ResolveChannels(resolver *ChannelResolver) error {
+	for i := range m.Rules {
+		rule := &m.Rules[i]
+		ids, err := resolver.ResolveAll(rule.ServiceList)
+		if err != nil {
+			return fmt.Errorf("rules[%d] (%s): serviceList: %w", i, rule.AndKey, err)
+		}
+		rule.ServiceList = ids
+	}
+	return nil
+}
+
+// Validate checks every rule and rejects rules that would match the same server rule
+func (m *Manifest) Validate() error {
+	seenIDs := make(map[int]bool)
+	seenKeys := make(map[string]bool)
+
+	for i := range m.Rules {
+		rule := &m.Rules[i]
+		if _, err := rule.ToRequest(); err != nil {
+			return fmt.Errorf("rules[%d] (%s): %w", i, rule.AndKey, err)
+		}
+
+		if rule.ID != 0 {
+			if seenIDs[rule.ID] {
+				return fmt.Errorf("rules[%d] (%s): duplicate id %d", i, rule.AndKey, rule.ID)
+			}
+			seenIDs[rule.ID] = true
+			continue
+		}
+
+		key := rule.Key()
+		if seenKeys[key] {
+			return fmt.Errorf("rules[%d] (%s): duplicate rule (same andKey and serviceList as an earlier rule)", i, rule.AndKey)
+		}
+		seenKeys[key] = true
+	}
+
+	return nil
+}
+
+// Key identifies the rule by its search keyword and channel set (channel order is ignored)
+func (r *ManifestRule) Key() string {
+	return autoAddRuleKey(r.AndKey, r.ServiceList)
+}
+
+// ToRequest converts the manifest rule to a new SetAutoAdd request
+func (r *ManifestRule) ToRequest() (*AutoAddRuleRequest, error) {
+	req := NewAutoAddRuleRequest("", "", nil)
+	if err := r.applyTo(req); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// applyTo overwrites every setting the manifest can express; other settings
+// (e.g. recording folders configured in the web UI) are left untouched
+func (r *ManifestRule) applyTo(req *AutoAddRuleRequest) error {
+	for i, service := range r.ServiceList {
+		if _, err := ParseServiceListEntry(service); err != nil {
+			return fmt.Errorf("serviceList[%d]: %w", i, err)
+		}
+	}
+
+	req.AndKey = r.AndKey
+	req.NotKey = r.NotKey
+	// Keep the server's channel order when only the order differs
+	if autoAddRuleKey("", req.ServiceList) != autoAddRuleKey("", r.ServiceList) {
+		req.ServiceList = append([]string(nil), r.ServiceList...)
+	}
+
+	req.DisableFlag = boolToFlag(r.Disabled)
+	req.RegExpFlag = boolToFlag(r.Regex)
+	req.CaseFlag = boolToFlag(r.CaseSensitive)
+	req.AimaiFlag = boolToFlag(r.Fuzzy)
+	req.TitleOnlyFlag = 1 - boolToFlag(r.FullText)
+	req.FreeCAFlag = boolToFlag(r.FreeCAOnly)
+	req.ChkDurationMin = r.DurationMin
+	req.ChkDurationMax = r.DurationMax
+
+	req.DateList = ""
+	if len(r.Days) > 0 || r.StartTime != "" || r.EndTime != "" {
+		days, err := ParseDaysOfWeek(r.Days)
+		if err != nil {
+			return err
+		}
+		dates, err := BuildDateList(days, r.StartTime, r.EndTime)
+		if err != nil {
+			return err
+		}
+		req.DateList = FormatDateList(dates)
+	}
+
+	defaults := NewRecSettingRequest()
+
+	req.Priority = defaults.Priority
+	if r.Priority != 0 {
+		req.Priority = r.Priority
+	}
+	req.RecMode = defaults.RecMode
+	if r.RecMode != nil {
+		req.RecMode = *r.RecMode
+	}
+	req.TunerID = r.Tuner
+	req.SuspendMode = r.SuspendMode
+	req.BatFilePath = r.BatFile
+
+	req.UseDefMarginFlag = 1
+	req.StartMargin, req.EndMargin = 0, 0
+	if r.StartMargin != nil || r.EndMargin != nil {
+		req.UseDefMarginFlag = 0
+		if r.StartMargin != nil {
+			req.StartMargin = *r.StartMargin
+		}
+		if r.EndMargin != nil {
+			req.EndMargin = *r.EndMargin
+		}
+	}
+
+	return nil
+}
+
+// PlanManifest compares the manifest with the rules on the server.
+// Manifest rules are matched by id when given, otherwise by andKey and channel set.
+// With prune, server rules that match no manifest rule are scheduled for deletion.
+func PlanManifest(manifest *Manifest, rules []AutoAddRule, prune bool) (*ManifestPlan, error) {
+	plan := &ManifestPlan{}
+	matched := make(map[int]bool)
+
+	byID := make(map[int]*AutoAddRule)
+	for i := range rules {
+		byID[rules[i].ID] = &rules[i]
+	}
+
+	// Rules pinned by ID are matched first so key matching cannot claim them
+	existing := make([]*AutoAddRule, len(manifest.Rules))
+	for i := range manifest.Rules {
+		id := manifest.Rules[i].ID
+		if id == 0 {
+			continue
+		}
+		rule, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("rules[%d] (%s): rule ID %d not found on server", i, manifest.Rules[i].AndKey, id)
+		}
+		existing[i] = rule
+		matched[id] = true
+	}
+
+	for i := range manifest.Rules {
+		if manifest.Rules[i].ID != 0 {
+			continue
+		}
+		key := manifest.Rules[i].Key()
+		for j := range rules {
+			if !matched[rules[j].ID] && rules[j].Key() == key {
+				existing[i] = &rules[j]
+				matched[rules[j].ID] = true
+				break
+			}
+		}
+	}
+
+	for i := range manifest.Rules {
+		mr := &manifest.Rules[i]
+
+		if existing[i] == nil {
+			req, err := mr.ToRequest()
+			if err != nil {
+				return nil, fmt.Errorf("rules[%d] (%s): %w", i, mr.AndKey, err)
+			}
+			plan.Creates = append(plan.Creates, req)
+			continue
+		}
+
+		current := NewAutoAddRuleRequestFromRule(existing[i])
+		req := NewAutoAddRuleRequestFromRule(existing[i])
+		if err := mr.applyTo(req); err != nil {
+			return nil, fmt.Errorf("rules[%d] (%s): %w", i, mr.AndKey, err)
+		}
+
+		changes := current.Diff(req)
+		if len(changes) == 0 {
+			plan.Unchanged++
+			continue
+		}
+		plan.Updates = append(plan.Updates, ManifestUpdate{
+			ID:      existing[i].ID,
+			Request: req,
+			Changes: changes,
+		})
+	}
+
+	if prune {
+		for _, rule := range rules {
+			if !matched[rule.ID] {
+				plan.Deletes = append(plan.Deletes, rule)
+			}
+		}
+	}
+
+	return plan, nil
+}
+
+// boolToFlag converts a boolean to EpgTimer's 0/1 flag value
+func boolToFlag(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
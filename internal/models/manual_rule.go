@@ -0,0 +1,199 @@
+package models
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// secondsPerDay is the length of the daily schedule of program-based rules
+const secondsPerDay = 24 * 60 * 60
+
+// EnumManuAddResponse represents the response from EMWUI EnumManuAdd API
+type EnumManuAddResponse struct {
+	XMLName xml.Name            `xml:"entry"`
+	Total   int                 `xml:"total"`
+	Index   int                 `xml:"index"`
+	Count   int                 `xml:"count"`
+	Items   []ManualAutoAddRule `xml:"items>manuautoaddinfo"`
+}
+
+// ManualAutoAddRule represents a program-based (manual) automatic recording rule,
+// which records a channel at a fixed time on the selected days of the week without using the EPG
+type ManualAutoAddRule struct {
+	Server            string            `xml:"-" json:"server,omitempty"` // EMWUI server the rule was read from (multi-server output only)
+	ID                int               `xml:"ID" json:"id"`
+	DayOfWeekFlag     int               `xml:"dayOfWeekFlag" json:"day_of_week_flag"` // Bit mask of days (bit 0 = Sunday)
+	StartTime         int               `xml:"startTime" json:"start_time"`           // Seconds from 0:00
+	DurationSecond    int               `xml:"durationSecond" json:"duration_second"`
+	Title             string            `xml:"title" json:"title"`
+	StationName       string            `xml:"stationName" json:"station_name"`
+	ONID              int               `xml:"ONID" json:"onid"`
+	TSID              int               `xml:"TSID" json:"tsid"`
+	SID               int               `xml:"SID" json:"sid"`
+	RecordingSettings RecordingSettings `xml:"recsetting" json:"recording"`
+}
+
+// ChannelID returns the channel identifier in ONID-TSID-SID format
+func (r *ManualAutoAddRule) ChannelID() string {
+	return fmt.Sprintf("%d-%d-%d", r.ONID, r.TSID, r.SID)
+}
+
+// Days returns the days of week the rule records on (0 = Sunday)
+func (r *ManualAutoAddRule) Days() []int {
+	var days []int
+	for day := 0; day < 7; day++ {
+		if r.DayOfWeekFlag&(1<<day) != 0 {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+// DaysString returns the days of week as Japanese abbreviations (e.g., "月火水木金")
+func (r *ManualAutoAddRule) DaysString() string {
+	var sb strings.Builder
+	for _, day := range r.Days() {
+		sb.WriteString(dayOfWeekName(day))
+	}
+	return sb.String()
+}
+
+// StartTimeString returns the start time in HH:MM format (HH:MM:SS if it has seconds)
+func (r *ManualAutoAddRule) StartTimeString() string {
+	return formatSecondsOfDay(r.StartTime)
+}
+
+// EndTimeString returns the end time in HH:MM format; programs past midnight end on the next day
+func (r *ManualAutoAddRule) EndTimeString() string {
+	return formatSecondsOfDay((r.StartTime + r.DurationSecond) % secondsPerDay)
+}
+
+// DurationMinutes returns the duration in minutes
+func (r *ManualAutoAddRule) DurationMinutes() int {
+	return r.DurationSecond / 60
+}
+
+// IsEnabled returns true unless the recording mode is one of the disabled modes (5-9)
+func (r *ManualAutoAddRule) IsEnabled() bool {
+	return !isNoRecMode(r.RecordingSettings.RecMode)
+}
+
+// formatSecondsOfDay formats seconds from 0:00 as HH:MM, or HH:MM:SS if the seconds are not zero
+func formatSecondsOfDay(seconds int) string {
+	if seconds%60 != 0 {
+		return fmt.Sprintf("%02d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+	}
+	return fmt.Sprintf("%02d:%02d", seconds/3600, seconds/60%60)
+}
+
+// DayOfWeekMask converts day-of-week numbers (0 = Sunday) to the dayOfWeekFlag bit mask
+func DayOfWeekMask(days []int) int {
+	mask := 0
+	for _, day := range days {
+		mask |= 1 << day
+	}
+	return mask
+}
+
+// ParseTimeOfDay parses "HH:MM" into seconds from 0:00
+func ParseTimeOfDay(s string) (int, error) {
+	hour, min, err := parseClock(s)
+	if err != nil {
+		return 0, err
+	}
+	if hour == 24 {
+		return 0, fmt.Errorf("time '%s' is out of range", s)
+	}
+	return hour*3600 + min*60, nil
+}
+
+// ManualAutoAddRuleRequest contains the parameters for creating a program-based automatic recording rule
+type ManualAutoAddRuleRequest struct {
+	DayOfWeekFlag  int    `json:"day_of_week_flag"` // Bit mask of days (bit 0 = Sunday)
+	StartTime      int    `json:"start_time"`       // Seconds from 0:00
+	DurationSecond int    `json:"duration_second"`  // Recording length (the end may be on the next day)
+	Title          string `json:"title"`            // Title given to the reservations
+	ONID           int    `json:"onid"`             // Original Network ID of the channel
+	TSID           int    `json:"tsid"`             // Transport Stream ID of the channel
+	SID            int    `json:"sid"`              // Service ID of the channel
+	PresetID       int    `json:"preset_id"`        // Preset ID (RecPresetIDCustom = custom settings below)
+	CToken         string `json:"ctok"`             // CSRF token (fetched from HTML page)
+
+	// Recording settings
+	RecSettingRequest
+}
+
+// NewManualAutoAddRuleRequest creates a request recording the channel on the given days
+// from startTime for durationSecond (both in seconds), with default recording settings
+func NewManualAutoAddRuleRequest(title string, onid, tsid, sid int, days []int, startTime, durationSecond int) *ManualAutoAddRuleRequest {
+	return &ManualAutoAddRuleRequest{
+		DayOfWeekFlag:  DayOfWeekMask(days),
+		StartTime:      startTime,
+		DurationSecond: durationSecond,
+		Title:          title,
+		ONID:           onid,
+		TSID:           tsid,
+		SID:            sid,
+		PresetID:       RecPresetIDCustom,
+
+		RecSettingRequest: NewRecSettingRequest(),
+	}
+}
+
+// ChannelID returns the channel identifier in ONID-TSID-SID format
+func (r *ManualAutoAddRuleRequest) ChannelID() string {
+	return fmt.Sprintf("%d-%d-%d", r.ONID, r.TSID, r.SID)
+}
+
+// Validate checks if the request has valid parameters
+func (r *ManualAutoAddRuleRequest) Validate() error {
+	if strings.TrimSpace(r.Title) == "" {
+		return fmt.Errorf("title is required")
+	}
+
+	if r.ONID <= 0 || r.TSID < 0 || r.SID <= 0 {
+		return fmt.Errorf("invalid channel %s: ONID and SID must be greater than 0", r.ChannelID())
+	}
+
+	if r.DayOfWeekFlag <= 0 || r.DayOfWeekFlag > 0x7f {
+		return fmt.Errorf("at least one day of week is required")
+	}
+
+	if r.StartTime < 0 || r.StartTime >= secondsPerDay {
+		return fmt.Errorf("start time must be between 00:00 and 23:59, got %d seconds", r.StartTime)
+	}
+
+	if r.DurationSecond <= 0 || r.DurationSecond >= secondsPerDay {
+		return fmt.Errorf("duration must be longer than 0 and shorter than 24 hours, got %d seconds", r.DurationSecond)
+	}
+
+	return r.RecSettingRequest.Validate()
+}
+
+// ToFormData converts the request to application/x-www-form-urlencoded format
+// EMWUI takes the schedule as start and end clock times; an end before the start is on the next day.
+func (r *ManualAutoAddRuleRequest) ToFormData() string {
+	v := url.Values{}
+
+	for day := 0; day < 7; day++ {
+		setFlag(v, fmt.Sprintf("dayOfWeek%d", day), r.DayOfWeekFlag>>day&1)
+	}
+
+	end := (r.StartTime + r.DurationSecond) % secondsPerDay
+	v.Set("startH", fmt.Sprintf("%d", r.StartTime/3600))
+	v.Set("startM", fmt.Sprintf("%d", r.StartTime/60%60))
+	v.Set("startS", fmt.Sprintf("%d", r.StartTime%60))
+	v.Set("endH", fmt.Sprintf("%d", end/3600))
+	v.Set("endM", fmt.Sprintf("%d", end/60%60))
+	v.Set("endS", fmt.Sprintf("%d", end%60))
+
+	v.Set("title", r.Title)
+	v.Set("serviceID", r.ChannelID())
+	v.Set("presetID", fmt.Sprintf("%d", r.PresetID))
+	v.Set("ctok", r.CToken)
+	r.RecSettingRequest.addFormData(v)
+
+	return v.Encode()
+}
@@ -0,0 +1,125 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// DefaultWritePlugIn is the write plugin EpgTimer uses when none is selected
+const DefaultWritePlugIn = "Write_Default.dll"
+
+// RecFolders is the list of recording folders of a rule or preset (empty = EpgTimer's default folder)
+type RecFolders []RecFolder
+
+// UnmarshalJSON reads the folder list, accepting the empty string that backups written
+// before the folders were parsed contain
+func (f *RecFolders) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*f = nil
+		return nil
+	}
+	return json.Unmarshal(data, (*[]RecFolder)(f))
+}
+
+// MarshalJSON writes an empty list as [] rather than null
+func (f RecFolders) MarshalJSON() ([]byte, error) {
+	if f == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]RecFolder(f))
+}
+
+// String returns the folder paths separated by "; ", or "(default)" for an empty list
+func (f RecFolders) String() string {
+	if len(f) == 0 {
+		return "(default)"
+	}
+	paths := make([]string, 0, len(f))
+	for _, folder := range f {
+		paths = append(paths, folder.RecFolder)
+	}
+	return strings.Join(paths, "; ")
+}
+
+// addFormData adds the folders to EMWUI form data as repeated recFolder, writePlugIn and
+// recNamePlugIn fields, each name prefixed with prefix ("partial" for partial reception folders)
+func (f RecFolders) addFormData(v url.Values, prefix string) {
+	for _, folder := range f {
+		v.Add(prefix+"recFolder", folder.RecFolder)
+		v.Add(prefix+"writePlugIn", folder.WritePlugIn)
+		v.Add(prefix+"recNamePlugIn", folder.RecNamePlugIn)
+	}
+}
+
+// spec returns the folders in --rec-folder format separated by ", " (used by Diff)
+func (f RecFolders) spec() string {
+	specs := make([]string, 0, len(f))
+	for _, folder := range f {
+		specs = append(specs, folder.Spec())
+	}
+	return strings.Join(specs, ", ")
+}
+
+// Spec returns the folder in PATH[:writePlugin[:namePlugin]] format, as accepted by ParseRecFolder
+// The default write plugin is omitted.
+func (r RecFolder) Spec() string {
+	switch {
+	case r.RecNamePlugIn != "":
+		return r.RecFolder + ":" + r.WritePlugIn + ":" + r.RecNamePlugIn
+	case r.WritePlugIn != DefaultWritePlugIn:
+		return r.RecFolder + ":" + r.WritePlugIn
+	default:
+		return r.RecFolder
+	}
+}
+
+// ParseRecFolder parses a recording folder given as PATH[:writePlugin[:namePlugin]]
+// A drive letter at the start of the path (e.g., "D:\Recorded") is part of the path, and the
+// name plugin may contain colons. The write plugin defaults to Write_Default.dll.
+// Example: `D:\Recorded\Kids:Write_Default.dll:RecName_Macro.dll?$Title$.ts`
+func ParseRecFolder(s string) (RecFolder, error) {
+	rest := strings.TrimSpace(s)
+
+	drive := ""
+	if len(rest) >= 2 && rest[1] == ':' && isASCIILetter(rest[0]) && (len(rest) == 2 || rest[2] == '\\' || rest[2] == '/') {
+		drive, rest = rest[:2], rest[2:]
+	}
+
+	parts := strings.SplitN(rest, ":", 3)
+	folder := RecFolder{RecFolder: drive + parts[0], WritePlugIn: DefaultWritePlugIn}
+	if folder.RecFolder == "" {
+		return RecFolder{}, fmt.Errorf("invalid recording folder '%s': expected PATH[:writePlugin[:namePlugin]]", s)
+	}
+	if len(parts) > 1 && parts[1] != "" {
+		folder.WritePlugIn = parts[1]
+	}
+	if len(parts) > 2 {
+		folder.RecNamePlugIn = parts[2]
+	}
+	return folder, nil
+}
+
+// ParseRecFolders parses --rec-folder values; a single empty value means EpgTimer's default folder
+func ParseRecFolders(values []string) (RecFolders, error) {
+	if len(values) == 1 && strings.TrimSpace(values[0]) == "" {
+		return nil, nil
+	}
+
+	folders := make(RecFolders, 0, len(values))
+	for _, value := range values {
+		folder, err := ParseRecFolder(value)
+		if err != nil {
+			return nil, err
+		}
+		folders = append(folders, folder)
+	}
+	return folders, nil
+}
+
+// isASCIILetter returns true for A-Z and a-z
+func isASCIILetter(c byte) bool {
+	return (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z')
+}
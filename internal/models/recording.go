@@ -3,6 +3,7 @@ package models
 import (
 	"encoding/xml"
 	"fmt"
+	"time"
 )
 
 // EnumRecInfoResponse represents the response from EMWUI EnumRecInfo API
@@ -16,6 +17,7 @@ type EnumRecInfoResponse struct {
 
 // RecordingInfo represents a single recorded program from EnumRecInfo API
 type RecordingInfo struct {
+	Server         string `xml:"-" json:"server,omitempty"` // EMWUI server the recording was read from (multi-server output only)
 	ID             int    `xml:"ID" json:"id"`
 	Title          string `xml:"title" json:"title"`
 	StartDate      string `xml:"startDate" json:"start_date"`      // Format: 2025/12/22
@@ -45,3 +47,18 @@ func (r *RecordingInfo) DurationMinutes() int {
 func (r *RecordingInfo) IsProtected() bool {
 	return r.ProtectFlag == 1
 }
+
+// StartDateTime parses and returns the start date and time in local time
+func (r *RecordingInfo) StartDateTime() (time.Time, error) {
+	return time.ParseInLocation("2006/01/02 15:04:05", r.StartDate+" "+r.StartTime, time.Local)
+}
+
+// IsOlderThan returns true if the recording started more than age before now
+// Recordings with an unparseable start time are never considered old
+func (r *RecordingInfo) IsOlderThan(age time.Duration, now time.Time) bool {
+	start, err := r.StartDateTime()
+	if err != nil {
+		return false
+	}
+	return start.Before(now.Add(-age))
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// RecPresetIDCustom is the presetID sent when the recording settings are given explicitly
+const RecPresetIDCustom = 65535
+
+// EnumRecPresetResponse represents the response from EMWUI EnumRecPreset API
+type EnumRecPresetResponse struct {
+	XMLName xml.Name    `xml:"entry"`
+	Total   int         `xml:"total"`
+	Index   int         `xml:"index"`
+	Count   int         `xml:"count"`
+	Items   []RecPreset `xml:"items>recpresetinfo"`
+}
+
+// RecPreset represents a named set of recording settings configured in EpgTimer
+// Preset 0 is EpgTimer's default settings.
+type RecPreset struct {
+	ID                int               `xml:"id" json:"id"`
+	Name              string            `xml:"name" json:"name"`
+	RecordingSettings RecordingSettings `xml:"recsetting" json:"recording"`
+}
+
+// MarginsString returns the start/end margins in seconds (e.g., "60/120"), or "default"
+func (p *RecPreset) MarginsString() string {
+	if !p.RecordingSettings.HasMargins() {
+		return "default"
+	}
+	return fmt.Sprintf("%d/%d", p.RecordingSettings.StartMargine, p.RecordingSettings.EndMargine)
+}
+
+// FindRecPreset returns the preset with the given ID or name
+// Names are matched exactly first, then case-insensitively.
+func FindRecPreset(presets []RecPreset, ref string) (*RecPreset, error) {
+	ref = strings.TrimSpace(ref)
+
+	if id, err := strconv.Atoi(ref); err == nil {
+		for i := range presets {
+			if presets[i].ID == id {
+				return &presets[i], nil
+			}
+		}
+	}
+
+	for i := range presets {
+		if presets[i].Name == ref {
+			return &presets[i], nil
+		}
+	}
+
+	var found *RecPreset
+	for i := range presets {
+		if strings.EqualFold(presets[i].Name, ref) {
+			if found != nil {
+				return nil, fmt.Errorf("recording preset '%s' is ambiguous: matches '%s' and '%s'", ref, found.Name, presets[i].Name)
+			}
+			found = &presets[i]
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("recording preset '%s' not found", ref)
+	}
+	return found, nil
+}
@@ -0,0 +1,249 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Recording modes used by EpgTimer's recMode setting
+const (
+	RecModeAll         = 0 // All services
+	RecModeSpecified   = 1 // Specified service only
+	RecModeAllNoDec    = 2 // All services without descrambling
+	RecModeSpecNoDec   = 3 // Specified service without descrambling
+	RecModeView        = 4 // View only
+	RecModeNoRecording = 5 // Disabled
+
+	// recModeMax is the highest valid recMode. EpgTimer stores a disabled
+	// setting as 5-9 so that the original mode survives re-enabling.
+	recModeMax = 9
+)
+
+// RecSettingRequest contains the recording settings sent along with rules and reservations
+type RecSettingRequest struct {
+	RecMode          int    `json:"rec_mode"`            // Recording mode (1 = standard)
+	TuijyuuFlag      int    `json:"tuijyuu_flag"`        // Auto-follow flag (1 = enabled)
+	Priority         int    `json:"priority"`            // Recording priority (2 = normal)
+	UseDefMarginFlag int    `json:"use_def_margin_flag"` // Use default margins (1 = yes)
+	StartMargin      int    `json:"start_margin"`        // Start margin in seconds (used when UseDefMarginFlag == 0)
+	EndMargin        int    `json:"end_margin"`          // End margin in seconds (used when UseDefMarginFlag == 0)
+	ServiceMode      int    `json:"service_mode"`        // Service mode (1 = enabled)
+	TunerID          int    `json:"tuner_id"`            // Tuner ID (0 = auto)
+	SuspendMode      int    `json:"suspend_mode"`        // Suspend mode (0 = default)
+	BatFilePath      string `json:"bat_file_path"`       // Batch file path (empty)
+	BatFileTag       string `json:"bat_file_tag"`        // Batch file tag (empty)
+	PittariFlag      int    `json:"pittari_flag"`        // 1 = exact-time recording
+	RebootFlag       int    `json:"reboot_flag"`         // 1 = reboot after recording
+	ContinueRecFlag  int    `json:"continue_rec_flag"`   // 1 = continue recording into the next program
+	PartialRecFlag   int    `json:"partial_rec_flag"`    // 1 = also record the partial reception (1seg) service
+
+	RecFolders        RecFolders `json:"rec_folders"`         // Recording folders (empty = default folder)
+	PartialRecFolders RecFolders `json:"partial_rec_folders"` // Recording folders of the partial reception service
+}
+
+// NewRecSettingRequest returns recording settings with the defaults from the curl sample
+func NewRecSettingRequest() RecSettingRequest {
+	return RecSettingRequest{
+		RecMode:          RecModeSpecified,
+		TuijyuuFlag:      1,
+		Priority:         2,
+		UseDefMarginFlag: 1,
+		ServiceMode:      1,
+		TunerID:          0,
+		SuspendMode:      0,
+		BatFilePath:      "",
+		BatFileTag:       "",
+	}
+}
+
+// NewRecSettingRequestFromRecSetting creates recording settings that reproduce
+// those of an existing reservation
+func NewRecSettingRequestFromRecSetting(rs *RecSetting) RecSettingRequest {
+	r := NewRecSettingRequest()
+	r.RecMode = rs.RecMode
+	r.Priority = rs.Priority
+	r.TuijyuuFlag = rs.TuijyuuFlag
+	r.ServiceMode = rs.ServiceMode
+	r.PittariFlag = rs.PittariFlag
+	r.BatFilePath = rs.BatFilePath
+	r.SuspendMode = rs.SuspendMode
+	r.RebootFlag = rs.RebootFlag
+	r.ContinueRecFlag = rs.ContinueRecFlag
+	r.PartialRecFlag = rs.PartialRecFlag
+	r.TunerID = rs.TunerID
+	if rs.HasMargins() {
+		r.UseDefMarginFlag = 0
+		r.StartMargin = rs.StartMargin
+		r.EndMargin = rs.EndMargin
+	}
+	r.RecFolders = append(RecFolders(nil), rs.RecFolderList...)
+	r.PartialRecFolders = append(RecFolders(nil), rs.PartialRecFolder...)
+	return r
+}
+
+// NewRecSettingRequestFromRecordingSettings creates recording settings that reproduce
+// those of an existing rule or preset
+func NewRecSettingRequestFromRecordingSettings(rs *RecordingSettings) RecSettingRequest {
+	r := NewRecSettingRequest()
+	r.RecMode = rs.RecMode
+	r.Priority = rs.Priority
+	r.TuijyuuFlag = rs.TuijyuuFlag
+	r.ServiceMode = rs.ServiceMode
+	r.PittariFlag = rs.PittariFlag
+	r.BatFilePath = rs.BatFilePath
+	r.SuspendMode = rs.SuspendMode
+	r.RebootFlag = rs.RebootFlag
+	r.ContinueRecFlag = rs.ContinueRecFlag
+	r.PartialRecFlag = rs.PartialRecFlag
+	r.TunerID = rs.TunerID
+	r.StartMargin = rs.StartMargine
+	r.EndMargin = rs.EndMargine
+	if rs.HasMargins() {
+		r.UseDefMarginFlag = 0
+	}
+	r.RecFolders = append(RecFolders(nil), rs.RecFolderList...)
+	r.PartialRecFolders = append(RecFolders(nil), rs.PartialRecFolder...)
+	return r
+}
+
+// IsDisabled returns true if the recording mode is one of the disabled modes (5-9)
+func (r *RecSettingRequest) IsDisabled() bool {
+	return isNoRecMode(r.RecMode)
+}
+
+// SetDisabled disables or re-enables recording while keeping the original mode
+// Uses EpgTimer's encoding: disabling mode m stores 5 + (m+4)%5
+func (r *RecSettingRequest) SetDisabled(disabled bool) {
+	mode := (r.RecMode + r.RecMode/5%2) % 5
+	if disabled {
+		r.RecMode = 5 + (mode+4)%5
+	} else {
+		r.RecMode = mode
+	}
+}
+
+// RecModeName returns a short name for a recording mode (e.g., "specified", "disabled")
+func RecModeName(recMode int) string {
+	if isNoRecMode(recMode) {
+		return "disabled"
+	}
+	switch recMode {
+	case RecModeAll:
+		return "all"
+	case RecModeSpecified:
+		return "specified"
+	case RecModeAllNoDec:
+		return "all (no descramble)"
+	case RecModeSpecNoDec:
+		return "specified (no descramble)"
+	case RecModeView:
+		return "view"
+	default:
+		return fmt.Sprintf("mode %d", recMode)
+	}
+}
+
+// isNoRecMode returns true for EpgTimer's disabled recording modes
+func isNoRecMode(recMode int) bool {
+	return recMode/5%2 != 0
+}
+
+// Validate checks that the recording settings are within the ranges EpgTimer accepts
+func (r *RecSettingRequest) Validate() error {
+	if r.RecMode < RecModeAll || r.RecMode > recModeMax {
+		return fmt.Errorf("recMode must be between %d and %d, got %d", RecModeAll, recModeMax, r.RecMode)
+	}
+
+	if r.Priority < 1 || r.Priority > 5 {
+		return fmt.Errorf("priority must be between 1 and 5, got %d", r.Priority)
+	}
+
+	if r.TunerID < 0 {
+		return fmt.Errorf("tunerID must be 0 (auto) or a tuner ID, got %d", r.TunerID)
+	}
+
+	// 0 = default, 1 = standby, 2 = hibernate, 3 = shutdown, 4 = do nothing
+	if r.SuspendMode < 0 || r.SuspendMode > 4 {
+		return fmt.Errorf("suspendMode must be between 0 and 4, got %d", r.SuspendMode)
+	}
+
+	return nil
+}
+
+// addFormData adds the recording settings to EMWUI form data
+func (r *RecSettingRequest) addFormData(v url.Values) {
+	v.Set("recMode", fmt.Sprintf("%d", r.RecMode))
+	v.Set("tuijyuuFlag", fmt.Sprintf("%d", r.TuijyuuFlag))
+	v.Set("priority", fmt.Sprintf("%d", r.Priority))
+	v.Set("useDefMarginFlag", fmt.Sprintf("%d", r.UseDefMarginFlag))
+	if r.UseDefMarginFlag == 0 {
+		v.Set("startMargin", fmt.Sprintf("%d", r.StartMargin))
+		v.Set("endMargin", fmt.Sprintf("%d", r.EndMargin))
+	}
+	v.Set("serviceMode", fmt.Sprintf("%d", r.ServiceMode))
+	v.Set("tunerID", fmt.Sprintf("%d", r.TunerID))
+	v.Set("suspendMode", fmt.Sprintf("%d", r.SuspendMode))
+	v.Set("batFilePath", r.BatFilePath)
+	v.Set("batFileTag", r.BatFileTag)
+	setFlag(v, "pittariFlag", r.PittariFlag)
+	setFlag(v, "rebootFlag", r.RebootFlag)
+	setFlag(v, "continueRecFlag", r.ContinueRecFlag)
+	setFlag(v, "partialRecFlag", r.PartialRecFlag)
+	r.RecFolders.addFormData(v, "")
+	r.PartialRecFolders.addFormData(v, "partial")
+}
+
+// Diff returns a human-readable list of recording settings that differ between r and other,
+// formatted as "name: old -> new" where old is taken from r
+func (r *RecSettingRequest) Diff(other *RecSettingRequest) []string {
+	return diffSettings(r.settings(), other.settings())
+}
+
+// requestSetting is a single named setting used by Diff
+type requestSetting struct {
+	name  string
+	value string
+}
+
+// diffSettings compares two setting lists produced by the same settings() method
+func diffSettings(before, after []requestSetting) []string {
+	var changes []string
+	for i := range before {
+		if before[i].value != after[i].value {
+			changes = append(changes, fmt.Sprintf("%s: %q -> %q", before[i].name, before[i].value, after[i].value))
+		}
+	}
+	return changes
+}
+
+// settings lists the recording settings in a stable order for Diff
+func (r *RecSettingRequest) settings() []requestSetting {
+	margins := "default"
+	if r.UseDefMarginFlag == 0 {
+		margins = fmt.Sprintf("%d/%d", r.StartMargin, r.EndMargin)
+	}
+
+	return []requestSetting{
+		{"recMode", fmt.Sprintf("%d", r.RecMode)},
+		{"priority", fmt.Sprintf("%d", r.Priority)},
+		{"tuijyuu", fmt.Sprintf("%d", r.TuijyuuFlag)},
+		{"pittari", fmt.Sprintf("%d", r.PittariFlag)},
+		{"margins", margins},
+		{"serviceMode", fmt.Sprintf("%d", r.ServiceMode)},
+		{"tunerID", fmt.Sprintf("%d", r.TunerID)},
+		{"suspendMode", fmt.Sprintf("%d", r.SuspendMode)},
+		{"reboot", fmt.Sprintf("%d", r.RebootFlag)},
+		{"continueRec", fmt.Sprintf("%d", r.ContinueRecFlag)},
+		{"partialRec", fmt.Sprintf("%d", r.PartialRecFlag)},
+		{"batFilePath", r.BatFilePath},
+		{"recFolders", r.RecFolders.spec()},
+		{"partialRecFolders", r.PartialRecFolders.spec()},
+	}
+}
+
+// setFlag adds a checkbox-style flag to the form data when it is enabled
+func setFlag(v url.Values, name string, flag int) {
+	if flag != 0 {
+		v.Set(name, fmt.Sprintf("%d", flag))
+	}
+}
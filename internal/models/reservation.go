@@ -1,8 +1,11 @@
 package models
 
 import (
+	"encoding/json"
 	"encoding/xml"
 	"fmt"
+	"sort"
+	"strings"
 )
 
 // EnumAutoAddResponse represents the root response structure from GET /api/EnumAutoAdd
@@ -16,6 +19,7 @@ type EnumAutoAddResponse struct {
 
 // AutoAddRule represents a single automatic recording rule configuration
 type AutoAddRule struct {
+	Server            string            `xml:"-" json:"server,omitempty"` // EMWUI server the rule was read from (multi-server output only)
 	ID                int               `xml:"ID" json:"id"`
 	SearchSettings    SearchSettings    `xml:"searchsetting" json:"search"`
 	RecordingSettings RecordingSettings `xml:"recsetting" json:"recording"`
@@ -38,9 +42,34 @@ type SearchSettings struct {
 	ChkRecNoService int           `xml:"chkRecNoService" json:"check_no_service"`
 	ChkDurationMin  int           `xml:"chkDurationMin" json:"duration_min"`
 	ChkDurationMax  int           `xml:"chkDurationMax" json:"duration_max"`
+	ContentList     []ContentData `xml:"contentList" json:"genres"`
+	DateList        []DateInfo    `xml:"dateList" json:"date_list"`
 	ServiceList     []ServiceInfo `xml:"serviceList" json:"channels"`
 }
 
+// Key identifies the rule by its search keyword and channel set (channel order is ignored)
+// Used to detect rules that already exist when applying manifests or importing backups
+func (r *AutoAddRule) Key() string {
+	serviceList := make([]string, 0, len(r.SearchSettings.ServiceList))
+	for _, service := range r.SearchSettings.ServiceList {
+		serviceList = append(serviceList, service.String())
+	}
+	return autoAddRuleKey(r.SearchSettings.AndKey, serviceList)
+}
+
+// autoAddRuleKey joins andKey with the sorted, normalized channel list
+func autoAddRuleKey(andKey string, serviceList []string) string {
+	sorted := make([]string, 0, len(serviceList))
+	for _, service := range serviceList {
+		if entry, err := ParseServiceListEntry(service); err == nil {
+			service = entry.String()
+		}
+		sorted = append(sorted, service)
+	}
+	sort.Strings(sorted)
+	return andKey + "\x00" + strings.Join(sorted, ",")
+}
+
 // IsEnabled returns true if the rule is enabled (DisableFlag == 0)
 func (s *SearchSettings) IsEnabled() bool {
 	return s.DisableFlag == 0
@@ -78,25 +107,51 @@ func (s *ServiceInfo) Matches(onid, tsid, sid int) bool {
 	return s.ONID == onid && s.TSID == tsid && s.SID == sid
 }
 
+// DateInfo represents a weekly time window used by the date filter of a rule
+// Days of week are numbered from 0 (Sunday) to 6 (Saturday)
+type DateInfo struct {
+	StartDayOfWeek int `xml:"startDayOfWeek" json:"start_day_of_week"`
+	StartHour      int `xml:"startHour" json:"start_hour"`
+	StartMin       int `xml:"startMin" json:"start_min"`
+	EndDayOfWeek   int `xml:"endDayOfWeek" json:"end_day_of_week"`
+	EndHour        int `xml:"endHour" json:"end_hour"`
+	EndMin         int `xml:"endMin" json:"end_min"`
+}
+
+// String returns the window in EMWUI dateList format (e.g., "月-21:00-月-23:30")
+func (d *DateInfo) String() string {
+	return fmt.Sprintf("%s-%d:%02d-%s-%d:%02d",
+		dayOfWeekName(d.StartDayOfWeek), d.StartHour, d.StartMin,
+		dayOfWeekName(d.EndDayOfWeek), d.EndHour, d.EndMin)
+}
+
+// ContentData represents a genre of the genre filter of a rule
+// ContentNibble holds the major genre in the upper byte and the minor genre in the lower byte
+// (0xFF = all minor genres), as in the nibble1/nibble2 pair of EventInfo.ContentInfo.
+type ContentData struct {
+	ContentNibble int `xml:"content_nibble" json:"content_nibble"`
+	UserNibble    int `xml:"user_nibble" json:"user_nibble"`
+}
+
 // RecordingSettings defines recording behavior and post-processing options
 type RecordingSettings struct {
-	RecMode          int    `xml:"recMode" json:"rec_mode"`
-	Priority         int    `xml:"priority" json:"priority"`
-	TuijyuuFlag      int    `xml:"tuijyuuFlag" json:"auto_follow"`
-	ServiceMode      int    `xml:"serviceMode" json:"service_mode"`
-	PittariFlag      int    `xml:"pittariFlag" json:"exact_match"`
-	BatFilePath      string `xml:"batFilePath" json:"bat_file"`
-	RecFolderList    string `xml:"recFolderList" json:"rec_folders"`
-	SuspendMode      int    `xml:"suspendMode" json:"suspend_mode"`
-	DefServiceMode   int    `xml:"defserviceMode" json:"def_service_mode"`
-	RebootFlag       int    `xml:"rebootFlag" json:"reboot"`
-	UseMargineFlag   int    `xml:"useMargineFlag" json:"use_margin"`
-	StartMargine     int    `xml:"startMargine" json:"start_margin"`
-	EndMargine       int    `xml:"endMargine" json:"end_margin"`
-	ContinueRecFlag  int    `xml:"continueRecFlag" json:"continue_rec"`
-	PartialRecFlag   int    `xml:"partialRecFlag" json:"partial_rec"`
-	TunerID          int    `xml:"tunerID" json:"tuner_id"`
-	PartialRecFolder string `xml:"partialRecFolder" json:"partial_rec_folder"`
+	RecMode          int        `xml:"recMode" json:"rec_mode"`
+	Priority         int        `xml:"priority" json:"priority"`
+	TuijyuuFlag      int        `xml:"tuijyuuFlag" json:"auto_follow"`
+	ServiceMode      int        `xml:"serviceMode" json:"service_mode"`
+	PittariFlag      int        `xml:"pittariFlag" json:"exact_match"`
+	BatFilePath      string     `xml:"batFilePath" json:"bat_file"`
+	RecFolderList    RecFolders `xml:"recFolderList>recFolderInfo" json:"rec_folders"`
+	SuspendMode      int        `xml:"suspendMode" json:"suspend_mode"`
+	DefServiceMode   int        `xml:"defserviceMode" json:"def_service_mode"`
+	RebootFlag       int        `xml:"rebootFlag" json:"reboot"`
+	UseMargineFlag   int        `xml:"useMargineFlag" json:"use_margin"`
+	StartMargine     int        `xml:"startMargine" json:"start_margin"`
+	EndMargine       int        `xml:"endMargine" json:"end_margin"`
+	ContinueRecFlag  int        `xml:"continueRecFlag" json:"continue_rec"`
+	PartialRecFlag   int        `xml:"partialRecFlag" json:"partial_rec"`
+	TunerID          int        `xml:"tunerID" json:"tuner_id"`
+	PartialRecFolder RecFolders `xml:"partialRecFolder>recFolderInfo" json:"partial_rec_folder"`
 }
 
 // IsAutoFollow returns true if auto-follow is enabled (TuijyuuFlag == 1)
@@ -125,6 +180,7 @@ type EnumReserveInfoResponse struct {
 
 // ReservationInfo represents a single reservation from EnumReserveInfo API
 type ReservationInfo struct {
+	Server         string     `xml:"-" json:"server,omitempty"` // EMWUI server the reservation was read from (multi-server output only)
 	ID             int        `xml:"ID" json:"id"`
 	Title          string     `xml:"title" json:"title"`
 	StartDate      string     `xml:"startDate" json:"start_date"`      // Format: 2025/12/22
@@ -136,42 +192,82 @@ type ReservationInfo struct {
 	SID            int        `xml:"SID" json:"sid"`
 	EventID        int        `xml:"eventID" json:"event_id"`
 	Comment        string     `xml:"comment" json:"comment"`
+	OverlapMode    int        `xml:"overlapMode" json:"overlap_mode"` // 0 = OK, 1 = partially recorded, 2 = not recorded (no free tuner)
 	RecSetting     RecSetting `xml:"recSetting" json:"rec_setting"`
 }
 
 // RecSetting represents recording settings for a reservation
 type RecSetting struct {
-	RecMode          int              `xml:"recMode" json:"rec_mode"`
-	Priority         int              `xml:"priority" json:"priority"`
-	TuijyuuFlag      int              `xml:"tuijyuuFlag" json:"tuijyuu_flag"`
-	ServiceMode      int              `xml:"serviceMode" json:"service_mode"`
-	PittariFlag      int              `xml:"pittariFlag" json:"pittari_flag"`
-	BatFilePath      string           `xml:"batFilePath" json:"bat_file_path"`
-	SuspendMode      int              `xml:"suspendMode" json:"suspend_mode"`
-	RebootFlag       int              `xml:"rebootFlag" json:"reboot_flag"`
-	StartMargin      int              `xml:"startMargin" json:"start_margin"`
-	EndMargin        int              `xml:"endMargin" json:"end_margin"`
-	ContinueRecFlag  int              `xml:"continueRecFlag" json:"continue_rec_flag"`
-	PartialRecFlag   int              `xml:"partialRecFlag" json:"partial_rec_flag"`
-	TunerID          int              `xml:"tunerID" json:"tuner_id"`
-	RecFolderList    RecFolderList    `xml:"recFolderList" json:"rec_folder_list"`
-	PartialRecFolder PartialRecFolder `xml:"partialRecFolder" json:"partial_rec_folder"`
-}
-
-// RecFolderList represents the list of recording folders
-type RecFolderList struct {
-	RecFolders []RecFolder `xml:"recFolderInfo" json:"rec_folders"`
+	RecMode          int        `xml:"recMode" json:"rec_mode"`
+	Priority         int        `xml:"priority" json:"priority"`
+	TuijyuuFlag      int        `xml:"tuijyuuFlag" json:"tuijyuu_flag"`
+	ServiceMode      int        `xml:"serviceMode" json:"service_mode"`
+	PittariFlag      int        `xml:"pittariFlag" json:"pittari_flag"`
+	BatFilePath      string     `xml:"batFilePath" json:"bat_file_path"`
+	SuspendMode      int        `xml:"suspendMode" json:"suspend_mode"`
+	RebootFlag       int        `xml:"rebootFlag" json:"reboot_flag"`
+	UseMargineFlag   int        `xml:"useMargineFlag" json:"use_margine_flag"`
+	StartMargin      int        `xml:"startMargin" json:"start_margin"`
+	EndMargin        int        `xml:"endMargin" json:"end_margin"`
+	ContinueRecFlag  int        `xml:"continueRecFlag" json:"continue_rec_flag"`
+	PartialRecFlag   int        `xml:"partialRecFlag" json:"partial_rec_flag"`
+	TunerID          int        `xml:"tunerID" json:"tuner_id"`
+	RecFolderList    RecFolders `xml:"recFolderList>recFolderInfo" json:"rec_folder_list"`
+	PartialRecFolder RecFolders `xml:"partialRecFolder>recFolderInfo" json:"partial_rec_folder"`
 }
 
-// RecFolder represents a single recording folder
-type RecFolder struct {
-	RecFolder     string `xml:"recFolder" json:"rec_folder"`
-	WritePlugIn   string `xml:"writePlugIn" json:"write_plugin"`
-	RecNamePlugIn string `xml:"recNamePlugIn" json:"rec_name_plugin"`
+// recSettingFields is RecSetting without its JSON methods
+type recSettingFields RecSetting
+
+// recSettingJSON is the JSON form of RecSetting, which keeps the layout of earlier
+// versions: the folders wrapped in an object, and a single partial reception folder
+type recSettingJSON struct {
+	recSettingFields
+	RecFolderList    recFolderListJSON `json:"rec_folder_list"`
+	PartialRecFolder RecFolder         `json:"partial_rec_folder"`
+}
+
+// recFolderListJSON wraps the recording folders of a reservation in JSON output
+type recFolderListJSON struct {
+	RecFolders []RecFolder `json:"rec_folders"`
 }
 
-// PartialRecFolder represents partial recording folder settings
-type PartialRecFolder struct {
+// MarshalJSON writes the folders in the JSON layout of earlier versions
+// Only the first partial reception folder is written (an empty one if there is none).
+func (r RecSetting) MarshalJSON() ([]byte, error) {
+	v := recSettingJSON{
+		recSettingFields: recSettingFields(r),
+		RecFolderList:    recFolderListJSON{RecFolders: r.RecFolderList},
+	}
+	if len(r.PartialRecFolder) > 0 {
+		v.PartialRecFolder = r.PartialRecFolder[0]
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON reads the JSON layout written by MarshalJSON
+func (r *RecSetting) UnmarshalJSON(data []byte) error {
+	var v recSettingJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*r = RecSetting(v.recSettingFields)
+	r.RecFolderList = v.RecFolderList.RecFolders
+	r.PartialRecFolder = nil
+	if v.PartialRecFolder.RecFolder != "" {
+		r.PartialRecFolder = RecFolders{v.PartialRecFolder}
+	}
+	return nil
+}
+
+// HasMargins returns true if the reservation uses custom margins
+// EMWUI omits useMargineFlag for reservations, so non-zero margins count as custom
+func (r *RecSetting) HasMargins() bool {
+	return r.UseMargineFlag == 1 || r.StartMargin != 0 || r.EndMargin != 0
+}
+
+// RecFolder represents a single recording folder
+type RecFolder struct {
 	RecFolder     string `xml:"recFolder" json:"rec_folder"`
 	WritePlugIn   string `xml:"writePlugIn" json:"write_plugin"`
 	RecNamePlugIn string `xml:"recNamePlugIn" json:"rec_name_plugin"`
@@ -187,6 +283,11 @@ func (r *ReservationInfo) DurationMinutes() int {
 	return r.DurationSecond / 60
 }
 
+// IsDisabled returns true if recording is disabled for this reservation (recMode 5-9)
+func (r *ReservationInfo) IsDisabled() bool {
+	return isNoRecMode(r.RecSetting.RecMode)
+}
+
 // RecModeString returns a human-readable recording mode
 func (r *ReservationInfo) RecModeString() string {
 	switch r.RecSetting.RecMode {
@@ -0,0 +1,78 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// ReserveRequest contains the parameters for reserving a single EPG event
+type ReserveRequest struct {
+	ONID     int    `json:"onid"`      // Original Network ID of the event's channel
+	TSID     int    `json:"tsid"`      // Transport Stream ID of the event's channel
+	SID      int    `json:"sid"`       // Service ID of the event's channel
+	EventID  int    `json:"event_id"`  // Event ID from EnumEventInfo
+	PresetID int    `json:"preset_id"` // Preset ID (RecPresetIDCustom = custom settings below)
+	CToken   string `json:"ctok"`      // CSRF token (fetched from HTML page)
+
+	// Recording settings
+	RecSettingRequest
+}
+
+// NewReserveRequest creates a reservation request for an event with default recording settings
+func NewReserveRequest(onid, tsid, sid, eventID int) *ReserveRequest {
+	return &ReserveRequest{
+		ONID:     onid,
+		TSID:     tsid,
+		SID:      sid,
+		EventID:  eventID,
+		PresetID: RecPresetIDCustom,
+
+		RecSettingRequest: NewRecSettingRequest(),
+	}
+}
+
+// NewReserveRequestFromEvent creates a reservation request for an EPG event
+func NewReserveRequestFromEvent(event *EventInfo) *ReserveRequest {
+	return NewReserveRequest(event.ONID, event.TSID, event.SID, event.EventID)
+}
+
+// NewReserveRequestFromReservation creates a request that reproduces an existing reservation,
+// so that posting it back with UpdateReserve keeps every setting unchanged
+func NewReserveRequestFromReservation(res *ReservationInfo) *ReserveRequest {
+	req := NewReserveRequest(res.ONID, res.TSID, res.SID, res.EventID)
+	req.RecSettingRequest = NewRecSettingRequestFromRecSetting(&res.RecSetting)
+	return req
+}
+
+// ChannelID returns the channel identifier in ONID-TSID-SID format
+func (r *ReserveRequest) ChannelID() string {
+	return fmt.Sprintf("%d-%d-%d", r.ONID, r.TSID, r.SID)
+}
+
+// Validate checks if the request has valid parameters
+func (r *ReserveRequest) Validate() error {
+	if r.ONID <= 0 || r.TSID < 0 || r.SID <= 0 {
+		return fmt.Errorf("invalid channel %s: ONID and SID must be greater than 0", r.ChannelID())
+	}
+
+	if r.EventID <= 0 {
+		return fmt.Errorf("event ID is required (must be greater than 0)")
+	}
+
+	return r.RecSettingRequest.Validate()
+}
+
+// ToFormData converts the request to application/x-www-form-urlencoded format
+func (r *ReserveRequest) ToFormData() string {
+	v := url.Values{}
+
+	v.Set("onid", fmt.Sprintf("%d", r.ONID))
+	v.Set("tsid", fmt.Sprintf("%d", r.TSID))
+	v.Set("sid", fmt.Sprintf("%d", r.SID))
+	v.Set("eid", fmt.Sprintf("%d", r.EventID))
+	v.Set("presetID", fmt.Sprintf("%d", r.PresetID))
+	v.Set("ctok", r.CToken)
+	r.RecSettingRequest.addFormData(v)
+
+	return v.Encode()
+}
@@ -1,6 +1,8 @@
 package integration
 
 import (
+	"context"
+	"net/url"
 	"strings"
 	"testing"
 
@@ -19,7 +21,7 @@ func TestGetCToken(t *testing.T) {
 	c := client.NewClient(mock.URL())
 
 	// Get ctok
-	ctok, err := c.GetCToken()
+	ctok, err := c.GetCToken(context.Background())
 
 	// Verify
 	if err != nil {
@@ -45,7 +47,7 @@ func TestGetCToken_InvalidHTML(t *testing.T) {
 	c := client.NewClient(htmlServer.URL)
 
 	// Try to get ctok
-	_, err := c.GetCToken()
+	_, err := c.GetCToken(context.Background())
 
 	// Verify error
 	if err == nil {
@@ -74,7 +76,7 @@ func TestSetAutoAdd_Success(t *testing.T) {
 	)
 
 	// Call API
-	resp, err := c.SetAutoAdd(req)
+	resp, err := c.SetAutoAdd(context.Background(), req)
 
 	// Verify
 	if err != nil {
@@ -103,7 +105,7 @@ func TestSetAutoAdd_MissingAndKey(t *testing.T) {
 	)
 
 	// Call API
-	_, err := c.SetAutoAdd(req)
+	_, err := c.SetAutoAdd(context.Background(), req)
 
 	// Verify error
 	if err == nil {
@@ -132,7 +134,7 @@ func TestSetAutoAdd_MissingServiceList(t *testing.T) {
 	)
 
 	// Call API
-	_, err := c.SetAutoAdd(req)
+	_, err := c.SetAutoAdd(context.Background(), req)
 
 	// Verify error
 	if err == nil {
@@ -161,7 +163,7 @@ func TestSetAutoAdd_InvalidServiceListFormat(t *testing.T) {
 	)
 
 	// Call API
-	_, err := c.SetAutoAdd(req)
+	_, err := c.SetAutoAdd(context.Background(), req)
 
 	// Verify error
 	if err == nil {
@@ -186,7 +188,7 @@ func TestSetAutoAdd_ConnectionError(t *testing.T) {
 	)
 
 	// Call API
-	_, err := c.SetAutoAdd(req)
+	_, err := c.SetAutoAdd(context.Background(), req)
 
 	// Verify error
 	if err == nil {
@@ -230,7 +232,7 @@ func TestSetAutoAdd_JapaneseEncoding(t *testing.T) {
 	)
 
 	// Call API
-	resp, err := c.SetAutoAdd(req)
+	resp, err := c.SetAutoAdd(context.Background(), req)
 
 	// Verify
 	if err != nil {
@@ -268,7 +270,7 @@ func TestSetAutoAdd_HTMLResponse(t *testing.T) {
 	)
 
 	// Call API
-	_, err := c.SetAutoAdd(req)
+	_, err := c.SetAutoAdd(context.Background(), req)
 
 	// Verify error
 	if err == nil {
@@ -297,7 +299,7 @@ func TestSetAutoAdd_APIError(t *testing.T) {
 	)
 
 	// Call API
-	_, err := c.SetAutoAdd(req)
+	_, err := c.SetAutoAdd(context.Background(), req)
 
 	// Verify error
 	if err == nil {
@@ -340,7 +342,7 @@ func TestSetAutoAdd_MultipleChannels(t *testing.T) {
 	)
 
 	// Call API
-	resp, err := c.SetAutoAdd(req)
+	resp, err := c.SetAutoAdd(context.Background(), req)
 
 	// Verify
 	if err != nil {
@@ -397,7 +399,7 @@ func TestSetAutoAdd_WithNotKey(t *testing.T) {
 	)
 
 	// Call API
-	resp, err := c.SetAutoAdd(req)
+	resp, err := c.SetAutoAdd(context.Background(), req)
 
 	// Verify
 	if err != nil {
@@ -507,3 +509,171 @@ func TestAutoAddRuleRequest_ToFormData(t *testing.T) {
 		t.Error("Form data missing ctok")
 	}
 }
+
+// TestParseDaysOfWeek tests day-of-week name parsing
+func TestParseDaysOfWeek(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     []string
+		want      []int
+		wantError bool
+	}{
+		{name: "Japanese", input: []string{"月", "水"}, want: []int{1, 3}},
+		{name: "English", input: []string{"Sun", "saturday"}, want: []int{0, 6}},
+		{name: "Weekdays shortcut", input: []string{"weekdays"}, want: []int{1, 2, 3, 4, 5}},
+		{name: "Duplicates removed", input: []string{"土日", "sat"}, want: []int{6, 0}},
+		{name: "Unknown day", input: []string{"someday"}, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := models.ParseDaysOfWeek(tt.input)
+			if tt.wantError {
+				if err == nil {
+					t.Errorf("Expected error for %v, got nil", tt.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %v, got %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Expected %v, got %v", tt.want, got)
+					break
+				}
+			}
+		})
+	}
+}
+
+// TestBuildDateList tests conversion of days and time window to dateList entries
+func TestBuildDateList(t *testing.T) {
+	tests := []struct {
+		name      string
+		days      []int
+		start     string
+		end       string
+		want      string
+		wantError bool
+	}{
+		{name: "Evening window", days: []int{1, 2}, start: "21:00", end: "23:30", want: "月-21:00-月-23:30,火-21:00-火-23:30"},
+		{name: "Across midnight", days: []int{6}, start: "23:00", end: "01:00", want: "土-23:00-日-1:00"},
+		{name: "Whole day", days: []int{0}, want: "日-0:00-月-0:00"},
+		{name: "Every day", start: "06:00", end: "09:00", want: "日-6:00-日-9:00,月-6:00-月-9:00,火-6:00-火-9:00,水-6:00-水-9:00,木-6:00-木-9:00,金-6:00-金-9:00,土-6:00-土-9:00"},
+		{name: "Invalid time", days: []int{1}, start: "25:00", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dates, err := models.BuildDateList(tt.days, tt.start, tt.end)
+			if tt.wantError {
+				if err == nil {
+					t.Errorf("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := models.FormatDateList(dates); got != tt.want {
+				t.Errorf("Expected '%s', got '%s'", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestAutoAddRuleRequest_ValidateSettings tests range checks on search and recording settings
+func TestAutoAddRuleRequest_ValidateSettings(t *testing.T) {
+	tests := []struct {
+		name          string
+		modify        func(req *models.AutoAddRuleRequest)
+		errorContains string
+	}{
+		{name: "Defaults are valid", modify: func(req *models.AutoAddRuleRequest) {}},
+		{name: "Priority too high", modify: func(req *models.AutoAddRuleRequest) { req.Priority = 6 }, errorContains: "priority"},
+		{name: "Invalid rec mode", modify: func(req *models.AutoAddRuleRequest) { req.RecMode = 10 }, errorContains: "recMode"},
+		{name: "Negative tuner", modify: func(req *models.AutoAddRuleRequest) { req.TunerID = -1 }, errorContains: "tunerID"},
+		{name: "Invalid suspend mode", modify: func(req *models.AutoAddRuleRequest) { req.SuspendMode = 5 }, errorContains: "suspendMode"},
+		{name: "Duration min above max", modify: func(req *models.AutoAddRuleRequest) {
+			req.ChkDurationMin = 60
+			req.ChkDurationMax = 30
+		}, errorContains: "duration"},
+		{name: "Malformed dateList", modify: func(req *models.AutoAddRuleRequest) { req.DateList = "月-21:00" }, errorContains: "dateList"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := models.NewAutoAddRuleRequest("ニュース", "", []string{"32736-32736-1024"})
+			tt.modify(req)
+
+			err := req.Validate()
+			if tt.errorContains == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected error containing '%s', got nil", tt.errorContains)
+			}
+			if !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("Expected error containing '%s', got: %v", tt.errorContains, err)
+			}
+		})
+	}
+}
+
+// TestSetAutoAdd_SearchAndRecSettings tests that custom settings reach the server
+func TestSetAutoAdd_SearchAndRecSettings(t *testing.T) {
+	// Create mock server
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	var values url.Values
+	mock.SetAutoAddHandler(func(v map[string][]string) (bool, string) {
+		values = v
+		return true, "EPG自動予約を追加しました"
+	})
+
+	// Create client
+	c := client.NewClient(mock.URL())
+
+	req := models.NewAutoAddRuleRequest("^ドラマ", "", []string{"32736-32736-1024"})
+	req.RegExpFlag = 1
+	req.TitleOnlyFlag = 0
+	req.ChkDurationMin = 45
+	req.DateList = "金-21:00-金-23:30"
+	req.Priority = 4
+	req.TunerID = 2
+	req.UseDefMarginFlag = 0
+	req.StartMargin = 30
+	req.EndMargin = 60
+
+	if _, err := c.SetAutoAdd(context.Background(), req); err != nil {
+		t.Fatalf("SetAutoAdd() failed: %v", err)
+	}
+
+	checks := map[string]string{
+		"regExpFlag":     "1",
+		"chkDurationMin": "45",
+		"dateList":       "金-21:00-金-23:30",
+		"priority":       "4",
+		"tunerID":        "2",
+		"startMargin":    "30",
+		"endMargin":      "60",
+		"presetID":       "65535",
+	}
+	for name, want := range checks {
+		if got := values.Get(name); got != want {
+			t.Errorf("Expected %s=%s, got '%s'", name, want, got)
+		}
+	}
+
+	if values.Get("titleOnlyFlag") != "" {
+		t.Errorf("Expected titleOnlyFlag to be omitted for full-text search, got '%s'", values.Get("titleOnlyFlag"))
+	}
+}
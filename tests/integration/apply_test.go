@@ -0,0 +1,318 @@
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+	"github.com/epy0n0ff/epgtimer-cli/tests/testdata"
+)
+
+// applyTestRules returns server rules used by the manifest plan tests
+func applyTestRules() []models.AutoAddRule {
+	return []models.AutoAddRule{
+		{
+			ID: 1,
+			SearchSettings: models.SearchSettings{
+				AndKey:        "ニュース",
+				TitleOnlyFlag: 1,
+				ServiceList:   []models.ServiceInfo{{ONID: 32736, TSID: 32736, SID: 1024}},
+			},
+			RecordingSettings: models.RecordingSettings{RecMode: 1, Priority: 2, TuijyuuFlag: 1, ServiceMode: 1},
+		},
+		{
+			ID: 2,
+			SearchSettings: models.SearchSettings{
+				AndKey:        "ドラマ",
+				TitleOnlyFlag: 1,
+				ServiceList: []models.ServiceInfo{
+					{ONID: 32736, TSID: 32736, SID: 1024},
+					{ONID: 32736, TSID: 32736, SID: 1025},
+				},
+			},
+			RecordingSettings: models.RecordingSettings{RecMode: 1, Priority: 2, TuijyuuFlag: 1, ServiceMode: 1},
+		},
+		{
+			ID: 3,
+			SearchSettings: models.SearchSettings{
+				AndKey:      "天気",
+				ServiceList: []models.ServiceInfo{{ONID: 32736, TSID: 32736, SID: 1024}},
+			},
+			RecordingSettings: models.RecordingSettings{RecMode: 1, Priority: 2},
+		},
+	}
+}
+
+// TestPlanManifest tests that creates, updates, deletes and unchanged rules are detected
+func TestPlanManifest(t *testing.T) {
+	manifest := &models.Manifest{Rules: []models.ManifestRule{
+		{AndKey: "ニュース", ServiceList: []string{"32736-32736-1024"}},
+		// Channel order differs from the server but the set is the same
+		{AndKey: "ドラマ", ServiceList: []string{"32736-32736-1025", "32736-32736-1024"}, Priority: 4},
+		{AndKey: "映画", ServiceList: []string{"4-16625-211"}},
+	}}
+
+	plan, err := models.PlanManifest(manifest, applyTestRules(), true)
+	if err != nil {
+		t.Fatalf("PlanManifest() failed: %v", err)
+	}
+
+	if plan.Unchanged != 1 {
+		t.Errorf("Expected 1 unchanged rule, got %d", plan.Unchanged)
+	}
+
+	if len(plan.Creates) != 1 || plan.Creates[0].AndKey != "映画" {
+		t.Errorf("Expected to create '映画', got %+v", plan.Creates)
+	}
+
+	if len(plan.Updates) != 1 {
+		t.Fatalf("Expected 1 update, got %d", len(plan.Updates))
+	}
+	update := plan.Updates[0]
+	if update.ID != 2 {
+		t.Errorf("Expected update for ID 2, got %d", update.ID)
+	}
+	if len(update.Changes) != 1 || !strings.HasPrefix(update.Changes[0], "priority:") {
+		t.Errorf("Expected only a priority change, got %v", update.Changes)
+	}
+
+	if len(plan.Deletes) != 1 || plan.Deletes[0].ID != 3 {
+		t.Errorf("Expected to delete ID 3, got %+v", plan.Deletes)
+	}
+}
+
+// TestPlanManifest_NoPrune tests that unmatched rules are kept without --prune
+func TestPlanManifest_NoPrune(t *testing.T) {
+	manifest := &models.Manifest{Rules: []models.ManifestRule{
+		{AndKey: "ニュース", ServiceList: []string{"32736-32736-1024"}},
+	}}
+
+	plan, err := models.PlanManifest(manifest, applyTestRules(), false)
+	if err != nil {
+		t.Fatalf("PlanManifest() failed: %v", err)
+	}
+
+	if !plan.IsEmpty() {
+		t.Errorf("Expected an empty plan, got %+v", plan)
+	}
+}
+
+// TestPlanManifest_PinnedID tests matching by ID and the error for unknown IDs
+func TestPlanManifest_PinnedID(t *testing.T) {
+	// Renaming a rule keeps its ID when pinned
+	manifest := &models.Manifest{Rules: []models.ManifestRule{
+		{ID: 3, AndKey: "気象情報", ServiceList: []string{"32736-32736-1024"}},
+	}}
+
+	plan, err := models.PlanManifest(manifest, applyTestRules(), false)
+	if err != nil {
+		t.Fatalf("PlanManifest() failed: %v", err)
+	}
+	if len(plan.Creates) != 0 || len(plan.Updates) != 1 || plan.Updates[0].ID != 3 {
+		t.Errorf("Expected a single update of ID 3, got %+v", plan)
+	}
+
+	manifest.Rules[0].ID = 999
+	_, err = models.PlanManifest(manifest, applyTestRules(), false)
+	if err == nil {
+		t.Fatal("Expected error for unknown rule ID, got nil")
+	}
+	if !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected error message to mention 'not found', got: %v", err)
+	}
+}
+
+// TestLoadManifest tests reading YAML and JSON manifests
+func TestLoadManifest(t *testing.T) {
+	dir := t.TempDir()
+
+	yamlPath := filepath.Join(dir, "rules.yaml")
+	yamlContent := `rules:
+  - andKey: ドラマ
+    notKey: 再放送
+    serviceList: [32736-32736-1024]
+    days: [weekdays]
+    startTime: "21:00"
+    endTime: "23:30"
+    startMargin: 30
+`
+	if err := os.WriteFile(yamlPath, []byte(yamlContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	manifest, err := models.LoadManifest(yamlPath)
+	if err != nil {
+		t.Fatalf("LoadManifest() failed: %v", err)
+	}
+	if len(manifest.Rules) != 1 {
+		t.Fatalf("Expected 1 rule, got %d", len(manifest.Rules))
+	}
+
+	req, err := manifest.Rules[0].ToRequest()
+	if err != nil {
+		t.Fatalf("ToRequest() failed: %v", err)
+	}
+	if !strings.HasPrefix(req.DateList, "月-21:00-月-23:30,") {
+		t.Errorf("Unexpected dateList '%s'", req.DateList)
+	}
+	if req.UseDefMarginFlag != 0 || req.StartMargin != 30 || req.EndMargin != 0 {
+		t.Errorf("Expected custom margins 30/0, got use_def=%d start=%d end=%d", req.UseDefMarginFlag, req.StartMargin, req.EndMargin)
+	}
+
+	jsonPath := filepath.Join(dir, "rules.json")
+	jsonContent := `{"rules": [{"andKey": "ニュース", "serviceList": ["32736-32736-1024"], "priority": 3}]}`
+	if err := os.WriteFile(jsonPath, []byte(jsonContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	manifest, err = models.LoadManifest(jsonPath)
+	if err != nil {
+		t.Fatalf("LoadManifest() failed: %v", err)
+	}
+	if manifest.Rules[0].Priority != 3 {
+		t.Errorf("Expected priority 3, got %d", manifest.Rules[0].Priority)
+	}
+}
+
+// TestLoadManifest_Invalid tests manifest validation errors
+func TestLoadManifest_Invalid(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		errorContains string
+	}{
+		{
+			name:          "Unknown field",
+			content:       "rules:\n  - andKey: ニュース\n    serviceList: [32736-32736-1024]\n    priorty: 3\n",
+			errorContains: "priorty",
+		},
+		{
+			name:          "Invalid channel",
+			content:       "rules:\n  - andKey: ニュース\n    serviceList: [NHK]\n",
+			errorContains: "serviceList[0]",
+		},
+		{
+			name:          "Duplicate rule",
+			content:       "rules:\n  - andKey: ニュース\n    serviceList: [32736-32736-1024]\n  - andKey: ニュース\n    serviceList: [32736-32736-1024]\n",
+			errorContains: "duplicate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "rules.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := models.LoadManifest(path)
+			if err == nil {
+				t.Fatalf("Expected error containing '%s', got nil", tt.errorContains)
+			}
+			if !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("Expected error containing '%s', got: %v", tt.errorContains, err)
+			}
+		})
+	}
+}
+
+// TestApplyCommand_Prune tests that --prune asks for confirmation and backs up
+// the rules before deleting them
+func TestApplyCommand_Prune(t *testing.T) {
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	var deleted []int
+	mock.SetDeleteAutoAddHandler(func(id int) (bool, string) {
+		deleted = append(deleted, id)
+		return true, "EPG自動予約を削除しました"
+	})
+
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "rules.yaml")
+	manifest := "rules:\n  - andKey: サイエンスZERO\n    serviceList: [32736-32736-1024, 32736-32736-1025]\n"
+	if err := os.WriteFile(manifestPath, []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+	backupPath := filepath.Join(dir, "pruned.json")
+
+	// Declining the prompt changes nothing
+	out, err := runCLI(t, mock, "n\n", "apply", "-f", manifestPath, "--prune", "--backup-file", backupPath)
+	if err != nil {
+		t.Fatalf("apply --prune failed: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "Cancelled.") {
+		t.Errorf("Expected the prune to be cancelled, got:\n%s", out)
+	}
+	if len(deleted) != 0 {
+		t.Errorf("Expected no deletes after declining, got %v", deleted)
+	}
+	if _, err := os.Stat(backupPath); !os.IsNotExist(err) {
+		t.Errorf("Expected no backup after declining, got %v", err)
+	}
+
+	out, err = runCLI(t, mock, "", "apply", "-f", manifestPath, "--prune", "--yes", "--backup-file", backupPath)
+	if err != nil {
+		t.Fatalf("apply --prune --yes failed: %v\n%s", err, out)
+	}
+	if len(deleted) != 2 || deleted[0] != 2 || deleted[1] != 3 {
+		t.Errorf("Expected rules 2 and 3 to be deleted, got %v", deleted)
+	}
+
+	backup, err := models.LoadAutoAddRuleBackup(backupPath)
+	if err != nil {
+		t.Fatalf("LoadAutoAddRuleBackup() failed: %v", err)
+	}
+	if len(backup) != 2 || backup[0].ID != 2 || backup[1].ID != 3 {
+		t.Errorf("Expected rules 2 and 3 in the backup, got %+v", backup)
+	}
+}
+
+// TestApplyCommand_ChannelReferences tests that channel names, key:N and groups in a
+// manifest are resolved against the server's channel list
+func TestApplyCommand_ChannelReferences(t *testing.T) {
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	var received []map[string][]string
+	mock.SetAutoAddHandler(func(values map[string][]string) (bool, string) {
+		received = append(received, values)
+		return true, "EPG自動予約を追加しました"
+	})
+
+	manifestPath := filepath.Join(t.TempDir(), "rules.yaml")
+	manifest := "rules:\n  - andKey: 映画\n    serviceList: [key:1, BS朝日, \"@bs\"]\n"
+	if err := os.WriteFile(manifestPath, []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runCLI(t, mock, "", "apply", "-f", manifestPath)
+	if err != nil {
+		t.Fatalf("apply failed: %v\n%s", err, out)
+	}
+
+	if len(received) != 1 {
+		t.Fatalf("Expected 1 rule to be created, got %d", len(received))
+	}
+	var services []string
+	for _, ch := range received[0]["serviceList"] {
+		if ch != "" {
+			services = append(services, ch)
+		}
+	}
+	if len(services) != 2 || services[0] != "32736-32736-1024" || services[1] != "4-16400-151" {
+		t.Errorf("Expected serviceList [32736-32736-1024 4-16400-151], got %v", services)
+	}
+
+	// Unknown channels are reported as manifest errors
+	manifest = "rules:\n  - andKey: 映画\n    serviceList: [存在しない局]\n"
+	if err := os.WriteFile(manifestPath, []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+	out, err = runCLI(t, mock, "", "apply", "-f", manifestPath)
+	if err == nil || !strings.Contains(out, "rules[0] (映画): serviceList") {
+		t.Errorf("Expected a serviceList error for rules[0], got %v\n%s", err, out)
+	}
+}
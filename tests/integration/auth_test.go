@@ -0,0 +1,165 @@
+package integration
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/client"
+	"github.com/epy0n0ff/epgtimer-cli/tests/testdata"
+)
+
+// TestAuth_Basic tests that Basic credentials are sent after a Basic challenge
+func TestAuth_Basic(t *testing.T) {
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+	mock.RequireAuth("basic", "edcb", "secret")
+
+	apiClient := client.NewClient(mock.URL(), client.WithAuth("edcb", "secret"))
+
+	if _, err := apiClient.EnumService(context.Background()); err != nil {
+		t.Fatalf("Expected success with Basic auth, got: %v", err)
+	}
+	if _, err := apiClient.EnumReserveInfo(context.Background()); err != nil {
+		t.Fatalf("Expected success on second request, got: %v", err)
+	}
+}
+
+// TestAuth_Digest tests Digest authentication for GET and POST requests
+func TestAuth_Digest(t *testing.T) {
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+	mock.RequireAuth("digest", "edcb", "secret")
+
+	deleted := 0
+	mock.SetDeleteAutoAddHandler(func(id int) (bool, string) {
+		deleted = id
+		return true, "deleted"
+	})
+
+	apiClient := client.NewClient(mock.URL(), client.WithAuth("edcb", "secret"))
+
+	if _, err := apiClient.EnumService(context.Background()); err != nil {
+		t.Fatalf("Expected success with Digest auth, got: %v", err)
+	}
+
+	// The ctok page and the form POST are both authenticated
+	if _, err := apiClient.DeleteAutoAdd(context.Background(), 334); err != nil {
+		t.Fatalf("Expected delete to succeed with Digest auth, got: %v", err)
+	}
+	if deleted != 334 {
+		t.Errorf("Expected rule 334 to be deleted, got %d", deleted)
+	}
+}
+
+// TestAuth_WrongPassword tests that rejected credentials return ErrAPI with status 401
+func TestAuth_WrongPassword(t *testing.T) {
+	for _, scheme := range []string{"basic", "digest"} {
+		t.Run(scheme, func(t *testing.T) {
+			mock := testdata.NewMockEMWUIServer()
+			defer mock.Close()
+			mock.RequireAuth(scheme, "edcb", "secret")
+
+			_, err := client.NewClient(mock.URL(), client.WithAuth("edcb", "wrong")).EnumService(context.Background())
+
+			var apiErr *client.ErrAPI
+			if !errors.As(err, &apiErr) || apiErr.Status != http.StatusUnauthorized {
+				t.Fatalf("Expected ErrAPI with status 401, got: %v", err)
+			}
+		})
+	}
+}
+
+// TestAuth_NoCredentials tests that a 401 without configured credentials returns ErrAPI
+func TestAuth_NoCredentials(t *testing.T) {
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+	mock.RequireAuth("basic", "edcb", "secret")
+
+	_, err := client.NewClient(mock.URL()).EnumService(context.Background())
+
+	var apiErr *client.ErrAPI
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusUnauthorized {
+		t.Fatalf("Expected ErrAPI with status 401, got: %v", err)
+	}
+}
+
+// writeCACert writes the certificate of a TLS mock server to a PEM file
+func writeCACert(t *testing.T, mock *testdata.MockEMWUIServer) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: mock.Server.Certificate().Raw})
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("Failed to write CA certificate: %v", err)
+	}
+	return path
+}
+
+// TestTLS_CACert tests that a custom CA certificate is trusted
+func TestTLS_CACert(t *testing.T) {
+	mock := testdata.NewMockEMWUIServerTLS()
+	defer mock.Close()
+
+	tlsConfig, err := client.LoadTLSConfig(writeCACert(t, mock), false)
+	if err != nil {
+		t.Fatalf("Failed to load TLS config: %v", err)
+	}
+
+	if _, err := client.NewClient(mock.URL(), client.WithTLSConfig(tlsConfig)).EnumService(context.Background()); err != nil {
+		t.Fatalf("Expected success with custom CA, got: %v", err)
+	}
+}
+
+// TestTLS_UntrustedCertificate tests that self-signed certificates are rejected by default
+func TestTLS_UntrustedCertificate(t *testing.T) {
+	mock := testdata.NewMockEMWUIServerTLS()
+	defer mock.Close()
+
+	_, err := client.NewClient(mock.URL()).EnumService(context.Background())
+
+	var connErr *client.ErrConnection
+	if !errors.As(err, &connErr) {
+		t.Fatalf("Expected ErrConnection, got: %v", err)
+	}
+}
+
+// TestTLS_InsecureSkipVerify tests that verification can be disabled
+func TestTLS_InsecureSkipVerify(t *testing.T) {
+	mock := testdata.NewMockEMWUIServerTLS()
+	defer mock.Close()
+	mock.RequireAuth("digest", "edcb", "secret")
+
+	tlsConfig, err := client.LoadTLSConfig("", true)
+	if err != nil {
+		t.Fatalf("Failed to load TLS config: %v", err)
+	}
+
+	apiClient := client.NewClient(mock.URL(), client.WithTLSConfig(tlsConfig), client.WithAuth("edcb", "secret"))
+	if _, err := apiClient.EnumService(context.Background()); err != nil {
+		t.Fatalf("Expected success with verification disabled, got: %v", err)
+	}
+}
+
+// TestLoadTLSConfig_Errors tests that unreadable or invalid CA files are reported
+func TestLoadTLSConfig_Errors(t *testing.T) {
+	invalid := filepath.Join(t.TempDir(), "invalid.pem")
+	if err := os.WriteFile(invalid, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{filepath.Join(t.TempDir(), "missing.pem"), invalid} {
+		if _, err := client.LoadTLSConfig(path, false); err == nil {
+			t.Errorf("Expected error for %s, got nil", path)
+		}
+	}
+
+	config, err := client.LoadTLSConfig("", false)
+	if err != nil || config != nil {
+		t.Errorf("Expected nil config without options, got %v, %v", config, err)
+	}
+}
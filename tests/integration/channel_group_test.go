@@ -0,0 +1,297 @@
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+	"github.com/epy0n0ff/epgtimer-cli/tests/testdata"
+)
+
+// TestChannelGroups_ResolveAll tests expanding built-in, filter, list and file groups
+func TestChannelGroups_ResolveAll(t *testing.T) {
+	listFile := filepath.Join(t.TempDir(), "news.txt")
+	content := "# News channels\n32737-32737-1032  # Eテレ\n\nBS11\n"
+	if err := os.WriteFile(listFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	builtin := models.BuiltinChannelGroups
+	groups := map[string]models.ChannelGroup{
+		"all":          builtin["all"],
+		"terrestrial":  builtin["terrestrial"],
+		"bs":           builtin["bs"],
+		"my-favorites": {Channels: []string{"key:2", "BS朝日1"}},
+		"news":         {File: listFile},
+		"data":         {Type: "data"},
+		"asahi":        {Name: "ｂｓ朝日"},
+	}
+	resolver := &models.ChannelResolver{Channels: resolverTestChannels(), Groups: groups}
+
+	tests := []struct {
+		refs     []string
+		expected []string
+	}{
+		{[]string{"@bs"}, []string{"4-16625-211", "4-16400-151", "4-16400-152"}},
+		{[]string{"@terrestrial"}, []string{"32736-32736-1024", "32736-32736-1025", "32737-32737-1032"}},
+		{[]string{"@all"}, []string{"32736-32736-1024", "32736-32736-1025", "32737-32737-1032", "4-16625-211", "4-16400-151", "4-16400-152"}},
+		{[]string{"@data"}, []string{"32737-32737-1040"}},
+		{[]string{"@asahi"}, []string{"4-16400-151", "4-16400-152"}},
+		{[]string{"@my-favorites"}, []string{"32737-32737-1032", "4-16400-151"}},
+		{[]string{"@news"}, []string{"32737-32737-1032", "4-16625-211"}},
+		// Duplicates are removed, the first occurrence keeps its position
+		{[]string{"BS朝日1", "@my-favorites", "@asahi"}, []string{"4-16400-151", "32737-32737-1032", "4-16400-152"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.refs, ","), func(t *testing.T) {
+			ids, err := resolver.ResolveAll(tt.refs)
+			if err != nil {
+				t.Fatalf("ResolveAll(%v) failed: %v", tt.refs, err)
+			}
+			if !reflect.DeepEqual(ids, tt.expected) {
+				t.Errorf("ResolveAll(%v) = %v, expected %v", tt.refs, ids, tt.expected)
+			}
+		})
+	}
+}
+
+// TestChannelGroups_Errors tests unknown, empty and misused groups
+func TestChannelGroups_Errors(t *testing.T) {
+	groups := map[string]models.ChannelGroup{
+		"cs":      {Broadcast: "cs"},
+		"missing": {File: filepath.Join(t.TempDir(), "missing.txt")},
+		"typo":    {Channels: []string{"NHK総合9"}},
+	}
+	resolver := &models.ChannelResolver{Channels: resolverTestChannels(), Groups: groups}
+
+	tests := []struct {
+		name          string
+		refs          []string
+		errorContains string
+	}{
+		{"Unknown group", []string{"@bs"}, "available: @cs, @missing, @typo"},
+		{"No matching channels", []string{"@cs"}, "matches no channels"},
+		{"Missing file", []string{"@missing"}, "channel group '@missing'"},
+		{"Unknown channel", []string{"@typo"}, "no channel matches 'NHK総合9'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := resolver.ResolveAll(tt.refs)
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("Expected error containing '%s', got: %v", tt.errorContains, err)
+			}
+		})
+	}
+
+	// A group is not a single channel
+	if _, err := resolver.Resolve("@cs"); err == nil || !strings.Contains(err.Error(), "single channel") {
+		t.Errorf("Expected single channel error, got: %v", err)
+	}
+}
+
+// TestChannelGroup_Validate tests channel group validation
+func TestChannelGroup_Validate(t *testing.T) {
+	tests := []struct {
+		name          string
+		group         models.ChannelGroup
+		errorContains string
+	}{
+		{"List", models.ChannelGroup{Channels: []string{"key:1"}}, ""},
+		{"Filter", models.ChannelGroup{Broadcast: "bs", Type: "tv"}, ""},
+		{"Empty", models.ChannelGroup{}, "no channels"},
+		{"List and filter", models.ChannelGroup{File: "a.txt", Type: "tv"}, "not both"},
+		{"Invalid broadcast", models.ChannelGroup{Broadcast: "cable"}, "invalid broadcast"},
+		{"Invalid type", models.ChannelGroup{Type: "video"}, "invalid type"},
+		{"Nested group", models.ChannelGroup{Channels: []string{"@bs"}}, "cannot contain other groups"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.group.Validate()
+			if tt.errorContains == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("Expected error containing '%s', got: %v", tt.errorContains, err)
+			}
+		})
+	}
+}
+
+// TestChannelInfo_Broadcast tests detecting the broadcast system from the network ID
+func TestChannelInfo_Broadcast(t *testing.T) {
+	tests := []struct {
+		onid     int
+		expected string
+	}{
+		{32736, "terrestrial"},
+		{0x7880, "terrestrial"},
+		{4, "bs"},
+		{6, "cs"},
+		{7, "cs"},
+		{10, "cs"},
+		{1, "other"},
+	}
+
+	for _, tt := range tests {
+		ch := models.ChannelInfo{ONID: tt.onid}
+		if got := ch.Broadcast(); got != tt.expected {
+			t.Errorf("Broadcast() for ONID %d = %s, expected %s", tt.onid, got, tt.expected)
+		}
+	}
+}
+
+// TestReadChannelFile tests the channel list file format
+func TestReadChannelFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "channels.txt")
+	content := "# Tokyo channels\n32736-32736-1024  # NHK総合\n\n   key:4\nBS朝日 1\n@bs\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	channels, err := models.ReadChannelFile(path)
+	if err != nil {
+		t.Fatalf("ReadChannelFile failed: %v", err)
+	}
+
+	expected := []string{"32736-32736-1024", "key:4", "BS朝日 1", "@bs"}
+	if !reflect.DeepEqual(channels, expected) {
+		t.Errorf("Expected %v, got %v", expected, channels)
+	}
+
+	// A file with only comments is an error
+	if err := os.WriteFile(path, []byte("# nothing\n\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := models.ReadChannelFile(path); err == nil {
+		t.Error("Expected error for a file without channels")
+	}
+}
+
+// TestLoadConfig_ChannelGroups tests channel groups in the config file and profiles
+func TestLoadConfig_ChannelGroups(t *testing.T) {
+	path := writeConfig(t, `channelGroups:
+  my-favorites: [32736-32736-1024, key:4]
+  news:
+    file: news.txt
+  bs:
+    broadcast: bs
+profiles:
+  home:
+    endpoint: http://192.168.1.10:5510
+    channelGroups:
+      my-favorites:
+        channels: [BS朝日1]
+`)
+
+	config, err := models.LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	// Relative files are resolved against the config file
+	if expected := filepath.Join(filepath.Dir(path), "news.txt"); config.ChannelGroups["news"].File != expected {
+		t.Errorf("Expected file %s, got %s", expected, config.ChannelGroups["news"].File)
+	}
+
+	// Without a profile: built-in groups plus the shared groups
+	groups := config.ChannelGroupsFor(nil)
+	if !reflect.DeepEqual(groups["my-favorites"].Channels, []string{"32736-32736-1024", "key:4"}) {
+		t.Errorf("Unexpected my-favorites group: %+v", groups["my-favorites"])
+	}
+	if groups["bs"].Type != "" {
+		t.Errorf("Expected the shared bs group to replace the built-in one, got %+v", groups["bs"])
+	}
+	if _, ok := groups["terrestrial"]; !ok {
+		t.Error("Expected the built-in terrestrial group")
+	}
+
+	// The profile's groups take precedence
+	profile, err := config.Profile("home")
+	if err != nil {
+		t.Fatal(err)
+	}
+	groups = config.ChannelGroupsFor(profile)
+	if !reflect.DeepEqual(groups["my-favorites"].Channels, []string{"BS朝日1"}) {
+		t.Errorf("Expected the profile's my-favorites group, got %+v", groups["my-favorites"])
+	}
+
+	// The built-in groups are not modified
+	if models.BuiltinChannelGroups["bs"].Type != "tv" {
+		t.Errorf("Built-in groups were modified: %+v", models.BuiltinChannelGroups["bs"])
+	}
+}
+
+// TestLoadConfig_InvalidChannelGroups tests channel group validation in the config file
+func TestLoadConfig_InvalidChannelGroups(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		errorContains string
+	}{
+		{
+			name:          "Invalid name",
+			content:       "channelGroups:\n  \"my favorites\": [key:1]\n",
+			errorContains: "invalid channel group name",
+		},
+		{
+			name:          "Unknown field",
+			content:       "channelGroups:\n  bs:\n    broadcst: bs\n",
+			errorContains: "broadcst",
+		},
+		{
+			name:          "Invalid group in profile",
+			content:       "profiles:\n  home:\n    channelGroups:\n      bs:\n        broadcast: satellite\n",
+			errorContains: "profile 'home': channel group 'bs'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := models.LoadConfig(writeConfig(t, tt.content))
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("Expected error containing '%s', got: %v", tt.errorContains, err)
+			}
+		})
+	}
+}
+
+// TestChannelFlag_RejectsGroups tests that single-channel --channel flags reject channel
+// groups instead of silently using the first channel of the group
+func TestChannelFlag_RejectsGroups(t *testing.T) {
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	tests := [][]string{
+		{"list", "--channel", "@bs"},
+		{"reservations", "--channel", "@bs"},
+		{"reserve", "--channel", "@bs", "--event-id", "7331"},
+		{"manual-rules", "add", "--title", "ニュース", "--channel", "@bs", "--days", "mon", "--start-time", "19:00", "--duration", "30"},
+	}
+
+	for _, args := range tests {
+		t.Run(args[0], func(t *testing.T) {
+			out, err := runCLI(t, mock, "", args...)
+			if err == nil {
+				t.Fatalf("Expected an error, got:\n%s", out)
+			}
+			if !strings.Contains(out, "channel group '@bs' cannot be used here") {
+				t.Errorf("Expected the group to be rejected, got:\n%s", out)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package integration
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+)
+
+// resolverTestChannels returns channels used by the resolver tests
+func resolverTestChannels() []models.ChannelInfo {
+	return []models.ChannelInfo{
+		{ONID: 32736, TSID: 32736, SID: 1024, ServiceType: 1, ServiceName: "ＮＨＫ総合１・東京", NetworkName: "地上デジタル", RemoteControlKeyID: 1},
+		{ONID: 32736, TSID: 32736, SID: 1025, ServiceType: 1, ServiceName: "ＮＨＫ総合２・東京", NetworkName: "地上デジタル", RemoteControlKeyID: 1},
+		{ONID: 32737, TSID: 32737, SID: 1032, ServiceType: 1, ServiceName: "ＮＨＫＥテレ１東京", NetworkName: "地上デジタル", RemoteControlKeyID: 2},
+		{ONID: 32737, TSID: 32737, SID: 1040, ServiceType: 192, ServiceName: "ＮＨＫデータ", NetworkName: "地上デジタル", RemoteControlKeyID: 2},
+		{ONID: 4, TSID: 16625, SID: 211, ServiceType: 1, ServiceName: "BS11イレブン", NetworkName: "BS Digital"},
+		{ONID: 4, TSID: 16400, SID: 151, ServiceType: 1, ServiceName: "BS朝日1", NetworkName: "BS Digital"},
+		{ONID: 4, TSID: 16400, SID: 152, ServiceType: 1, ServiceName: "BS朝日2", NetworkName: "BS Digital"},
+	}
+}
+
+// TestChannelResolver tests resolving IDs, key numbers and names
+func TestChannelResolver(t *testing.T) {
+	resolver := &models.ChannelResolver{Channels: resolverTestChannels()}
+
+	tests := []struct {
+		ref      string
+		expected string
+	}{
+		{"32736-32736-1024", "32736-32736-1024"},
+		{"1-2-3", "1-2-3"}, // Channel IDs are not looked up
+		{"key:2", "32737-32737-1032"},
+		{"KEY:2", "32737-32737-1032"},
+		{"NHK総合1", "32736-32736-1024"},
+		{"ｎｈｋ総合２", "32736-32736-1025"},
+		{"nhk総合 1・東京", "32736-32736-1024"},
+		{"Eテレ", "32737-32737-1032"},
+		{"bs11", "4-16625-211"},
+		{"BS朝日1", "4-16400-151"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			id, err := resolver.Resolve(tt.ref)
+			if err != nil {
+				t.Fatalf("Resolve(%q) failed: %v", tt.ref, err)
+			}
+			if id != tt.expected {
+				t.Errorf("Resolve(%q) = %s, expected %s", tt.ref, id, tt.expected)
+			}
+		})
+	}
+}
+
+// TestChannelResolver_Errors tests unknown, invalid and ambiguous references
+func TestChannelResolver_Errors(t *testing.T) {
+	resolver := &models.ChannelResolver{Channels: resolverTestChannels()}
+
+	tests := []struct {
+		ref           string
+		errorContains []string
+	}{
+		{"key:1", []string{"ambiguous", "32736-32736-1024", "32736-32736-1025"}},
+		{"NHK総合", []string{"ambiguous", "ＮＨＫ総合１・東京", "ＮＨＫ総合２・東京"}},
+		{"BS朝日", []string{"ambiguous", "4-16400-151", "4-16400-152"}},
+		{"key:9", []string{"no channel matches 'key:9'"}},
+		{"key:x", []string{"positive number"}},
+		{"テレ東", []string{"no channel matches"}},
+		{" ", []string{"invalid channel"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			_, err := resolver.Resolve(tt.ref)
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+			for _, s := range tt.errorContains {
+				if !strings.Contains(err.Error(), s) {
+					t.Errorf("Expected error containing '%s', got: %v", s, err)
+				}
+			}
+		})
+	}
+}
+
+// TestChannelResolver_ResolveAll tests resolving a channel list
+func TestChannelResolver_ResolveAll(t *testing.T) {
+	resolver := &models.ChannelResolver{Channels: resolverTestChannels()}
+
+	ids, err := resolver.ResolveAll([]string{"key:2", "32736-32736-1024", "BS11"})
+	if err != nil {
+		t.Fatalf("ResolveAll() failed: %v", err)
+	}
+	if strings.Join(ids, ",") != "32737-32737-1032,32736-32736-1024,4-16625-211" {
+		t.Errorf("Unexpected IDs: %v", ids)
+	}
+
+	if _, err := resolver.ResolveAll([]string{"key:2", "unknown"}); err == nil {
+		t.Error("Expected error for unknown channel, got nil")
+	}
+}
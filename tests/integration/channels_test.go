@@ -1,6 +1,7 @@
 package integration
 
 import (
+	"context"
 	"strings"
 	"testing"
 
@@ -18,7 +19,7 @@ func TestEnumService_Success(t *testing.T) {
 	apiClient := client.NewClient(mock.URL())
 
 	// Call EnumService
-	response, err := apiClient.EnumService()
+	response, err := apiClient.EnumService(context.Background())
 	if err != nil {
 		t.Fatalf("EnumService() failed: %v", err)
 	}
@@ -101,7 +102,7 @@ func TestEnumService_Empty(t *testing.T) {
 	apiClient := client.NewClient(mock.URL())
 
 	// Call EnumService
-	response, err := apiClient.EnumService()
+	response, err := apiClient.EnumService(context.Background())
 	if err != nil {
 		t.Fatalf("EnumService() failed: %v", err)
 	}
@@ -126,7 +127,7 @@ func TestEnumService_ConnectionError(t *testing.T) {
 	apiClient := client.NewClient("http://localhost:99999")
 
 	// Call EnumService (should fail)
-	_, err := apiClient.EnumService()
+	_, err := apiClient.EnumService(context.Background())
 	if err == nil {
 		t.Fatal("Expected connection error, got nil")
 	}
@@ -151,7 +152,7 @@ func TestEnumService_MalformedXML(t *testing.T) {
 	apiClient := client.NewClient(mock.URL())
 
 	// Call EnumService (should fail to parse)
-	_, err := apiClient.EnumService()
+	_, err := apiClient.EnumService(context.Background())
 	if err == nil {
 		t.Fatal("Expected XML parse error, got nil")
 	}
@@ -173,7 +174,7 @@ func TestEnumService_JapaneseEncoding(t *testing.T) {
 	apiClient := client.NewClient(mock.URL())
 
 	// Call EnumService
-	response, err := apiClient.EnumService()
+	response, err := apiClient.EnumService(context.Background())
 	if err != nil {
 		t.Fatalf("EnumService() failed: %v", err)
 	}
@@ -203,7 +204,7 @@ func TestChannelInfo_ChannelID(t *testing.T) {
 	apiClient := client.NewClient(mock.URL())
 
 	// Call EnumService
-	response, err := apiClient.EnumService()
+	response, err := apiClient.EnumService(context.Background())
 	if err != nil {
 		t.Fatalf("EnumService() failed: %v", err)
 	}
@@ -0,0 +1,77 @@
+package integration
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/epy0n0ff/epgtimer-cli/tests/testdata"
+)
+
+var (
+	cliOnce sync.Once
+	cliDir  string
+	cliPath string
+	cliErr  error
+)
+
+// TestMain removes the epgtimer binary built for the command tests
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if cliDir != "" {
+		os.RemoveAll(cliDir)
+	}
+	os.Exit(code)
+}
+
+// buildCLI builds the epgtimer binary once for all command tests
+func buildCLI(t *testing.T) string {
+	t.Helper()
+
+	cliOnce.Do(func() {
+		cliDir, cliErr = os.MkdirTemp("", "epgtimer-cli-test")
+		if cliErr != nil {
+			return
+		}
+		cliPath = filepath.Join(cliDir, "epgtimer")
+		out, err := exec.Command("go", "build", "-o", cliPath, "github.com/epy0n0ff/epgtimer-cli/cmd/epgtimer").CombinedOutput()
+		if err != nil {
+			cliErr = fmt.Errorf("%w\n%s", err, out)
+		}
+	})
+
+	if cliErr != nil {
+		t.Fatalf("Failed to build epgtimer: %v", cliErr)
+	}
+	return cliPath
+}
+
+// runCLI runs epgtimer against the mock server with an empty config and cache
+// and returns its combined stdout and stderr
+func runCLI(t *testing.T, mock *testdata.MockEMWUIServer, stdin string, args ...string) (string, error) {
+	t.Helper()
+
+	cmd := exec.Command(buildCLI(t), args...)
+	cmd.Dir = t.TempDir()
+	cmd.Env = append(os.Environ(),
+		"EMWUI_ENDPOINT="+mock.URL(),
+		"EMWUI_USER=",
+		"EMWUI_PASSWORD=",
+		"EPGTIMER_PROFILE=",
+		"EPGTIMER_CONFIG=",
+		"XDG_CONFIG_HOME="+t.TempDir(),
+		"EPGTIMER_CACHE_DIR="+t.TempDir(),
+	)
+	cmd.Stdin = strings.NewReader(stdin)
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err := cmd.Run()
+	return out.String(), err
+}
@@ -0,0 +1,132 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/client"
+	"github.com/epy0n0ff/epgtimer-cli/tests/testdata"
+)
+
+// newSlowServer creates a server that responds only after delay (or when the request is cancelled)
+func newSlowServer(delay time.Duration) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+		}
+	}))
+}
+
+// TestNewClient_DefaultTimeout tests that the default timeout is applied
+func TestNewClient_DefaultTimeout(t *testing.T) {
+	c := client.NewClient("http://localhost:5510")
+
+	if c.HTTPClient.Timeout != client.DefaultTimeout {
+		t.Errorf("Expected timeout %v, got %v", client.DefaultTimeout, c.HTTPClient.Timeout)
+	}
+}
+
+// TestNewClient_Options tests that options configure the client
+func TestNewClient_Options(t *testing.T) {
+	c := client.NewClient("http://localhost:5510", client.WithTimeout(3*time.Second))
+	if c.HTTPClient.Timeout != 3*time.Second {
+		t.Errorf("Expected timeout 3s, got %v", c.HTTPClient.Timeout)
+	}
+
+	httpClient := &http.Client{}
+	c = client.NewClient("http://localhost:5510", client.WithHTTPClient(httpClient), client.WithTimeout(time.Second))
+	if c.HTTPClient != httpClient {
+		t.Error("Expected the given HTTP client to be used")
+	}
+	if httpClient.Timeout != time.Second {
+		t.Errorf("Expected timeout 1s on the given HTTP client, got %v", httpClient.Timeout)
+	}
+}
+
+// TestClient_ContextCancel tests that cancelling the context aborts an in-flight request
+func TestClient_ContextCancel(t *testing.T) {
+	server := newSlowServer(5 * time.Second)
+	defer server.Close()
+
+	apiClient := client.NewClient(server.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := apiClient.EnumAutoAdd(ctx)
+	if err == nil {
+		t.Fatal("Expected error after cancel, got nil")
+	}
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected request to be aborted promptly, took %v", elapsed)
+	}
+}
+
+// TestClient_ContextDeadline tests that a context deadline aborts a write request
+func TestClient_ContextDeadline(t *testing.T) {
+	server := newSlowServer(5 * time.Second)
+	defer server.Close()
+
+	apiClient := client.NewClient(server.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := apiClient.DeleteAutoAdd(ctx, 334)
+	if err == nil {
+		t.Fatal("Expected error after deadline, got nil")
+	}
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got: %v", err)
+	}
+}
+
+// TestClient_Timeout tests the per-request timeout configured with WithTimeout
+func TestClient_Timeout(t *testing.T) {
+	server := newSlowServer(5 * time.Second)
+	defer server.Close()
+
+	apiClient := client.NewClient(server.URL, client.WithTimeout(50*time.Millisecond))
+
+	_, err := apiClient.EnumService(context.Background())
+	if err == nil {
+		t.Fatal("Expected timeout error, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "Timeout") {
+		t.Errorf("Expected timeout error, got: %v", err)
+	}
+}
+
+// TestClient_StatusError tests that non-200 responses include the status and body
+func TestClient_StatusError(t *testing.T) {
+	mock := testdata.NewMockEMWUIServer()
+	mock.SetEnumServiceHandler(func() (string, int) {
+		return "Service Unavailable", http.StatusServiceUnavailable
+	})
+	defer mock.Close()
+
+	apiClient := client.NewClient(mock.URL())
+
+	_, err := apiClient.EnumService(context.Background())
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "503") || !strings.Contains(err.Error(), "Service Unavailable") {
+		t.Errorf("Expected status and body in error, got: %v", err)
+	}
+}
@@ -0,0 +1,180 @@
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+	"github.com/epy0n0ff/epgtimer-cli/tests/testdata"
+)
+
+// writeConfig writes a config file to a temporary directory
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// TestLoadConfig tests reading profiles from a config file
+func TestLoadConfig(t *testing.T) {
+	path := writeConfig(t, `defaultProfile: living-room
+profiles:
+  living-room:
+    endpoint: http://192.168.1.10:5510
+    format: json
+    serviceList: [32736-32736-1024, 32736-32736-1025]
+  parents:
+    endpoint: https://parents.example.net:5510
+    user: edcb
+    password: secret
+    caCert: certs/ca.pem
+    insecureSkipVerify: false
+    timeout: 30s
+    retries: 0
+    retryWait: 500ms
+`)
+
+	config, err := models.LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() failed: %v", err)
+	}
+
+	if names := config.ProfileNames(); strings.Join(names, ",") != "living-room,parents" {
+		t.Errorf("Expected profiles living-room,parents, got %v", names)
+	}
+
+	// Empty name selects the default profile
+	profile, err := config.Profile("")
+	if err != nil {
+		t.Fatalf("Profile(\"\") failed: %v", err)
+	}
+	if profile.Endpoint != "http://192.168.1.10:5510" || profile.Format != "json" || len(profile.ServiceList) != 2 {
+		t.Errorf("Unexpected default profile: %+v", profile)
+	}
+	if profile.Timeout != nil || profile.Retries != nil {
+		t.Errorf("Expected unset timeout and retries, got %v %v", profile.Timeout, profile.Retries)
+	}
+
+	profile, err = config.Profile("parents")
+	if err != nil {
+		t.Fatalf("Profile(\"parents\") failed: %v", err)
+	}
+	if profile.User != "edcb" || profile.Password != "secret" {
+		t.Errorf("Unexpected credentials: %s / %s", profile.User, profile.Password)
+	}
+	if profile.Timeout == nil || *profile.Timeout != 30*time.Second {
+		t.Errorf("Expected timeout 30s, got %v", profile.Timeout)
+	}
+	if profile.Retries == nil || *profile.Retries != 0 {
+		t.Errorf("Expected retries 0, got %v", profile.Retries)
+	}
+	if profile.RetryWait == nil || *profile.RetryWait != 500*time.Millisecond {
+		t.Errorf("Expected retryWait 500ms, got %v", profile.RetryWait)
+	}
+	if profile.InsecureSkipVerify == nil || *profile.InsecureSkipVerify {
+		t.Errorf("Expected insecureSkipVerify false, got %v", profile.InsecureSkipVerify)
+	}
+	if expected := filepath.Join(filepath.Dir(path), "certs", "ca.pem"); profile.CACert != expected {
+		t.Errorf("Expected caCert %s, got %s", expected, profile.CACert)
+	}
+
+	// Unknown profile
+	if _, err := config.Profile("office"); err == nil || !strings.Contains(err.Error(), "living-room, parents") {
+		t.Errorf("Expected error listing available profiles, got: %v", err)
+	}
+}
+
+// TestLoadConfig_NoDefault tests that no profile is selected without defaultProfile
+func TestLoadConfig_NoDefault(t *testing.T) {
+	config, err := models.LoadConfig(writeConfig(t, "profiles:\n  home:\n    endpoint: http://localhost:5510\n"))
+	if err != nil {
+		t.Fatalf("LoadConfig() failed: %v", err)
+	}
+
+	profile, err := config.Profile("")
+	if err != nil || profile != nil {
+		t.Errorf("Expected no profile, got %+v, %v", profile, err)
+	}
+}
+
+// TestLoadConfig_Invalid tests config validation errors
+func TestLoadConfig_Invalid(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		errorContains string
+	}{
+		{
+			name:          "Unknown field",
+			content:       "profiles:\n  home:\n    endpiont: http://localhost:5510\n",
+			errorContains: "endpiont",
+		},
+		{
+			name:          "Undefined default profile",
+			content:       "defaultProfile: office\nprofiles:\n  home:\n    endpoint: http://localhost:5510\n",
+			errorContains: "office",
+		},
+		{
+			name:          "Invalid channel",
+			content:       "profiles:\n  home:\n    serviceList: [NHK]\n",
+			errorContains: "serviceList[0]",
+		},
+		{
+			name:          "Invalid timeout",
+			content:       "profiles:\n  home:\n    timeout: soon\n",
+			errorContains: "timeout",
+		},
+		{
+			name:          "Negative retries",
+			content:       "profiles:\n  home:\n    retries: -1\n",
+			errorContains: "retries",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := models.LoadConfig(writeConfig(t, tt.content))
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("Expected error containing '%s', got: %v", tt.errorContains, err)
+			}
+		})
+	}
+}
+
+// TestProfilePrecedence tests that a profile selected with --profile wins over EMWUI_ENDPOINT,
+// while the defaultProfile does not
+func TestProfilePrecedence(t *testing.T) {
+	// The EMWUI_ENDPOINT server has no rules
+	envServer := testdata.NewEmptyEnumAutoAddServer()
+	defer envServer.Close()
+	profileServer := testdata.NewMockEMWUIServer()
+	defer profileServer.Close()
+
+	config := writeConfig(t, "defaultProfile: parents\nprofiles:\n  parents:\n    endpoint: "+profileServer.URL()+"\n")
+
+	out, err := runCLI(t, envServer, "", "--config", config, "--profile", "parents", "list")
+	if err != nil {
+		t.Fatalf("list --profile parents failed: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "ブラタモリ") {
+		t.Errorf("Expected the rules of the profile's server, got:\n%s", out)
+	}
+
+	out, err = runCLI(t, envServer, "", "--config", config, "list")
+	if err != nil {
+		t.Fatalf("list failed: %v\n%s", err, out)
+	}
+	if strings.Contains(out, "ブラタモリ") {
+		t.Errorf("Expected EMWUI_ENDPOINT to override the defaultProfile, got:\n%s", out)
+	}
+}
@@ -0,0 +1,201 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/client"
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+	"github.com/epy0n0ff/epgtimer-cli/tests/testdata"
+)
+
+// newConflictReservation creates a reservation for conflict tests
+func newConflictReservation(id int, start string, minutes int, tsid int, priority int) models.ReservationInfo {
+	return models.ReservationInfo{
+		ID:             id,
+		Title:          "Program",
+		StartDate:      "2025/12/22",
+		StartTime:      start,
+		DurationSecond: minutes * 60,
+		ONID:           4,
+		TSID:           tsid,
+		SID:            tsid,
+		RecSetting:     models.RecSetting{Priority: priority},
+	}
+}
+
+// TestFindConflicts_Groups tests grouping of overlapping reservations
+func TestFindConflicts_Groups(t *testing.T) {
+	reservations := []models.ReservationInfo{
+		newConflictReservation(1, "21:00:00", 60, 16625, 2),
+		newConflictReservation(2, "21:30:00", 60, 16626, 2),
+		newConflictReservation(3, "22:15:00", 30, 16627, 2),
+		// Back-to-back with the group above, not overlapping
+		newConflictReservation(4, "23:00:00", 30, 16625, 2),
+	}
+
+	groups, err := models.FindConflicts(reservations, models.ConflictOptions{})
+	if err != nil {
+		t.Fatalf("FindConflicts() failed: %v", err)
+	}
+
+	if len(groups) != 1 {
+		t.Fatalf("Expected 1 overlap window, got %d", len(groups))
+	}
+
+	group := groups[0]
+	if len(group.Reservations) != 3 {
+		t.Errorf("Expected 3 reservations in the window, got %d", len(group.Reservations))
+	}
+	if group.TunersNeeded != 2 {
+		t.Errorf("Expected 2 tuners needed, got %d", group.TunersNeeded)
+	}
+	if got := group.End.Format("15:04"); got != "22:45" {
+		t.Errorf("Expected window to end at 22:45, got %s", got)
+	}
+}
+
+// TestFindConflicts_Margins tests that margins extend the recording window
+func TestFindConflicts_Margins(t *testing.T) {
+	reservations := []models.ReservationInfo{
+		newConflictReservation(1, "21:00:00", 60, 16625, 2),
+		newConflictReservation(2, "22:00:00", 60, 16626, 2),
+	}
+
+	groups, err := models.FindConflicts(reservations, models.ConflictOptions{})
+	if err != nil {
+		t.Fatalf("FindConflicts() failed: %v", err)
+	}
+	if len(groups) != 0 {
+		t.Errorf("Expected back-to-back reservations not to overlap, got %d windows", len(groups))
+	}
+
+	// Default margins make them overlap
+	groups, err = models.FindConflicts(reservations, models.ConflictOptions{DefaultStartMargin: 5, DefaultEndMargin: 5})
+	if err != nil {
+		t.Fatalf("FindConflicts() failed: %v", err)
+	}
+	if len(groups) != 1 {
+		t.Errorf("Expected default margins to create an overlap, got %d windows", len(groups))
+	}
+
+	// Custom margins take precedence over the default margins
+	reservations[0].RecSetting.EndMargin = -10
+	reservations[1].RecSetting.StartMargin = -10
+	groups, err = models.FindConflicts(reservations, models.ConflictOptions{DefaultStartMargin: 5, DefaultEndMargin: 5})
+	if err != nil {
+		t.Fatalf("FindConflicts() failed: %v", err)
+	}
+	if len(groups) != 0 {
+		t.Errorf("Expected custom negative margins to remove the overlap, got %d windows", len(groups))
+	}
+}
+
+// TestFindConflicts_SimulateTuners tests the simulated tuner allocation
+func TestFindConflicts_SimulateTuners(t *testing.T) {
+	reservations := []models.ReservationInfo{
+		newConflictReservation(1, "21:00:00", 60, 16625, 2),
+		newConflictReservation(2, "21:00:00", 60, 16626, 5),
+		newConflictReservation(3, "21:30:00", 60, 16627, 3),
+		// Same transport stream as reservation 2, shares its tuner
+		newConflictReservation(4, "21:00:00", 45, 16626, 1),
+	}
+	reservations[3].SID = 1
+
+	groups, err := models.FindConflicts(reservations, models.ConflictOptions{Tuners: 2})
+	if err != nil {
+		t.Fatalf("FindConflicts() failed: %v", err)
+	}
+	if len(groups) != 1 {
+		t.Fatalf("Expected 1 overlap window, got %d", len(groups))
+	}
+
+	status := make(map[int]string)
+	for _, entry := range groups[0].Reservations {
+		status[entry.ID] = entry.Status
+	}
+
+	// Priority 5 and 3 get the two tuners; priority 2 loses,
+	// while priority 1 rides along on the tuner of reservation 2
+	expected := map[int]string{
+		1: models.ConflictStatusFail,
+		2: models.ConflictStatusOK,
+		3: models.ConflictStatusOK,
+		4: models.ConflictStatusOK,
+	}
+	for id, want := range expected {
+		if status[id] != want {
+			t.Errorf("Reservation %d: expected status %s, got %s", id, want, status[id])
+		}
+	}
+
+	if groups[0].Failing != 1 {
+		t.Errorf("Expected 1 failing reservation, got %d", groups[0].Failing)
+	}
+	if groups[0].TunersNeeded != 3 {
+		t.Errorf("Expected 3 tuners needed, got %d", groups[0].TunersNeeded)
+	}
+}
+
+// TestFindConflicts_IgnoresDisabled tests that disabled reservations never conflict
+func TestFindConflicts_IgnoresDisabled(t *testing.T) {
+	reservations := []models.ReservationInfo{
+		newConflictReservation(1, "21:00:00", 60, 16625, 2),
+		newConflictReservation(2, "21:00:00", 60, 16626, 2),
+	}
+	reservations[1].RecSetting.RecMode = 6
+
+	groups, err := models.FindConflicts(reservations, models.ConflictOptions{})
+	if err != nil {
+		t.Fatalf("FindConflicts() failed: %v", err)
+	}
+	if len(groups) != 0 {
+		t.Errorf("Expected no overlap windows, got %d", len(groups))
+	}
+}
+
+// TestFindConflicts_ServerStatus tests that overlapMode from EnumReserveInfo is reported
+func TestFindConflicts_ServerStatus(t *testing.T) {
+	// Create mock server
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	mock.SetEnumReserveInfoHandler(func() (string, int) {
+		return `<?xml version="1.0" encoding="UTF-8" ?>
+<entry><total>2</total><index>0</index><count>2</count><items>
+<reserveinfo><ID>1001</ID><title>ニュース</title><startDate>2025/12/22</startDate><startTime>21:00:00</startTime><durationSecond>3600</durationSecond><ONID>4</ONID><TSID>16625</TSID><SID>101</SID><overlapMode>0</overlapMode><recSetting><priority>3</priority></recSetting></reserveinfo>
+<reserveinfo><ID>1002</ID><title>ドラマ</title><startDate>2025/12/22</startDate><startTime>21:30:00</startTime><durationSecond>3600</durationSecond><ONID>4</ONID><TSID>16626</TSID><SID>102</SID><overlapMode>2</overlapMode><recSetting><priority>2</priority><tunerID>65537</tunerID></recSetting></reserveinfo>
+</items></entry>`, 200
+	})
+
+	// Create client
+	apiClient := client.NewClient(mock.URL())
+
+	response, err := apiClient.EnumReserveInfo(context.Background())
+	if err != nil {
+		t.Fatalf("EnumReserveInfo() failed: %v", err)
+	}
+
+	groups, err := models.FindConflicts(response.Items, models.ConflictOptions{})
+	if err != nil {
+		t.Fatalf("FindConflicts() failed: %v", err)
+	}
+	if len(groups) != 1 || len(groups[0].Reservations) != 2 {
+		t.Fatalf("Expected 1 overlap window with 2 reservations, got %+v", groups)
+	}
+
+	entries := groups[0].Reservations
+	if entries[0].Status != models.ConflictStatusOK {
+		t.Errorf("Expected reservation 1001 to be ok, got %s", entries[0].Status)
+	}
+	if entries[1].Status != models.ConflictStatusFail {
+		t.Errorf("Expected reservation 1002 to fail, got %s", entries[1].Status)
+	}
+	if entries[1].TunerID != 65537 {
+		t.Errorf("Expected tuner 65537 for reservation 1002, got %d", entries[1].TunerID)
+	}
+	if want := time.Date(2025, 12, 22, 22, 30, 0, 0, time.Local); !groups[0].End.Equal(want) {
+		t.Errorf("Expected window to end at %v, got %v", want, groups[0].End)
+	}
+}
@@ -1,10 +1,13 @@
 package integration
 
 import (
+	"context"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/epy0n0ff/epgtimer-cli/internal/client"
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
 	"github.com/epy0n0ff/epgtimer-cli/tests/testdata"
 )
 
@@ -18,7 +21,7 @@ func TestDeleteAutoAdd_Success(t *testing.T) {
 	apiClient := client.NewClient(mock.URL())
 
 	// Call DeleteAutoAdd with valid ID
-	response, err := apiClient.DeleteAutoAdd(334)
+	response, err := apiClient.DeleteAutoAdd(context.Background(), 334)
 	if err != nil {
 		t.Fatalf("DeleteAutoAdd() failed: %v", err)
 	}
@@ -44,7 +47,7 @@ func TestDeleteAutoAdd_InvalidID(t *testing.T) {
 	apiClient := client.NewClient(mock.URL())
 
 	// Call DeleteAutoAdd with invalid ID (0)
-	_, err := apiClient.DeleteAutoAdd(0)
+	_, err := apiClient.DeleteAutoAdd(context.Background(), 0)
 	if err == nil {
 		t.Fatal("Expected error for invalid ID, got nil")
 	}
@@ -66,7 +69,7 @@ func TestDeleteAutoAdd_NegativeID(t *testing.T) {
 	apiClient := client.NewClient(mock.URL())
 
 	// Call DeleteAutoAdd with negative ID
-	_, err := apiClient.DeleteAutoAdd(-1)
+	_, err := apiClient.DeleteAutoAdd(context.Background(), -1)
 	if err == nil {
 		t.Fatal("Expected error for negative ID, got nil")
 	}
@@ -91,7 +94,7 @@ func TestDeleteAutoAdd_ServerError(t *testing.T) {
 	apiClient := client.NewClient(mock.URL())
 
 	// Call DeleteAutoAdd
-	_, err := apiClient.DeleteAutoAdd(999)
+	_, err := apiClient.DeleteAutoAdd(context.Background(), 999)
 	if err == nil {
 		t.Fatal("Expected error from server, got nil")
 	}
@@ -109,7 +112,7 @@ func TestDeleteAutoAdd_ConnectionError(t *testing.T) {
 	apiClient := client.NewClient("http://localhost:99999")
 
 	// Call DeleteAutoAdd (should fail)
-	_, err := apiClient.DeleteAutoAdd(334)
+	_, err := apiClient.DeleteAutoAdd(context.Background(), 334)
 	if err == nil {
 		t.Fatal("Expected connection error, got nil")
 	}
@@ -177,7 +180,7 @@ func TestDeleteAutoAdd_ValidIDs(t *testing.T) {
 			apiClient := client.NewClient(mock.URL())
 
 			// Call DeleteAutoAdd
-			_, err := apiClient.DeleteAutoAdd(tc.id)
+			_, err := apiClient.DeleteAutoAdd(context.Background(), tc.id)
 			if err != nil {
 				t.Fatalf("DeleteAutoAdd(%d) failed: %v", tc.id, err)
 			}
@@ -189,3 +192,110 @@ func TestDeleteAutoAdd_ValidIDs(t *testing.T) {
 		})
 	}
 }
+
+// TestDeleteAutoAdd_Backup tests that the backup written before a bulk delete can be imported again
+func TestDeleteAutoAdd_Backup(t *testing.T) {
+	// Create mock server
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	var deleted []int
+	mock.SetDeleteAutoAddHandler(func(id int) (bool, string) {
+		deleted = append(deleted, id)
+		return true, "Deleted successfully"
+	})
+
+	apiClient := client.NewClient(mock.URL())
+	response, err := apiClient.EnumAutoAdd(context.Background())
+	if err != nil {
+		t.Fatalf("EnumAutoAdd() failed: %v", err)
+	}
+
+	// Select the rules like 'delete 1-2 --andKey タモリ'
+	filter := models.FilterOptions{AndKeyFilter: "タモリ"}
+	var selected, remaining []models.AutoAddRule
+	for _, rule := range response.Items {
+		if rule.ID <= 2 && filter.Matches(&rule) {
+			selected = append(selected, rule)
+		} else {
+			remaining = append(remaining, rule)
+		}
+	}
+	if len(selected) != 1 {
+		t.Fatalf("Expected 1 selected rule, got %d", len(selected))
+	}
+
+	path := filepath.Join(t.TempDir(), "deleted-rules.json")
+	if err := models.WriteAutoAddRuleBackup(path, selected); err != nil {
+		t.Fatalf("WriteAutoAddRuleBackup() failed: %v", err)
+	}
+	for _, rule := range selected {
+		if _, err := apiClient.DeleteAutoAdd(context.Background(), rule.ID); err != nil {
+			t.Fatalf("DeleteAutoAdd(%d) failed: %v", rule.ID, err)
+		}
+	}
+	if len(deleted) != 1 || deleted[0] != 2 {
+		t.Errorf("Expected rule 2 to be deleted, got %v", deleted)
+	}
+
+	backup, err := models.LoadAutoAddRuleBackup(path)
+	if err != nil {
+		t.Fatalf("LoadAutoAddRuleBackup() failed: %v", err)
+	}
+	if len(backup) != 1 || backup[0].ID != 2 || backup[0].SearchSettings.AndKey != "ブラタモリ" {
+		t.Fatalf("Unexpected backup: %+v", backup)
+	}
+	if got := backup[0].RecordingSettings.RecFolderList.String(); got != `D:\Recorded\Tamori` {
+		t.Errorf("Expected the recording folder to be backed up, got %s", got)
+	}
+
+	// Restoring the backup re-creates the deleted rule
+	plan := models.PlanImport(backup, remaining)
+	if len(plan.Creates) != 1 || len(plan.Skipped) != 0 {
+		t.Errorf("Expected 1 rule to restore, got %d creates and %d skipped", len(plan.Creates), len(plan.Skipped))
+	}
+}
+
+// TestDeleteCommand_GappedRange tests that an ID range selects the existing rules within it,
+// while an ID given singly must exist
+func TestDeleteCommand_GappedRange(t *testing.T) {
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	var deleted []int
+	mock.SetDeleteAutoAddHandler(func(id int) (bool, string) {
+		deleted = append(deleted, id)
+		return true, "Deleted successfully"
+	})
+
+	// Rules 1-3 exist; 4 and 5 do not
+	out, err := runCLI(t, mock, "", "delete", "2-5", "--dry-run")
+	if err != nil {
+		t.Fatalf("delete 2-5 --dry-run failed: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "ブラタモリ") || !strings.Contains(out, "^NHKニュース") || strings.Contains(out, "サイエンスZERO") {
+		t.Errorf("Expected rules 2 and 3 to be listed, got:\n%s", out)
+	}
+
+	backup := filepath.Join(t.TempDir(), "backup.json")
+	out, err = runCLI(t, mock, "", "delete", "1", "3-5", "--yes", "--backup-file", backup)
+	if err != nil {
+		t.Fatalf("delete 1 3-5 failed: %v\n%s", err, out)
+	}
+	if len(deleted) != 2 || deleted[0] != 1 || deleted[1] != 3 {
+		t.Errorf("Expected rules 1 and 3 to be deleted, got %v", deleted)
+	}
+	if rules, err := models.LoadAutoAddRuleBackup(backup); err != nil || len(rules) != 2 {
+		t.Errorf("Expected a backup of 2 rules, got %d rules, %v", len(rules), err)
+	}
+
+	// A single ID that does not exist is an error
+	deleted = nil
+	out, err = runCLI(t, mock, "", "delete", "2", "4", "--yes", "--backup-file", backup)
+	if err == nil || !strings.Contains(out, "rule ID 4 not found") {
+		t.Errorf("Expected 'rule ID 4 not found', got %v:\n%s", err, out)
+	}
+	if len(deleted) != 0 {
+		t.Errorf("Expected nothing to be deleted, got %v", deleted)
+	}
+}
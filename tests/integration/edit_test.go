@@ -0,0 +1,315 @@
+package integration
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/client"
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+	"github.com/epy0n0ff/epgtimer-cli/tests/testdata"
+)
+
+// TestGetAutoAdd_Success tests retrieving a single rule by ID
+func TestGetAutoAdd_Success(t *testing.T) {
+	// Create mock server
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	// Create client
+	apiClient := client.NewClient(mock.URL())
+
+	rule, err := apiClient.GetAutoAdd(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("GetAutoAdd() failed: %v", err)
+	}
+
+	if rule.ID != 2 {
+		t.Errorf("Expected ID=2, got %d", rule.ID)
+	}
+
+	if rule.SearchSettings.AndKey != "ブラタモリ" {
+		t.Errorf("Expected AndKey='ブラタモリ', got '%s'", rule.SearchSettings.AndKey)
+	}
+}
+
+// TestGetAutoAdd_NotFound tests error when the rule ID does not exist
+func TestGetAutoAdd_NotFound(t *testing.T) {
+	// Create mock server
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	// Create client
+	apiClient := client.NewClient(mock.URL())
+
+	_, err := apiClient.GetAutoAdd(context.Background(), 999)
+	if err == nil {
+		t.Fatal("Expected error for unknown rule ID, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected error message to mention 'not found', got: %v", err)
+	}
+}
+
+// TestUpdateAutoAdd_Success tests that an update is posted to SetAutoAdd?id=N
+func TestUpdateAutoAdd_Success(t *testing.T) {
+	// Create mock server
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	var receivedID int
+	var receivedValues map[string][]string
+
+	mock.SetUpdateAutoAddHandler(func(id int, values map[string][]string) (bool, string) {
+		receivedID = id
+		receivedValues = values
+		return true, "EPG自動予約を変更しました"
+	})
+
+	// Create client
+	apiClient := client.NewClient(mock.URL())
+
+	rule, err := apiClient.GetAutoAdd(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetAutoAdd() failed: %v", err)
+	}
+
+	req := models.NewAutoAddRuleRequestFromRule(rule)
+	req.NotKey = "[再] 再放送"
+
+	resp, err := apiClient.UpdateAutoAdd(context.Background(), 1, req)
+	if err != nil {
+		t.Fatalf("UpdateAutoAdd() failed: %v", err)
+	}
+
+	if !resp.IsSuccess() {
+		t.Errorf("Expected success=true, got success=false")
+	}
+
+	if receivedID != 1 {
+		t.Errorf("Expected update for ID 1, got %d", receivedID)
+	}
+
+	if got := receivedValues["notKey"]; len(got) == 0 || got[0] != "[再] 再放送" {
+		t.Errorf("Expected notKey '[再] 再放送', got %v", got)
+	}
+
+	// Unchanged settings must be preserved
+	if got := receivedValues["andKey"]; len(got) == 0 || got[0] != "サイエンスZERO" {
+		t.Errorf("Expected andKey to be preserved, got %v", got)
+	}
+
+	var channels []string
+	for _, ch := range receivedValues["serviceList"] {
+		if ch != "" {
+			channels = append(channels, ch)
+		}
+	}
+	if len(channels) != 2 {
+		t.Errorf("Expected 2 channels to be preserved, got %v", channels)
+	}
+}
+
+// TestUpdateAutoAdd_InvalidID tests update with invalid ID
+func TestUpdateAutoAdd_InvalidID(t *testing.T) {
+	// Create mock server
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	// Create client
+	apiClient := client.NewClient(mock.URL())
+
+	req := models.NewAutoAddRuleRequest("ニュース", "", []string{"32736-32736-1024"})
+
+	_, err := apiClient.UpdateAutoAdd(context.Background(), 0, req)
+	if err == nil {
+		t.Fatal("Expected error for invalid ID, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "invalid rule ID") {
+		t.Errorf("Error message should mention invalid ID, got: %v", err)
+	}
+}
+
+// TestNewAutoAddRuleRequestFromRule tests that rule settings survive the conversion
+func TestNewAutoAddRuleRequestFromRule(t *testing.T) {
+	rule := &models.AutoAddRule{
+		ID: 3,
+		SearchSettings: models.SearchSettings{
+			DisableFlag:    1,
+			AndKey:         "^NHKニュース",
+			RegExpFlag:     1,
+			TitleOnlyFlag:  1,
+			ChkDurationMin: 10,
+			DateList: []models.DateInfo{
+				{StartDayOfWeek: 1, StartHour: 21, StartMin: 0, EndDayOfWeek: 1, EndHour: 23, EndMin: 30},
+			},
+			ServiceList: []models.ServiceInfo{{ONID: 32736, TSID: 32736, SID: 1024}},
+		},
+		RecordingSettings: models.RecordingSettings{
+			Priority:       3,
+			UseMargineFlag: 1,
+			StartMargine:   30,
+			EndMargine:     60,
+		},
+	}
+
+	req := models.NewAutoAddRuleRequestFromRule(rule)
+
+	if req.DisableFlag != 1 || req.RegExpFlag != 1 || req.ChkDurationMin != 10 {
+		t.Errorf("Search flags not preserved: %+v", req)
+	}
+
+	if req.DateList != "月-21:00-月-23:30" {
+		t.Errorf("Expected dateList '月-21:00-月-23:30', got '%s'", req.DateList)
+	}
+
+	if req.Priority != 3 {
+		t.Errorf("Expected priority 3, got %d", req.Priority)
+	}
+
+	if req.UseDefMarginFlag != 0 || req.StartMargin != 30 || req.EndMargin != 60 {
+		t.Errorf("Custom margins not preserved: use_def=%d start=%d end=%d", req.UseDefMarginFlag, req.StartMargin, req.EndMargin)
+	}
+
+	formData := req.ToFormData()
+	for _, want := range []string{"disableFlag=1", "regExpFlag=1", "startMargin=30", "endMargin=60"} {
+		if !strings.Contains(formData, want) {
+			t.Errorf("Form data missing %s: %s", want, formData)
+		}
+	}
+
+	// A request built from the same rule has no differences
+	if changes := req.Diff(models.NewAutoAddRuleRequestFromRule(rule)); len(changes) != 0 {
+		t.Errorf("Expected no changes, got %v", changes)
+	}
+
+	edited := models.NewAutoAddRuleRequestFromRule(rule)
+	edited.DisableFlag = 0
+	changes := req.Diff(edited)
+	if len(changes) != 1 || !strings.HasPrefix(changes[0], "disabled:") {
+		t.Errorf("Expected a single 'disabled' change, got %v", changes)
+	}
+}
+
+// TestEditCommand_ChannelReferences tests that edit resolves channel names and key:N
+// given to --serviceList before validating the rule
+func TestEditCommand_ChannelReferences(t *testing.T) {
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	var received map[string][]string
+	mock.SetUpdateAutoAddHandler(func(id int, values map[string][]string) (bool, string) {
+		received = values
+		return true, "EPG自動予約を変更しました"
+	})
+
+	tests := []struct {
+		ref  string
+		want string
+	}{
+		{"key:1", "32736-32736-1024"},
+		{"BS朝日", "4-16400-151"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			received = nil
+			out, err := runCLI(t, mock, "", "edit", "2", "--serviceList", tt.ref)
+			if err != nil {
+				t.Fatalf("edit --serviceList %s failed: %v\n%s", tt.ref, err, out)
+			}
+
+			var channels []string
+			for _, ch := range received["serviceList"] {
+				if ch != "" {
+					channels = append(channels, ch)
+				}
+			}
+			if len(channels) != 1 || channels[0] != tt.want {
+				t.Errorf("Expected serviceList [%s], got %v", tt.want, channels)
+			}
+		})
+	}
+}
+
+// TestUpdateAutoAdd_KeepsGenreFilter tests that the genre filter of a rule is sent back
+// unchanged when the rule is edited or enabled
+func TestUpdateAutoAdd_KeepsGenreFilter(t *testing.T) {
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	var received map[string][]string
+	mock.SetUpdateAutoAddHandler(func(id int, values map[string][]string) (bool, string) {
+		received = values
+		return true, "EPG自動予約を変更しました"
+	})
+
+	rule, err := client.NewClient(mock.URL()).GetAutoAdd(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("GetAutoAdd() failed: %v", err)
+	}
+
+	wantContents := []models.ContentData{{ContentNibble: 0x00FF}, {ContentNibble: 0x0200}}
+	if !reflect.DeepEqual(rule.SearchSettings.ContentList, wantContents) {
+		t.Fatalf("Expected genre filter %+v, got %+v", wantContents, rule.SearchSettings.ContentList)
+	}
+
+	// The request reproduces the search settings of the rule
+	settings, err := models.NewAutoAddRuleRequestFromRule(rule).SearchSettings()
+	if err != nil {
+		t.Fatalf("SearchSettings() failed: %v", err)
+	}
+	if !reflect.DeepEqual(settings.ContentList, wantContents) {
+		t.Errorf("Expected genre filter %+v after round trip, got %+v", wantContents, settings.ContentList)
+	}
+
+	wantForm := []string{"255", "512"}
+	for _, args := range [][]string{
+		{"edit", "3", "--priority", "4"},
+		{"rules", "enable", "3", "--yes"},
+	} {
+		received = nil
+		out, err := runCLI(t, mock, "", args...)
+		if err != nil {
+			t.Fatalf("%s failed: %v\n%s", strings.Join(args, " "), err, out)
+		}
+		if got := received["contentList"]; !reflect.DeepEqual(got, wantForm) {
+			t.Errorf("%s: expected contentList=%v, got %v", strings.Join(args, " "), wantForm, got)
+		}
+	}
+}
+
+// TestEditCommand_KeepsRecordingSettings tests that an edited rule is posted with
+// presetID=65535 and its own recording settings, so no preset replaces them
+func TestEditCommand_KeepsRecordingSettings(t *testing.T) {
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	var received map[string][]string
+	mock.SetUpdateAutoAddHandler(func(id int, values map[string][]string) (bool, string) {
+		received = values
+		return true, "EPG自動予約を変更しました"
+	})
+
+	out, err := runCLI(t, mock, "", "edit", "2", "--notKey", "再放送")
+	if err != nil {
+		t.Fatalf("edit failed: %v\n%s", err, out)
+	}
+
+	checks := map[string]string{
+		"presetID":         "65535",
+		"notKey":           "再放送",
+		"recMode":          "1",
+		"priority":         "2",
+		"recFolder":        `D:\Recorded\Tamori`,
+		"partialrecFolder": `D:\Recorded\1seg`,
+	}
+	for name, want := range checks {
+		if got := received[name]; len(got) == 0 || got[0] != want {
+			t.Errorf("Expected %s=%s, got %v", name, want, got)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package integration
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/cache"
+	"github.com/epy0n0ff/epgtimer-cli/internal/client"
+	"github.com/epy0n0ff/epgtimer-cli/tests/testdata"
+)
+
+// TestEPGCache_StoreLoad tests caching the EPG of a channel
+func TestEPGCache_StoreLoad(t *testing.T) {
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	response, err := client.NewClient(mock.URL()).EnumEventInfo(context.Background(), 32736, 32736, 1024)
+	if err != nil {
+		t.Fatalf("EnumEventInfo() failed: %v", err)
+	}
+
+	dir := t.TempDir()
+	epgCache := cache.NewEPGCache(dir, 0)
+
+	// Not cached yet
+	entry, err := epgCache.LoadEPG("192.168.1.10:5510", "32736-32736-1024")
+	if err != nil || entry != nil {
+		t.Fatalf("Expected no entry, got %+v, %v", entry, err)
+	}
+
+	if err := epgCache.StoreEPG("192.168.1.10:5510", "32736-32736-1024", response.Items); err != nil {
+		t.Fatalf("StoreEPG() failed: %v", err)
+	}
+
+	// The ':' of host:port is not used in the directory name
+	if _, err := os.Stat(filepath.Join(dir, "192.168.1.10_5510", "epg", "32736-32736-1024.json")); err != nil {
+		t.Errorf("Expected cache file: %v", err)
+	}
+
+	entry, err = epgCache.LoadEPG("192.168.1.10:5510", "32736-32736-1024")
+	if err != nil || entry == nil {
+		t.Fatalf("LoadEPG() failed: %+v, %v", entry, err)
+	}
+	if len(entry.Events) != len(response.Items) {
+		t.Fatalf("Expected %d events, got %d", len(response.Items), len(entry.Events))
+	}
+	if entry.Events[0].EventName != response.Items[0].EventName || entry.Events[0].GenreString() != response.Items[0].GenreString() {
+		t.Errorf("Cached event differs: %+v", entry.Events[0])
+	}
+	if entry.Channel != "32736-32736-1024" {
+		t.Errorf("Expected channel 32736-32736-1024, got %s", entry.Channel)
+	}
+
+	// Other servers have their own cache
+	if entry, _ := epgCache.LoadEPG("192.168.1.20:5510", "32736-32736-1024"); entry != nil {
+		t.Error("Expected no entry for another server")
+	}
+}
+
+// TestEPGCache_Fresh tests the TTL
+func TestEPGCache_Fresh(t *testing.T) {
+	now := time.Date(2025, 12, 23, 6, 0, 0, 0, time.Local)
+	epgCache := cache.NewEPGCache(t.TempDir(), 30*time.Minute)
+	epgCache.Now = func() time.Time { return now }
+
+	if err := epgCache.StoreEPG("recorder:5510", "4-16400-151", nil); err != nil {
+		t.Fatalf("StoreEPG() failed: %v", err)
+	}
+	entry, err := epgCache.LoadEPG("recorder:5510", "4-16400-151")
+	if err != nil || entry == nil {
+		t.Fatalf("LoadEPG() failed: %+v, %v", entry, err)
+	}
+
+	if !epgCache.Fresh(entry.FetchedAt) {
+		t.Error("Expected a new entry to be fresh")
+	}
+
+	now = now.Add(29 * time.Minute)
+	if !epgCache.Fresh(entry.FetchedAt) {
+		t.Error("Expected the entry to be fresh within the TTL")
+	}
+
+	now = now.Add(time.Minute)
+	if epgCache.Fresh(entry.FetchedAt) {
+		t.Error("Expected the entry to expire after the TTL")
+	}
+}
+
+// TestEPGCache_Channels tests caching the channel list
+func TestEPGCache_Channels(t *testing.T) {
+	epgCache := cache.NewEPGCache(t.TempDir(), 0)
+	if epgCache.TTL != cache.DefaultTTL {
+		t.Errorf("Expected default TTL %v, got %v", cache.DefaultTTL, epgCache.TTL)
+	}
+
+	if entry, err := epgCache.LoadChannels("recorder:5510"); err != nil || entry != nil {
+		t.Fatalf("Expected no entry, got %+v, %v", entry, err)
+	}
+
+	if err := epgCache.StoreChannels("recorder:5510", resolverTestChannels()); err != nil {
+		t.Fatalf("StoreChannels() failed: %v", err)
+	}
+
+	entry, err := epgCache.LoadChannels("recorder:5510")
+	if err != nil || entry == nil {
+		t.Fatalf("LoadChannels() failed: %+v, %v", entry, err)
+	}
+	if len(entry.Channels) != len(resolverTestChannels()) {
+		t.Fatalf("Expected %d channels, got %d", len(resolverTestChannels()), len(entry.Channels))
+	}
+	if entry.Channels[0].ServiceName != "ＮＨＫ総合１・東京" || entry.Channels[0].RemoteControlKeyID != 1 {
+		t.Errorf("Cached channel differs: %+v", entry.Channels[0])
+	}
+}
+
+// TestEPGCache_Corrupt tests that unreadable cache files are reported
+func TestEPGCache_Corrupt(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "recorder_5510", "epg", "4-16400-151.json")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := cache.NewEPGCache(dir, 0).LoadEPG("recorder:5510", "4-16400-151"); err == nil {
+		t.Error("Expected error for a corrupt cache file")
+	}
+}
+
+// TestEPGCache_DefaultDir tests the XDG cache directory
+func TestEPGCache_DefaultDir(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", "/tmp/xdg-cache")
+
+	dir, err := cache.DefaultDir()
+	if err != nil {
+		t.Fatalf("DefaultDir() failed: %v", err)
+	}
+	if dir != filepath.Join("/tmp/xdg-cache", "epgtimer") {
+		t.Errorf("Expected /tmp/xdg-cache/epgtimer, got %s", dir)
+	}
+}
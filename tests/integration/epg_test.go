@@ -1,10 +1,18 @@
 package integration
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/epy0n0ff/epgtimer-cli/internal/client"
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
 	"github.com/epy0n0ff/epgtimer-cli/tests/testdata"
 )
 
@@ -18,7 +26,7 @@ func TestEnumEventInfo_Success(t *testing.T) {
 	apiClient := client.NewClient(mock.URL())
 
 	// Call EnumEventInfo
-	response, err := apiClient.EnumEventInfo(32736, 32736, 1024)
+	response, err := apiClient.EnumEventInfo(context.Background(), 32736, 32736, 1024)
 	if err != nil {
 		t.Fatalf("EnumEventInfo() failed: %v", err)
 	}
@@ -105,7 +113,7 @@ func TestEnumEventInfo_Empty(t *testing.T) {
 	apiClient := client.NewClient(mock.URL())
 
 	// Call EnumEventInfo
-	response, err := apiClient.EnumEventInfo(32736, 32736, 1024)
+	response, err := apiClient.EnumEventInfo(context.Background(), 32736, 32736, 1024)
 	if err != nil {
 		t.Fatalf("EnumEventInfo() failed: %v", err)
 	}
@@ -130,7 +138,7 @@ func TestEnumEventInfo_ConnectionError(t *testing.T) {
 	apiClient := client.NewClient("http://localhost:99999")
 
 	// Call EnumEventInfo (should fail)
-	_, err := apiClient.EnumEventInfo(32736, 32736, 1024)
+	_, err := apiClient.EnumEventInfo(context.Background(), 32736, 32736, 1024)
 	if err == nil {
 		t.Fatal("Expected connection error, got nil")
 	}
@@ -155,7 +163,7 @@ func TestEnumEventInfo_MalformedXML(t *testing.T) {
 	apiClient := client.NewClient(mock.URL())
 
 	// Call EnumEventInfo (should fail to parse)
-	_, err := apiClient.EnumEventInfo(32736, 32736, 1024)
+	_, err := apiClient.EnumEventInfo(context.Background(), 32736, 32736, 1024)
 	if err == nil {
 		t.Fatal("Expected XML parse error, got nil")
 	}
@@ -177,7 +185,7 @@ func TestEnumEventInfo_JapaneseEncoding(t *testing.T) {
 	apiClient := client.NewClient(mock.URL())
 
 	// Call EnumEventInfo
-	response, err := apiClient.EnumEventInfo(32736, 32736, 1024)
+	response, err := apiClient.EnumEventInfo(context.Background(), 32736, 32736, 1024)
 	if err != nil {
 		t.Fatalf("EnumEventInfo() failed: %v", err)
 	}
@@ -211,7 +219,7 @@ func TestEventInfo_GenreString(t *testing.T) {
 	apiClient := client.NewClient(mock.URL())
 
 	// Call EnumEventInfo
-	response, err := apiClient.EnumEventInfo(32736, 32736, 1024)
+	response, err := apiClient.EnumEventInfo(context.Background(), 32736, 32736, 1024)
 	if err != nil {
 		t.Fatalf("EnumEventInfo() failed: %v", err)
 	}
@@ -227,3 +235,152 @@ func TestEventInfo_GenreString(t *testing.T) {
 		}
 	}
 }
+
+// newChannelEPGServer creates a server returning one program per channel, named after the SID
+// Lower SIDs answer more slowly so that requests complete out of order; failSID answers 500.
+// The highest number of requests in flight at the same time is recorded in maxInFlight.
+func newChannelEPGServer(failSID int, maxInFlight *atomic.Int32) *httptest.Server {
+	var inFlight atomic.Int32
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			highest := maxInFlight.Load()
+			if current <= highest || maxInFlight.CompareAndSwap(highest, current) {
+				break
+			}
+		}
+
+		sid, _ := strconv.Atoi(r.URL.Query().Get("SID"))
+		time.Sleep(time.Duration(1010-sid) * 5 * time.Millisecond)
+
+		if sid == failSID {
+			http.Error(w, "EPG not available", http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8" ?><entry><total>1</total><index>0</index><count>1</count><items>
+<eventinfo><ONID>1</ONID><TSID>1</TSID><SID>%d</SID><eventID>1</eventID><event_name>program %d</event_name><duration>60</duration></eventinfo>
+</items></entry>`, sid, sid)
+	}))
+}
+
+// epgTestChannels returns channels 1-1-1001 to 1-1-1008
+func epgTestChannels() []*models.ServiceListEntry {
+	var channels []*models.ServiceListEntry
+	for sid := 1001; sid <= 1008; sid++ {
+		channels = append(channels, &models.ServiceListEntry{ONID: 1, TSID: 1, SID: sid})
+	}
+	return channels
+}
+
+// TestEnumEventInfoChannels tests parallel retrieval with results in channel order
+func TestEnumEventInfoChannels(t *testing.T) {
+	var maxInFlight atomic.Int32
+	server := newChannelEPGServer(1003, &maxInFlight)
+	defer server.Close()
+
+	channels := epgTestChannels()
+	results := client.NewClient(server.URL).EnumEventInfoChannels(context.Background(), channels, client.BatchOptions{Concurrency: 3})
+
+	if len(results) != len(channels) {
+		t.Fatalf("Expected %d results, got %d", len(channels), len(results))
+	}
+	for i, result := range results {
+		if result.Channel != channels[i] {
+			t.Errorf("Result %d: expected channel %s, got %s", i, channels[i], result.Channel)
+		}
+		if result.Channel.SID == 1003 {
+			if result.Err == nil {
+				t.Error("Expected an error for channel 1-1-1003")
+			}
+			continue
+		}
+		if result.Err != nil {
+			t.Errorf("Channel %s failed: %v", result.Channel, result.Err)
+			continue
+		}
+		expected := fmt.Sprintf("program %d", result.Channel.SID)
+		if len(result.Events) != 1 || result.Events[0].EventName != expected {
+			t.Errorf("Channel %s: expected [%s], got %+v", result.Channel, expected, result.Events)
+		}
+	}
+
+	if got := maxInFlight.Load(); got < 2 || got > 3 {
+		t.Errorf("Expected 2-3 requests in flight, got %d", got)
+	}
+}
+
+// TestEnumEventInfoChannels_Rate tests that the rate limit spaces the requests
+func TestEnumEventInfoChannels_Rate(t *testing.T) {
+	var maxInFlight atomic.Int32
+	server := newChannelEPGServer(0, &maxInFlight)
+	defer server.Close()
+
+	channels := epgTestChannels()[6:] // 1007 and 1008 answer quickly
+	start := time.Now()
+	results := client.NewClient(server.URL).EnumEventInfoChannels(context.Background(), channels, client.BatchOptions{Concurrency: 2, Rate: 10})
+
+	// The second request starts 100ms after the first
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("Expected at least 100ms with 10 requests per second, took %v", elapsed)
+	}
+	for _, result := range results {
+		if result.Err != nil {
+			t.Errorf("Channel %s failed: %v", result.Channel, result.Err)
+		}
+	}
+}
+
+// TestEnumEventInfoChannels_Cancel tests that channels not requested before cancellation report the context error
+func TestEnumEventInfoChannels_Cancel(t *testing.T) {
+	var maxInFlight atomic.Int32
+	server := newChannelEPGServer(0, &maxInFlight)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+	defer cancel()
+
+	results := client.NewClient(server.URL).EnumEventInfoChannels(ctx, epgTestChannels(), client.BatchOptions{Concurrency: 1, Rate: 10})
+
+	last := results[len(results)-1]
+	if last.Err == nil {
+		t.Fatal("Expected the last channel to fail after cancellation")
+	}
+	if last.Events != nil {
+		t.Errorf("Expected no events for the last channel, got %+v", last.Events)
+	}
+}
+
+// TestEnumEventInfoChannels_HugeRate tests that a rate too high for a ticker interval means no limit
+func TestEnumEventInfoChannels_HugeRate(t *testing.T) {
+	var maxInFlight atomic.Int32
+	server := newChannelEPGServer(0, &maxInFlight)
+	defer server.Close()
+
+	channels := epgTestChannels()[6:]
+	results := client.NewClient(server.URL).EnumEventInfoChannels(context.Background(), channels, client.BatchOptions{Concurrency: 2, Rate: 2e9})
+
+	for _, result := range results {
+		if result.Err != nil {
+			t.Errorf("Channel %s failed: %v", result.Channel, result.Err)
+		}
+	}
+}
+
+// TestEPGCommand_InvalidRate tests that --rate rejects values that are not finite numbers
+func TestEPGCommand_InvalidRate(t *testing.T) {
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	for _, rate := range []string{"NaN", "+Inf"} {
+		out, err := runCLI(t, mock, "", "epg", "--channels", "key:1,key:5", "--no-cache", "--rate", rate)
+		if err == nil || !strings.Contains(out, "--rate must be a finite number") {
+			t.Errorf("Expected --rate %s to be rejected, got %v:\n%s", rate, err, out)
+		}
+	}
+
+	out, err := runCLI(t, mock, "", "epg", "--channels", "key:1,key:5", "--no-cache", "--rate", "2e9")
+	if err != nil {
+		t.Errorf("Expected --rate 2e9 to work, got %v:\n%s", err, out)
+	}
+}
@@ -0,0 +1,273 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/client"
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+	"github.com/epy0n0ff/epgtimer-cli/tests/testdata"
+)
+
+// newFlakyServer creates a mock server whose EnumService fails with 503 for the first failures requests
+func newFlakyServer(failures int32) (*testdata.MockEMWUIServer, *atomic.Int32) {
+	var calls atomic.Int32
+	mock := testdata.NewMockEMWUIServer()
+	mock.SetEnumServiceHandler(func() (string, int) {
+		if calls.Add(1) <= failures {
+			return "waking up", http.StatusServiceUnavailable
+		}
+		return `<?xml version="1.0" encoding="UTF-8" ?><entry><total>0</total><index>0</index><count>0</count><items></items></entry>`, http.StatusOK
+	})
+	return mock, &calls
+}
+
+// fastRetry is a retry policy with short waits for tests
+var fastRetry = client.RetryPolicy{MaxRetries: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+// TestErrConnection tests that unreachable servers return ErrConnection
+func TestErrConnection(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	_, err := client.NewClient(url).EnumAutoAdd(context.Background())
+
+	var connErr *client.ErrConnection
+	if !errors.As(err, &connErr) {
+		t.Fatalf("Expected ErrConnection, got: %v", err)
+	}
+	if connErr.URL != url {
+		t.Errorf("Expected URL %s, got %s", url, connErr.URL)
+	}
+}
+
+// TestErrTimeout tests that slow servers return ErrTimeout
+func TestErrTimeout(t *testing.T) {
+	server := newSlowServer(5 * time.Second)
+	defer server.Close()
+
+	_, err := client.NewClient(server.URL, client.WithTimeout(50*time.Millisecond)).EnumAutoAdd(context.Background())
+
+	var timeoutErr *client.ErrTimeout
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("Expected ErrTimeout, got: %v", err)
+	}
+}
+
+// TestErrAPI tests that non-200 responses return ErrAPI with status and body
+func TestErrAPI(t *testing.T) {
+	mock, _ := newFlakyServer(100)
+	defer mock.Close()
+
+	_, err := client.NewClient(mock.URL()).EnumService(context.Background())
+
+	var apiErr *client.ErrAPI
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected ErrAPI, got: %v", err)
+	}
+	if apiErr.Status != http.StatusServiceUnavailable || apiErr.Body != "waking up" {
+		t.Errorf("Expected status 503 with body 'waking up', got %d %q", apiErr.Status, apiErr.Body)
+	}
+}
+
+// TestErrServerRejected tests that <err> responses return ErrServerRejected
+func TestErrServerRejected(t *testing.T) {
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	mock.SetDeleteAutoAddHandler(func(id int) (bool, string) {
+		return false, "Rule not found"
+	})
+
+	_, err := client.NewClient(mock.URL()).DeleteAutoAdd(context.Background(), 999)
+
+	var rejectedErr *client.ErrServerRejected
+	if !errors.As(err, &rejectedErr) {
+		t.Fatalf("Expected ErrServerRejected, got: %v", err)
+	}
+	if rejectedErr.Message != "Rule not found" {
+		t.Errorf("Expected message 'Rule not found', got %q", rejectedErr.Message)
+	}
+}
+
+// TestErrCTokenMissing tests that pages without ctok return ErrCTokenMissing
+func TestErrCTokenMissing(t *testing.T) {
+	htmlServer := testdata.NewHTMLServer()
+	defer htmlServer.Close()
+
+	_, err := client.NewClient(htmlServer.URL).GetCToken(context.Background())
+
+	var ctokErr *client.ErrCTokenMissing
+	if !errors.As(err, &ctokErr) {
+		t.Fatalf("Expected ErrCTokenMissing, got: %v", err)
+	}
+}
+
+// TestErrValidation tests that invalid requests return ErrValidation without calling the API
+func TestErrValidation(t *testing.T) {
+	apiClient := client.NewClient("http://localhost:5510")
+
+	_, err := apiClient.SetAutoAdd(context.Background(), &models.AutoAddRuleRequest{})
+
+	var validationErr *client.ErrValidation
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected ErrValidation, got: %v", err)
+	}
+}
+
+// TestErrNotFound tests that lookups of unknown IDs return ErrNotFound
+func TestErrNotFound(t *testing.T) {
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	_, err := client.NewClient(mock.URL()).GetReserve(context.Background(), 9999)
+
+	var notFoundErr *client.ErrNotFound
+	if !errors.As(err, &notFoundErr) {
+		t.Fatalf("Expected ErrNotFound, got: %v", err)
+	}
+	if notFoundErr.ID != 9999 {
+		t.Errorf("Expected ID 9999, got %d", notFoundErr.ID)
+	}
+}
+
+// TestRetry_Success tests that transient failures of Enum* calls are retried
+func TestRetry_Success(t *testing.T) {
+	mock, calls := newFlakyServer(2)
+	defer mock.Close()
+
+	apiClient := client.NewClient(mock.URL(), client.WithRetry(fastRetry))
+
+	if _, err := apiClient.EnumService(context.Background()); err != nil {
+		t.Fatalf("Expected success after retries, got: %v", err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("Expected 3 attempts, got %d", got)
+	}
+}
+
+// TestRetry_GivesUp tests that the last error is returned when all retries fail
+func TestRetry_GivesUp(t *testing.T) {
+	mock, calls := newFlakyServer(100)
+	defer mock.Close()
+
+	apiClient := client.NewClient(mock.URL(), client.WithRetry(fastRetry))
+
+	_, err := apiClient.EnumService(context.Background())
+
+	var apiErr *client.ErrAPI
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected ErrAPI, got: %v", err)
+	}
+	if got := calls.Load(); got != 4 {
+		t.Errorf("Expected 4 attempts (1 + 3 retries), got %d", got)
+	}
+}
+
+// TestRetry_Disabled tests that requests are not retried by default
+func TestRetry_Disabled(t *testing.T) {
+	mock, calls := newFlakyServer(1)
+	defer mock.Close()
+
+	if _, err := client.NewClient(mock.URL()).EnumService(context.Background()); err == nil {
+		t.Fatal("Expected error without retries, got nil")
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("Expected 1 attempt, got %d", got)
+	}
+}
+
+// TestRetry_NotForWrites tests that rejected writes are not retried
+func TestRetry_NotForWrites(t *testing.T) {
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	var calls atomic.Int32
+	mock.SetDeleteAutoAddHandler(func(id int) (bool, string) {
+		calls.Add(1)
+		return false, "busy"
+	})
+
+	apiClient := client.NewClient(mock.URL(), client.WithRetry(fastRetry))
+
+	if _, err := apiClient.DeleteAutoAdd(context.Background(), 334); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("Expected 1 delete request, got %d", got)
+	}
+}
+
+// TestRetry_ContextCancel tests that cancelling the context stops waiting for a retry
+func TestRetry_ContextCancel(t *testing.T) {
+	mock, _ := newFlakyServer(100)
+	defer mock.Close()
+
+	apiClient := client.NewClient(mock.URL(), client.WithRetry(client.RetryPolicy{MaxRetries: 5, InitialBackoff: time.Minute}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	if _, err := apiClient.EnumService(ctx); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected retry wait to be cancelled promptly, took %v", elapsed)
+	}
+}
+
+// TestFormatConnectionError tests that read commands report timeouts, server errors and
+// unexpected responses as such instead of as connection failures
+func TestFormatConnectionError(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler func() (string, int)
+		want    string
+	}{
+		{
+			name: "Timeout",
+			handler: func() (string, int) {
+				time.Sleep(time.Second)
+				return "", http.StatusOK
+			},
+			want: "connection timeout",
+		},
+		{
+			name:    "ServerError",
+			handler: func() (string, int) { return "internal error", http.StatusInternalServerError },
+			want:    "EMWUI server error",
+		},
+		{
+			name:    "UnexpectedResponse",
+			handler: func() (string, int) { return "<html><body>Router login</body></html>", http.StatusOK },
+			want:    "unexpected response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := testdata.NewMockEMWUIServer()
+			defer mock.Close()
+			mock.SetEnumAutoAddHandler(tt.handler)
+
+			out, err := runCLI(t, mock, "", "list", "--timeout", "100ms", "--retries", "0")
+			if err == nil {
+				t.Fatalf("Expected an error, got:\n%s", out)
+			}
+			if !strings.Contains(out, tt.want) {
+				t.Errorf("Expected %q in the error, got:\n%s", tt.want, out)
+			}
+			if strings.Contains(out, "Failed to connect") {
+				t.Errorf("Expected no connection failure message, got:\n%s", out)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/client"
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+	"github.com/epy0n0ff/epgtimer-cli/tests/testdata"
+)
+
+// ruleIDs returns the IDs of the rules
+func ruleIDs(rules []models.AutoAddRule) []int {
+	var ids []int
+	for _, rule := range rules {
+		ids = append(ids, rule.ID)
+	}
+	return ids
+}
+
+// TestRuleExplainer tests finding the rules that created the mock reservations
+func TestRuleExplainer(t *testing.T) {
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	c := client.NewClient(mock.URL())
+	rules, err := c.EnumAutoAdd(context.Background())
+	if err != nil {
+		t.Fatalf("EnumAutoAdd() failed: %v", err)
+	}
+	reservations, err := c.EnumReserveInfo(context.Background())
+	if err != nil {
+		t.Fatalf("EnumReserveInfo() failed: %v", err)
+	}
+
+	explainer := models.NewRuleExplainer(rules.Items)
+	if len(explainer.Errors()) != 0 {
+		t.Fatalf("Unexpected errors: %v", explainer.Errors())
+	}
+
+	// 1001 "サイエンスZERO　テスト放送" is matched by rule 1
+	if ids := ruleIDs(explainer.Explain(&reservations.Items[0])); len(ids) != 1 || ids[0] != 1 {
+		t.Errorf("Expected rule 1 for reservation 1001, got %v", ids)
+	}
+
+	// 1002 "ブラタモリ　テスト回" is on a channel rule 2 does not record
+	if ids := ruleIDs(explainer.Explain(&reservations.Items[1])); len(ids) != 0 {
+		t.Errorf("Expected no rule for reservation 1002, got %v", ids)
+	}
+	if !reservations.Items[1].IsAutoReserved() {
+		t.Error("Expected reservation 1002 to be reserved automatically")
+	}
+}
+
+// TestRuleExplainer_Settings tests rule evaluation against reservations
+func TestRuleExplainer_Settings(t *testing.T) {
+	nhk := []models.ServiceInfo{{ONID: 32736, TSID: 32736, SID: 1024}}
+	rules := []models.AutoAddRule{
+		{ID: 1, SearchSettings: models.SearchSettings{AndKey: "ニュース", NotKey: "[再]", ServiceList: nhk}},
+		{ID: 2, SearchSettings: models.SearchSettings{AndKey: "ニュース", ServiceList: nhk, FreeCAFlag: 2}},
+		{ID: 3, SearchSettings: models.SearchSettings{AndKey: "ニュース", ServiceList: nhk, ChkDurationMin: 60}},
+		{ID: 4, SearchSettings: models.SearchSettings{AndKey: "(", RegExpFlag: 1, ServiceList: nhk}},
+		{ID: 5, Server: "other:5510", SearchSettings: models.SearchSettings{AndKey: "ニュース", ServiceList: nhk}},
+	}
+	explainer := models.NewRuleExplainer(rules)
+
+	if len(explainer.Errors()) != 1 {
+		t.Errorf("Expected an error for rule 4, got %v", explainer.Errors())
+	}
+
+	res := models.ReservationInfo{ID: 1, Title: "ＮＨＫニュース７", StartDate: "2025/12/22", StartTime: "19:00:00",
+		DurationSecond: 30 * 60, ONID: 32736, TSID: 32736, SID: 1024}
+
+	// The free-CA filter is unknown for reservations; rules of other servers are ignored
+	if ids := ruleIDs(explainer.Explain(&res)); len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Errorf("Expected rules 1 and 2, got %v", ids)
+	}
+
+	res.Title = "ＮＨＫニュース７[再]"
+	if ids := ruleIDs(explainer.Explain(&res)); len(ids) != 1 || ids[0] != 2 {
+		t.Errorf("Expected rule 2 for a rerun, got %v", ids)
+	}
+
+	res.Server = "other:5510"
+	if ids := ruleIDs(explainer.Explain(&res)); len(ids) != 1 || ids[0] != 5 {
+		t.Errorf("Expected rule 5 on the other server, got %v", ids)
+	}
+
+	if res.IsAutoReserved() {
+		t.Error("Expected a reservation without comment to be manual")
+	}
+}
@@ -1,6 +1,7 @@
 package integration
 
 import (
+	"context"
 	"encoding/json"
 	"strings"
 	"testing"
@@ -19,7 +20,7 @@ func TestJSONFormatter(t *testing.T) {
 
 	// Create client and retrieve rules
 	apiClient := client.NewClient(mock.URL())
-	response, err := apiClient.EnumAutoAdd()
+	response, err := apiClient.EnumAutoAdd(context.Background())
 	if err != nil {
 		t.Fatalf("EnumAutoAdd() failed: %v", err)
 	}
@@ -66,7 +67,7 @@ func TestCSVFormatter(t *testing.T) {
 
 	// Create client and retrieve rules
 	apiClient := client.NewClient(mock.URL())
-	response, err := apiClient.EnumAutoAdd()
+	response, err := apiClient.EnumAutoAdd(context.Background())
 	if err != nil {
 		t.Fatalf("EnumAutoAdd() failed: %v", err)
 	}
@@ -121,7 +122,7 @@ func TestTSVFormatter(t *testing.T) {
 
 	// Create client and retrieve rules
 	apiClient := client.NewClient(mock.URL())
-	response, err := apiClient.EnumAutoAdd()
+	response, err := apiClient.EnumAutoAdd(context.Background())
 	if err != nil {
 		t.Fatalf("EnumAutoAdd() failed: %v", err)
 	}
@@ -221,7 +222,7 @@ func TestExportWithSpecialCharacters(t *testing.T) {
 
 	// Create client and retrieve rules
 	apiClient := client.NewClient(mock.URL())
-	response, err := apiClient.EnumAutoAdd()
+	response, err := apiClient.EnumAutoAdd(context.Background())
 	if err != nil {
 		t.Fatalf("EnumAutoAdd() failed: %v", err)
 	}
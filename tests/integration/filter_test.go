@@ -1,6 +1,7 @@
 package integration
 
 import (
+	"context"
 	"testing"
 
 	"github.com/epy0n0ff/epgtimer-cli/internal/client"
@@ -18,7 +19,7 @@ func TestFilter_ByAndKey(t *testing.T) {
 	apiClient := client.NewClient(mock.URL())
 
 	// Retrieve all rules
-	response, err := apiClient.EnumAutoAdd()
+	response, err := apiClient.EnumAutoAdd(context.Background())
 	if err != nil {
 		t.Fatalf("EnumAutoAdd() failed: %v", err)
 	}
@@ -83,7 +84,7 @@ func TestFilter_ByChannel(t *testing.T) {
 	apiClient := client.NewClient(mock.URL())
 
 	// Retrieve all rules
-	response, err := apiClient.EnumAutoAdd()
+	response, err := apiClient.EnumAutoAdd(context.Background())
 	if err != nil {
 		t.Fatalf("EnumAutoAdd() failed: %v", err)
 	}
@@ -143,7 +144,7 @@ func TestFilter_ByEnabledStatus(t *testing.T) {
 	apiClient := client.NewClient(mock.URL())
 
 	// Retrieve all rules
-	response, err := apiClient.EnumAutoAdd()
+	response, err := apiClient.EnumAutoAdd(context.Background())
 	if err != nil {
 		t.Fatalf("EnumAutoAdd() failed: %v", err)
 	}
@@ -205,7 +206,7 @@ func TestFilter_CombinedFilters(t *testing.T) {
 	apiClient := client.NewClient(mock.URL())
 
 	// Retrieve all rules
-	response, err := apiClient.EnumAutoAdd()
+	response, err := apiClient.EnumAutoAdd(context.Background())
 	if err != nil {
 		t.Fatalf("EnumAutoAdd() failed: %v", err)
 	}
@@ -299,7 +300,7 @@ func TestFilter_RegexOnly(t *testing.T) {
 	apiClient := client.NewClient(mock.URL())
 
 	// Retrieve all rules
-	response, err := apiClient.EnumAutoAdd()
+	response, err := apiClient.EnumAutoAdd(context.Background())
 	if err != nil {
 		t.Fatalf("EnumAutoAdd() failed: %v", err)
 	}
@@ -0,0 +1,135 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/client"
+	"github.com/epy0n0ff/epgtimer-cli/internal/formatters"
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+	"github.com/epy0n0ff/epgtimer-cli/tests/testdata"
+)
+
+// TestImport_RoundTrip tests restoring a 'list --format json' backup
+func TestImport_RoundTrip(t *testing.T) {
+	// Create mock server
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	var received []map[string][]string
+	mock.SetAutoAddHandler(func(values map[string][]string) (bool, string) {
+		received = append(received, values)
+		return true, "EPG自動予約を追加しました"
+	})
+
+	// Create client
+	apiClient := client.NewClient(mock.URL())
+
+	response, err := apiClient.EnumAutoAdd(context.Background())
+	if err != nil {
+		t.Fatalf("EnumAutoAdd() failed: %v", err)
+	}
+
+	// Back up the rules with the list JSON formatter and add one rule the server does not have
+	rules := append([]models.AutoAddRule(nil), response.Items...)
+	extra := rules[2]
+	extra.SearchSettings.AndKey = "^NHKニュース7"
+	rules = append(rules, extra)
+
+	output, err := (&formatters.JSONFormatter{}).Format(rules)
+	if err != nil {
+		t.Fatalf("Format() failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "backup.json")
+	if err := os.WriteFile(path, []byte(output), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	backup, err := models.LoadAutoAddRuleBackup(path)
+	if err != nil {
+		t.Fatalf("LoadAutoAddRuleBackup() failed: %v", err)
+	}
+	if len(backup) != 4 {
+		t.Fatalf("Expected 4 rules in backup, got %d", len(backup))
+	}
+
+	plan := models.PlanImport(backup, response.Items)
+	if len(plan.Skipped) != 3 {
+		t.Errorf("Expected 3 existing rules to be skipped, got %d", len(plan.Skipped))
+	}
+	if len(plan.Creates) != 1 {
+		t.Fatalf("Expected 1 rule to create, got %d", len(plan.Creates))
+	}
+
+	if _, err := apiClient.SetAutoAdd(context.Background(), plan.Creates[0]); err != nil {
+		t.Fatalf("SetAutoAdd() failed: %v", err)
+	}
+
+	if len(received) != 1 {
+		t.Fatalf("Expected 1 SetAutoAdd call, got %d", len(received))
+	}
+
+	// Settings of the backed-up rule are preserved
+	values := received[0]
+	checks := map[string]string{
+		"andKey":      "^NHKニュース7",
+		"disableFlag": "1",
+		"regExpFlag":  "1",
+		"priority":    "3",
+	}
+	for name, want := range checks {
+		if got := values[name]; len(got) == 0 || got[0] != want {
+			t.Errorf("Expected %s=%s, got %v", name, want, got)
+		}
+	}
+}
+
+// TestPlanImport_DuplicatesInBackup tests that a rule appearing twice in a backup is created once
+func TestPlanImport_DuplicatesInBackup(t *testing.T) {
+	rule := models.AutoAddRule{
+		ID: 10,
+		SearchSettings: models.SearchSettings{
+			AndKey: "映画",
+			ServiceList: []models.ServiceInfo{
+				{ONID: 4, TSID: 16625, SID: 211},
+				{ONID: 32736, TSID: 32736, SID: 1024},
+			},
+		},
+		RecordingSettings: models.RecordingSettings{RecMode: 1, Priority: 2},
+	}
+
+	// Same rule with the channels in a different order
+	reordered := rule
+	reordered.ID = 11
+	reordered.SearchSettings.ServiceList = []models.ServiceInfo{
+		{ONID: 32736, TSID: 32736, SID: 1024},
+		{ONID: 4, TSID: 16625, SID: 211},
+	}
+
+	plan := models.PlanImport([]models.AutoAddRule{rule, reordered}, nil)
+	if len(plan.Creates) != 1 || len(plan.Skipped) != 1 {
+		t.Errorf("Expected 1 create and 1 skip, got %d and %d", len(plan.Creates), len(plan.Skipped))
+	}
+}
+
+// TestLoadAutoAddRuleBackup_Invalid tests the error for files that are not a rule backup
+func TestLoadAutoAddRuleBackup_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "backup.json")
+	data, _ := json.Marshal(map[string]string{"rules": "none"})
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := models.LoadAutoAddRuleBackup(path)
+	if err == nil {
+		t.Fatal("Expected error for invalid backup, got nil")
+	}
+	if !strings.Contains(err.Error(), "list --format json") {
+		t.Errorf("Expected error to mention the expected format, got: %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package integration
 
 import (
+	"context"
 	"strings"
 	"testing"
 
@@ -18,7 +19,7 @@ func TestEnumAutoAdd_Success(t *testing.T) {
 	apiClient := client.NewClient(mock.URL())
 
 	// Call EnumAutoAdd
-	response, err := apiClient.EnumAutoAdd()
+	response, err := apiClient.EnumAutoAdd(context.Background())
 	if err != nil {
 		t.Fatalf("EnumAutoAdd() failed: %v", err)
 	}
@@ -89,7 +90,7 @@ func TestEnumAutoAdd_Empty(t *testing.T) {
 	apiClient := client.NewClient(mock.URL())
 
 	// Call EnumAutoAdd
-	response, err := apiClient.EnumAutoAdd()
+	response, err := apiClient.EnumAutoAdd(context.Background())
 	if err != nil {
 		t.Fatalf("EnumAutoAdd() failed: %v", err)
 	}
@@ -114,7 +115,7 @@ func TestEnumAutoAdd_ConnectionError(t *testing.T) {
 	apiClient := client.NewClient("http://localhost:99999")
 
 	// Call EnumAutoAdd (should fail)
-	_, err := apiClient.EnumAutoAdd()
+	_, err := apiClient.EnumAutoAdd(context.Background())
 	if err == nil {
 		t.Fatal("Expected connection error, got nil")
 	}
@@ -139,7 +140,7 @@ func TestEnumAutoAdd_MalformedXML(t *testing.T) {
 	apiClient := client.NewClient(mock.URL())
 
 	// Call EnumAutoAdd (should fail to parse)
-	_, err := apiClient.EnumAutoAdd()
+	_, err := apiClient.EnumAutoAdd(context.Background())
 	if err == nil {
 		t.Fatal("Expected XML parse error, got nil")
 	}
@@ -161,7 +162,7 @@ func TestEnumAutoAdd_JapaneseEncoding(t *testing.T) {
 	apiClient := client.NewClient(mock.URL())
 
 	// Call EnumAutoAdd
-	response, err := apiClient.EnumAutoAdd()
+	response, err := apiClient.EnumAutoAdd(context.Background())
 	if err != nil {
 		t.Fatalf("EnumAutoAdd() failed: %v", err)
 	}
@@ -192,7 +193,7 @@ func TestServiceInfo_String(t *testing.T) {
 	apiClient := client.NewClient(mock.URL())
 
 	// Call EnumAutoAdd
-	response, err := apiClient.EnumAutoAdd()
+	response, err := apiClient.EnumAutoAdd(context.Background())
 	if err != nil {
 		t.Fatalf("EnumAutoAdd() failed: %v", err)
 	}
@@ -0,0 +1,208 @@
+package integration
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/client"
+	"github.com/epy0n0ff/epgtimer-cli/internal/formatters"
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+	"github.com/epy0n0ff/epgtimer-cli/tests/testdata"
+)
+
+// TestEnumManuAdd_Success tests retrieving program-based rules
+func TestEnumManuAdd_Success(t *testing.T) {
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	apiClient := client.NewClient(mock.URL())
+	response, err := apiClient.EnumManuAdd(context.Background())
+	if err != nil {
+		t.Fatalf("EnumManuAdd() failed: %v", err)
+	}
+
+	if len(response.Items) != 2 {
+		t.Fatalf("Expected 2 rules, got %d", len(response.Items))
+	}
+
+	radio := response.Items[0]
+	if radio.ID != 1 || radio.Title != "ラジオ深夜便　テスト" || radio.ChannelID() != "32737-32737-1032" {
+		t.Errorf("Unexpected first rule: %+v", radio)
+	}
+	if !slices.Equal(radio.Days(), []int{1, 2, 3, 4, 5}) || radio.DaysString() != "月火水木金" {
+		t.Errorf("Expected weekdays, got %v (%s)", radio.Days(), radio.DaysString())
+	}
+	if radio.StartTimeString() != "05:30" || radio.EndTimeString() != "06:00" || radio.DurationMinutes() != 30 {
+		t.Errorf("Expected 05:30-06:00 (30 min), got %s-%s (%d min)",
+			radio.StartTimeString(), radio.EndTimeString(), radio.DurationMinutes())
+	}
+	if !radio.IsEnabled() {
+		t.Error("Expected the first rule to be enabled")
+	}
+
+	// The second rule ends after midnight and is disabled
+	special := response.Items[1]
+	if special.EndTimeString() != "01:00" || special.IsEnabled() {
+		t.Errorf("Expected a disabled rule ending at 01:00, got %s (enabled=%t)", special.EndTimeString(), special.IsEnabled())
+	}
+	if special.RecordingSettings.StartMargine != 60 {
+		t.Errorf("Expected start margin 60, got %d", special.RecordingSettings.StartMargine)
+	}
+}
+
+// TestEnumManuAdd_Empty tests a server without program-based rules
+func TestEnumManuAdd_Empty(t *testing.T) {
+	mock := testdata.NewEmptyEnumManuAddServer()
+	defer mock.Close()
+
+	response, err := client.NewClient(mock.URL()).EnumManuAdd(context.Background())
+	if err != nil {
+		t.Fatalf("EnumManuAdd() failed: %v", err)
+	}
+	if len(response.Items) != 0 {
+		t.Errorf("Expected no rules, got %d", len(response.Items))
+	}
+}
+
+// TestSetManuAdd_Success tests the form data sent to create a program-based rule
+func TestSetManuAdd_Success(t *testing.T) {
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	var received map[string][]string
+	mock.SetManuAddHandler(func(values map[string][]string) (bool, string) {
+		received = values
+		return true, "プログラム予約を追加しました"
+	})
+
+	// Saturday and Sunday from 23:30 for 90 minutes
+	req := models.NewManualAutoAddRuleRequest("週末特番", 32736, 32736, 1024, []int{6, 0}, 23*3600+30*60, 90*60)
+	req.Priority = 4
+
+	resp, err := client.NewClient(mock.URL()).SetManuAdd(context.Background(), req)
+	if err != nil {
+		t.Fatalf("SetManuAdd() failed: %v", err)
+	}
+	if !resp.IsSuccess() {
+		t.Errorf("Expected success=true, got success=false")
+	}
+
+	checks := map[string]string{
+		"dayOfWeek0": "1",
+		"dayOfWeek6": "1",
+		"startH":     "23",
+		"startM":     "30",
+		"endH":       "1",
+		"endM":       "0",
+		"title":      "週末特番",
+		"serviceID":  "32736-32736-1024",
+		"priority":   "4",
+		"ctok":       mock.CToken,
+	}
+	for name, want := range checks {
+		if got := received[name]; len(got) == 0 || got[0] != want {
+			t.Errorf("Expected %s=%s, got %v", name, want, got)
+		}
+	}
+	if _, ok := received["dayOfWeek1"]; ok {
+		t.Error("Expected dayOfWeek1 to be omitted")
+	}
+}
+
+// TestManualAutoAddRuleRequest_Validate tests validation of program-based rule requests
+func TestManualAutoAddRuleRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(req *models.ManualAutoAddRuleRequest)
+		wantErr string
+	}{
+		{"valid", func(req *models.ManualAutoAddRuleRequest) {}, ""},
+		{"empty title", func(req *models.ManualAutoAddRuleRequest) { req.Title = " " }, "title"},
+		{"no days", func(req *models.ManualAutoAddRuleRequest) { req.DayOfWeekFlag = 0 }, "day of week"},
+		{"invalid channel", func(req *models.ManualAutoAddRuleRequest) { req.SID = 0 }, "invalid channel"},
+		{"start out of range", func(req *models.ManualAutoAddRuleRequest) { req.StartTime = 24 * 3600 }, "start time"},
+		{"zero duration", func(req *models.ManualAutoAddRuleRequest) { req.DurationSecond = 0 }, "duration"},
+		{"invalid priority", func(req *models.ManualAutoAddRuleRequest) { req.Priority = 6 }, "priority"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := models.NewManualAutoAddRuleRequest("ラジオ英会話", 32737, 32737, 1032, []int{1}, 6*3600, 15*60)
+			tt.modify(req)
+
+			err := req.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestDeleteManuAdd tests deleting a program-based rule
+func TestDeleteManuAdd(t *testing.T) {
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	deleted := 0
+	mock.SetDeleteManuAddHandler(func(id int) (bool, string) {
+		deleted = id
+		return true, "プログラム予約を削除しました"
+	})
+
+	apiClient := client.NewClient(mock.URL())
+	if _, err := apiClient.DeleteManuAdd(context.Background(), 2); err != nil {
+		t.Fatalf("DeleteManuAdd() failed: %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("Expected rule 2 to be deleted, got %d", deleted)
+	}
+
+	if _, err := apiClient.DeleteManuAdd(context.Background(), 0); err == nil {
+		t.Error("Expected validation error for ID 0, got nil")
+	}
+}
+
+// TestParseTimeOfDay tests parsing the start and end times of program-based rules
+func TestParseTimeOfDay(t *testing.T) {
+	if seconds, err := models.ParseTimeOfDay("05:30"); err != nil || seconds != 19800 {
+		t.Errorf("Expected 19800, got %d (%v)", seconds, err)
+	}
+	for _, value := range []string{"24:00", "5", "12:60"} {
+		if _, err := models.ParseTimeOfDay(value); err == nil {
+			t.Errorf("Expected error for %q, got nil", value)
+		}
+	}
+}
+
+// TestManualRulesFormatters tests the CSV output of program-based rules
+func TestManualRulesFormatters(t *testing.T) {
+	rules := []models.ManualAutoAddRule{
+		{ID: 1, DayOfWeekFlag: 0x3e, StartTime: 19800, DurationSecond: 1800, Title: "ラジオ, 英会話",
+			StationName: "NHKラジオ第1", ONID: 32737, TSID: 32737, SID: 1032},
+	}
+
+	output, err := (&formatters.ManualRulesCSVFormatter{}).Format(rules)
+	if err != nil {
+		t.Fatalf("Format() failed: %v", err)
+	}
+	want := "1,true,月火水木金,62,05:30,06:00,30,\"ラジオ, 英会話\",NHKラジオ第1,32737-32737-1032,0,0\n"
+	if lines := strings.SplitAfter(output, "\n"); len(lines) < 2 || lines[1] != want {
+		t.Errorf("Unexpected CSV row:\n%s", output)
+	}
+
+	output, err = (&formatters.ManualRulesTableFormatter{}).Format(rules)
+	if err != nil {
+		t.Fatalf("Format() failed: %v", err)
+	}
+	if !strings.Contains(output, "05:30-06:00") || !strings.Contains(output, "Total: 1 rules") {
+		t.Errorf("Unexpected table output:\n%s", output)
+	}
+}
@@ -0,0 +1,166 @@
+package integration
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/client"
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+	"github.com/epy0n0ff/epgtimer-cli/tests/testdata"
+)
+
+// TestEnumRecPreset_Success tests retrieving recording presets with their folders
+func TestEnumRecPreset_Success(t *testing.T) {
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	response, err := client.NewClient(mock.URL()).EnumRecPreset(context.Background())
+	if err != nil {
+		t.Fatalf("EnumRecPreset() failed: %v", err)
+	}
+
+	if len(response.Items) != 3 {
+		t.Fatalf("Expected 3 presets, got %d", len(response.Items))
+	}
+
+	def := response.Items[0]
+	if def.ID != 0 || def.Name != "デフォルト" || len(def.RecordingSettings.RecFolderList) != 0 {
+		t.Errorf("Unexpected default preset: %+v", def)
+	}
+	if def.MarginsString() != "default" || def.RecordingSettings.RecFolderList.String() != "(default)" {
+		t.Errorf("Expected default margins and folder, got %s and %s", def.MarginsString(), def.RecordingSettings.RecFolderList.String())
+	}
+
+	kids := response.Items[1]
+	folders := kids.RecordingSettings.RecFolderList
+	if len(folders) != 1 || folders[0].RecFolder != `D:\Recorded\Kids` || folders[0].RecNamePlugIn != "RecName_Macro.dll?$Title$.ts" {
+		t.Errorf("Unexpected folders of the Kids preset: %+v", folders)
+	}
+	if kids.MarginsString() != "30/60" {
+		t.Errorf("Expected margins 30/60, got %s", kids.MarginsString())
+	}
+
+	papa := response.Items[2]
+	if got := papa.RecordingSettings.RecFolderList.String(); got != `D:\Recorded\Papa; E:\Backup\Papa` {
+		t.Errorf("Unexpected folders of the third preset: %s", got)
+	}
+}
+
+// TestFindRecPreset tests resolving --preset values
+func TestFindRecPreset(t *testing.T) {
+	presets := []models.RecPreset{
+		{ID: 0, Name: "デフォルト"},
+		{ID: 1, Name: "Kids"},
+		{ID: 2, Name: "kids"},
+		{ID: 3, Name: "Papa"},
+	}
+
+	tests := []struct {
+		ref     string
+		wantID  int
+		wantErr string
+	}{
+		{"1", 1, ""},
+		{"デフォルト", 0, ""},
+		{"kids", 2, ""}, // Exact match wins over case-insensitive
+		{"PAPA", 3, ""}, // Case-insensitive
+		{"KIDS", 0, "ambiguous"},
+		{"Mama", 0, "not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			preset, err := models.FindRecPreset(presets, tt.ref)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FindRecPreset() failed: %v", err)
+			}
+			if preset.ID != tt.wantID {
+				t.Errorf("Expected preset %d, got %d", tt.wantID, preset.ID)
+			}
+		})
+	}
+}
+
+// TestAddCommand_Preset tests that a rule created from a preset submits the preset's
+// settings as custom settings, so flags given with --preset override them
+func TestAddCommand_Preset(t *testing.T) {
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	var received map[string][]string
+	mock.SetAutoAddHandler(func(values map[string][]string) (bool, string) {
+		received = values
+		return true, "Automatic recording rule created successfully"
+	})
+
+	tests := []struct {
+		name   string
+		args   []string
+		checks map[string]string
+	}{
+		{
+			name: "Preset",
+			checks: map[string]string{
+				"presetID":         "65535",
+				"priority":         "3",
+				"useDefMarginFlag": "0",
+				"startMargin":      "30",
+				"endMargin":        "60",
+			},
+		},
+		{
+			name: "Overrides",
+			args: []string{"--priority", "5", "--rec-folder", `E:\Anime`},
+			checks: map[string]string{
+				"presetID":    "65535",
+				"priority":    "5",
+				"recFolder":   `E:\Anime`,
+				"startMargin": "30",
+				"endMargin":   "60",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			received = nil
+			args := append([]string{"add", "--andKey", "アニメ", "--serviceList", "32736-32736-1024", "--preset", "Kids"}, tt.args...)
+			out, err := runCLI(t, mock, "", args...)
+			if err != nil {
+				t.Fatalf("add failed: %v\n%s", err, out)
+			}
+			for name, want := range tt.checks {
+				if got := received[name]; len(got) == 0 || got[0] != want {
+					t.Errorf("Expected %s=%s, got %v", name, want, got)
+				}
+			}
+		})
+	}
+}
+
+// TestLoadAutoAddRuleBackup_StringRecFolders tests reading backups written before
+// recording folders were parsed, which contain an empty string
+func TestLoadAutoAddRuleBackup_StringRecFolders(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "backup.json")
+	backup := `[{"id": 1, "search": {"and_key": "ニュース"}, "recording": {"priority": 2, "rec_folders": ""}}]`
+	if err := os.WriteFile(path, []byte(backup), 0644); err != nil {
+		t.Fatalf("Failed to write backup: %v", err)
+	}
+
+	rules, err := models.LoadAutoAddRuleBackup(path)
+	if err != nil {
+		t.Fatalf("LoadAutoAddRuleBackup() failed: %v", err)
+	}
+	if len(rules) != 1 || len(rules[0].RecordingSettings.RecFolderList) != 0 {
+		t.Errorf("Expected one rule without folders, got %+v", rules)
+	}
+}
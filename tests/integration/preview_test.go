@@ -0,0 +1,80 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/client"
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+	"github.com/epy0n0ff/epgtimer-cli/tests/testdata"
+)
+
+// TestEventMatcher_ExistingRules tests previewing the rules of EnumAutoAdd against EnumEventInfo
+func TestEventMatcher_ExistingRules(t *testing.T) {
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	c := client.NewClient(mock.URL())
+	rules, err := c.EnumAutoAdd(context.Background())
+	if err != nil {
+		t.Fatalf("EnumAutoAdd() failed: %v", err)
+	}
+	epg, err := c.EnumEventInfo(context.Background(), 32736, 32736, 1024)
+	if err != nil {
+		t.Fatalf("EnumEventInfo() failed: %v", err)
+	}
+
+	matches := make(map[int][]string)
+	for _, rule := range rules.Items {
+		matcher, err := models.NewEventMatcher(&rule.SearchSettings)
+		if err != nil {
+			t.Fatalf("NewEventMatcher() for rule %d failed: %v", rule.ID, err)
+		}
+		for _, event := range epg.Items {
+			if matcher.Matches(&event) {
+				matches[rule.ID] = append(matches[rule.ID], event.EventName)
+			}
+		}
+	}
+
+	// Rule 3 is the regular expression ^NHKニュース, which matches the full-width title
+	if len(matches[3]) != 1 || matches[3][0] != "ＮＨＫニュース　おはよう日本　テスト" {
+		t.Errorf("Expected rule 3 to match the news program, got %v", matches[3])
+	}
+	if len(matches[1]) != 0 || len(matches[2]) != 0 {
+		t.Errorf("Expected rules 1 and 2 to match nothing, got %v and %v", matches[1], matches[2])
+	}
+}
+
+// TestEventMatcher_Request tests previewing a rule before it is created
+func TestEventMatcher_Request(t *testing.T) {
+	req := models.NewAutoAddRuleRequest("おはよう", "", []string{"32736-32736-1024"})
+
+	settings, err := req.SearchSettings()
+	if err != nil {
+		t.Fatalf("SearchSettings() failed: %v", err)
+	}
+	matcher, err := models.NewEventMatcher(settings)
+	if err != nil {
+		t.Fatalf("NewEventMatcher() failed: %v", err)
+	}
+
+	events := searchTestEvents()
+	var ids []int
+	for _, event := range events {
+		if matcher.Matches(&event) {
+			ids = append(ids, event.EventID)
+		}
+	}
+	if len(ids) != 1 || ids[0] != 1 {
+		t.Errorf("Expected event 1, got %v", ids)
+	}
+
+	// A channel outside the rule's service list does not match
+	req.ServiceList = []string{"4-16400-151"}
+	settings, _ = req.SearchSettings()
+	matcher, _ = models.NewEventMatcher(settings)
+	if matcher.Matches(&events[0]) {
+		t.Error("Expected no match on another channel")
+	}
+}
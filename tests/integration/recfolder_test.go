@@ -0,0 +1,164 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/client"
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+	"github.com/epy0n0ff/epgtimer-cli/tests/testdata"
+)
+
+// TestParseRecFolder tests parsing --rec-folder values
+func TestParseRecFolder(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    models.RecFolder
+		wantErr bool
+	}{
+		{`D:\Recorded`, models.RecFolder{RecFolder: `D:\Recorded`, WritePlugIn: "Write_Default.dll"}, false},
+		{`D:\Recorded:Write_OneService.dll`, models.RecFolder{RecFolder: `D:\Recorded`, WritePlugIn: "Write_OneService.dll"}, false},
+		{`D:\Recorded::RecName_Macro.dll?$Title$.ts`, models.RecFolder{RecFolder: `D:\Recorded`, WritePlugIn: "Write_Default.dll", RecNamePlugIn: "RecName_Macro.dll?$Title$.ts"}, false},
+		{`D:\Recorded:Write_Default.dll:RecName_Macro.dll?$SDYY$:$Title$.ts`, models.RecFolder{RecFolder: `D:\Recorded`, WritePlugIn: "Write_Default.dll", RecNamePlugIn: "RecName_Macro.dll?$SDYY$:$Title$.ts"}, false},
+		{`E:`, models.RecFolder{RecFolder: `E:`, WritePlugIn: "Write_Default.dll"}, false},
+		{`/mnt/recorded`, models.RecFolder{RecFolder: `/mnt/recorded`, WritePlugIn: "Write_Default.dll"}, false},
+		{`\\nas\tv`, models.RecFolder{RecFolder: `\\nas\tv`, WritePlugIn: "Write_Default.dll"}, false},
+		{``, models.RecFolder{}, true},
+		{`:Write_Default.dll`, models.RecFolder{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := models.ParseRecFolder(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error for %q, got %+v", tt.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRecFolder() failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %+v, got %+v", tt.want, got)
+			}
+			if back, _ := models.ParseRecFolder(got.Spec()); back != got {
+				t.Errorf("Spec() %q does not parse back to %+v", got.Spec(), got)
+			}
+		})
+	}
+
+	folders, err := models.ParseRecFolders([]string{""})
+	if err != nil || folders != nil {
+		t.Errorf("Expected an empty value to select the default folder, got %+v, %v", folders, err)
+	}
+}
+
+// TestSetAutoAdd_RecFolders tests that recording folders are sent as repeated form fields
+func TestSetAutoAdd_RecFolders(t *testing.T) {
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	var received map[string][]string
+	mock.SetAutoAddHandler(func(values map[string][]string) (bool, string) {
+		received = values
+		return true, "Automatic recording rule created successfully"
+	})
+
+	req := models.NewAutoAddRuleRequest("ドラマ", "", []string{"32736-32736-1024"})
+	req.RecFolders, _ = models.ParseRecFolders([]string{`D:\Recorded\Drama::RecName_Macro.dll?$Title$.ts`, `E:\Backup`})
+	req.PartialRecFolders, _ = models.ParseRecFolders([]string{`D:\Recorded\1seg:Write_OneService.dll`})
+
+	if _, err := client.NewClient(mock.URL()).SetAutoAdd(context.Background(), req); err != nil {
+		t.Fatalf("SetAutoAdd() failed: %v", err)
+	}
+
+	checks := map[string][]string{
+		"recFolder":            {`D:\Recorded\Drama`, `E:\Backup`},
+		"writePlugIn":          {"Write_Default.dll", "Write_Default.dll"},
+		"recNamePlugIn":        {"RecName_Macro.dll?$Title$.ts", ""},
+		"partialrecFolder":     {`D:\Recorded\1seg`},
+		"partialwritePlugIn":   {"Write_OneService.dll"},
+		"partialrecNamePlugIn": {""},
+	}
+	for name, want := range checks {
+		if got := received[name]; !reflect.DeepEqual(got, want) {
+			t.Errorf("Expected %s=%v, got %v", name, want, got)
+		}
+	}
+}
+
+// TestUpdateAutoAdd_KeepsRecFolders tests that editing a rule sends its folders back unchanged
+func TestUpdateAutoAdd_KeepsRecFolders(t *testing.T) {
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	var received map[string][]string
+	mock.SetUpdateAutoAddHandler(func(id int, values map[string][]string) (bool, string) {
+		received = values
+		return true, "EPG自動予約を変更しました"
+	})
+
+	apiClient := client.NewClient(mock.URL())
+	rule, err := apiClient.GetAutoAdd(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("GetAutoAdd() failed: %v", err)
+	}
+
+	req := models.NewAutoAddRuleRequestFromRule(rule)
+	req.Priority = 4
+	if _, err := apiClient.UpdateAutoAdd(context.Background(), 2, req); err != nil {
+		t.Fatalf("UpdateAutoAdd() failed: %v", err)
+	}
+
+	checks := map[string]string{
+		"recFolder":          `D:\Recorded\Tamori`,
+		"recNamePlugIn":      "RecName_Macro.dll?$SDYY$$SDMM$$SDDD$_$Title$.ts",
+		"partialrecFolder":   `D:\Recorded\1seg`,
+		"partialwritePlugIn": "Write_OneService.dll",
+	}
+	for name, want := range checks {
+		if got := received[name]; len(got) != 1 || got[0] != want {
+			t.Errorf("Expected %s=%s, got %v", name, want, got)
+		}
+	}
+
+	// Rules without folders send none, so EpgTimer uses its default folder
+	rule, _ = apiClient.GetAutoAdd(context.Background(), 1)
+	if _, err := apiClient.UpdateAutoAdd(context.Background(), 1, models.NewAutoAddRuleRequestFromRule(rule)); err != nil {
+		t.Fatalf("UpdateAutoAdd() failed: %v", err)
+	}
+	if got, ok := received["recFolder"]; ok {
+		t.Errorf("Expected no recFolder fields, got %v", got)
+	}
+}
+
+// TestAutoAddRule_RecFoldersJSON tests that folders survive a JSON export and import
+func TestAutoAddRule_RecFoldersJSON(t *testing.T) {
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	rule, err := client.NewClient(mock.URL()).GetAutoAdd(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("GetAutoAdd() failed: %v", err)
+	}
+
+	data, err := json.Marshal(rule)
+	if err != nil {
+		t.Fatalf("json.Marshal() failed: %v", err)
+	}
+
+	var decoded models.AutoAddRule
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(decoded.RecordingSettings.RecFolderList, rule.RecordingSettings.RecFolderList) {
+		t.Errorf("Expected folders %+v, got %+v", rule.RecordingSettings.RecFolderList, decoded.RecordingSettings.RecFolderList)
+	}
+	if !reflect.DeepEqual(decoded.RecordingSettings.PartialRecFolder, rule.RecordingSettings.PartialRecFolder) {
+		t.Errorf("Expected partial folders %+v, got %+v", rule.RecordingSettings.PartialRecFolder, decoded.RecordingSettings.PartialRecFolder)
+	}
+}
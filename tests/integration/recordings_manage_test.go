@@ -0,0 +1,179 @@
+package integration
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/client"
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+	"github.com/epy0n0ff/epgtimer-cli/tests/testdata"
+)
+
+// TestSetRecProtect_Success tests protecting and unprotecting a recording
+func TestSetRecProtect_Success(t *testing.T) {
+	// Create mock server
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	var receivedID int
+	var receivedProtect bool
+	mock.SetProtectRecInfoHandler(func(id int, protect bool) (bool, string) {
+		receivedID = id
+		receivedProtect = protect
+		return true, "録画情報を変更しました"
+	})
+
+	// Create client
+	apiClient := client.NewClient(mock.URL())
+
+	if _, err := apiClient.SetRecProtect(context.Background(), 2001, true); err != nil {
+		t.Fatalf("SetRecProtect(true) failed: %v", err)
+	}
+	if receivedID != 2001 || !receivedProtect {
+		t.Errorf("Expected protect=true for ID 2001, got ID %d protect=%t", receivedID, receivedProtect)
+	}
+
+	if _, err := apiClient.SetRecProtect(context.Background(), 2002, false); err != nil {
+		t.Fatalf("SetRecProtect(false) failed: %v", err)
+	}
+	if receivedID != 2002 || receivedProtect {
+		t.Errorf("Expected protect=false for ID 2002, got ID %d protect=%t", receivedID, receivedProtect)
+	}
+}
+
+// TestDeleteRecInfo_Success tests deleting a recording
+func TestDeleteRecInfo_Success(t *testing.T) {
+	// Create mock server
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	var receivedID int
+	mock.SetDeleteRecInfoHandler(func(id int) (bool, string) {
+		receivedID = id
+		return true, "録画情報を削除しました"
+	})
+
+	// Create client
+	apiClient := client.NewClient(mock.URL())
+
+	if _, err := apiClient.DeleteRecInfo(context.Background(), 2001); err != nil {
+		t.Fatalf("DeleteRecInfo() failed: %v", err)
+	}
+
+	if receivedID != 2001 {
+		t.Errorf("Expected delete for ID 2001, got %d", receivedID)
+	}
+}
+
+// TestDeleteRecInfo_APIError tests that an API error is returned to the caller
+func TestDeleteRecInfo_APIError(t *testing.T) {
+	// Create mock server
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	mock.SetDeleteRecInfoHandler(func(id int) (bool, string) {
+		return false, "録画中のため削除できません"
+	})
+
+	// Create client
+	apiClient := client.NewClient(mock.URL())
+
+	_, err := apiClient.DeleteRecInfo(context.Background(), 2001)
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "録画中のため削除できません") {
+		t.Errorf("Expected API error message, got: %v", err)
+	}
+}
+
+// TestDeleteRecInfo_InvalidID tests that invalid IDs are rejected before calling the API
+func TestDeleteRecInfo_InvalidID(t *testing.T) {
+	apiClient := client.NewClient("http://localhost:5510")
+
+	_, err := apiClient.DeleteRecInfo(context.Background(), 0)
+	if err == nil {
+		t.Fatal("Expected error for ID 0, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "invalid recording ID") {
+		t.Errorf("Expected 'invalid recording ID' error, got: %v", err)
+	}
+}
+
+// TestEnumAllRecInfo_Success tests retrieving all recordings
+func TestEnumAllRecInfo_Success(t *testing.T) {
+	// Create mock server
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	// Create client
+	apiClient := client.NewClient(mock.URL())
+
+	recordings, err := apiClient.EnumAllRecInfo(context.Background())
+	if err != nil {
+		t.Fatalf("EnumAllRecInfo() failed: %v", err)
+	}
+
+	if len(recordings) != 2 {
+		t.Errorf("Expected 2 recordings, got %d", len(recordings))
+	}
+}
+
+// TestParseAge tests parsing of --older-than values
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{"14d", 14 * 24 * time.Hour, false},
+		{"2w", 14 * 24 * time.Hour, false},
+		{"36h", 36 * time.Hour, false},
+		{"90m", 90 * time.Minute, false},
+		{"", 0, true},
+		{"d", 0, true},
+		{"0d", 0, true},
+		{"-3d", 0, true},
+		{"two weeks", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := models.ParseAge(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseAge(%q): expected error, got %v", tt.input, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseAge(%q) failed: %v", tt.input, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("ParseAge(%q) = %v, expected %v", tt.input, got, tt.expected)
+		}
+	}
+}
+
+// TestRecordingInfo_IsOlderThan tests age-based selection of recordings
+func TestRecordingInfo_IsOlderThan(t *testing.T) {
+	rec := models.RecordingInfo{StartDate: "2025/12/15", StartTime: "23:30:00"}
+	now := time.Date(2025, 12, 30, 0, 0, 0, 0, time.Local)
+
+	if !rec.IsOlderThan(14*24*time.Hour, now) {
+		t.Error("Expected recording from 12/15 to be older than 14 days on 12/30")
+	}
+
+	if rec.IsOlderThan(15*24*time.Hour, now) {
+		t.Error("Expected recording from 12/15 23:30 not to be older than 15 days on 12/30")
+	}
+
+	invalid := models.RecordingInfo{StartDate: "", StartTime: ""}
+	if invalid.IsOlderThan(time.Hour, now) {
+		t.Error("Expected recording without start time never to be considered old")
+	}
+}
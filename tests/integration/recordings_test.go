@@ -1,6 +1,7 @@
 package integration
 
 import (
+	"context"
 	"strings"
 	"testing"
 
@@ -18,7 +19,7 @@ func TestEnumRecInfo_Success(t *testing.T) {
 	apiClient := client.NewClient(mock.URL())
 
 	// Call EnumRecInfo
-	response, err := apiClient.EnumRecInfo()
+	response, err := apiClient.EnumRecInfo(context.Background())
 	if err != nil {
 		t.Fatalf("EnumRecInfo() failed: %v", err)
 	}
@@ -99,7 +100,7 @@ func TestEnumRecInfo_Empty(t *testing.T) {
 	apiClient := client.NewClient(mock.URL())
 
 	// Call EnumRecInfo
-	response, err := apiClient.EnumRecInfo()
+	response, err := apiClient.EnumRecInfo(context.Background())
 	if err != nil {
 		t.Fatalf("EnumRecInfo() failed: %v", err)
 	}
@@ -124,7 +125,7 @@ func TestEnumRecInfo_ConnectionError(t *testing.T) {
 	apiClient := client.NewClient("http://localhost:99999")
 
 	// Call EnumRecInfo (should fail)
-	_, err := apiClient.EnumRecInfo()
+	_, err := apiClient.EnumRecInfo(context.Background())
 	if err == nil {
 		t.Fatal("Expected connection error, got nil")
 	}
@@ -149,7 +150,7 @@ func TestEnumRecInfo_MalformedXML(t *testing.T) {
 	apiClient := client.NewClient(mock.URL())
 
 	// Call EnumRecInfo (should fail to parse)
-	_, err := apiClient.EnumRecInfo()
+	_, err := apiClient.EnumRecInfo(context.Background())
 	if err == nil {
 		t.Fatal("Expected XML parse error, got nil")
 	}
@@ -171,7 +172,7 @@ func TestEnumRecInfo_JapaneseEncoding(t *testing.T) {
 	apiClient := client.NewClient(mock.URL())
 
 	// Call EnumRecInfo
-	response, err := apiClient.EnumRecInfo()
+	response, err := apiClient.EnumRecInfo(context.Background())
 	if err != nil {
 		t.Fatalf("EnumRecInfo() failed: %v", err)
 	}
@@ -209,7 +210,7 @@ func TestRecordingInfo_IsProtected(t *testing.T) {
 	apiClient := client.NewClient(mock.URL())
 
 	// Call EnumRecInfo
-	response, err := apiClient.EnumRecInfo()
+	response, err := apiClient.EnumRecInfo(context.Background())
 	if err != nil {
 		t.Fatalf("EnumRecInfo() failed: %v", err)
 	}
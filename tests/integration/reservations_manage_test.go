@@ -0,0 +1,237 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/client"
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+	"github.com/epy0n0ff/epgtimer-cli/tests/testdata"
+)
+
+// TestUpdateReserve_Disable tests disabling a reservation while preserving its settings
+func TestUpdateReserve_Disable(t *testing.T) {
+	// Create mock server
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	var receivedID int
+	var receivedValues map[string][]string
+	mock.SetUpdateReserveHandler(func(id int, values map[string][]string) (bool, string) {
+		receivedID = id
+		receivedValues = values
+		return true, "予約を変更しました"
+	})
+
+	// Create client
+	apiClient := client.NewClient(mock.URL())
+
+	res, err := apiClient.GetReserve(context.Background(), 1001)
+	if err != nil {
+		t.Fatalf("GetReserve() failed: %v", err)
+	}
+
+	req := models.NewReserveRequestFromReservation(res)
+	req.SetDisabled(true)
+
+	if _, err := apiClient.UpdateReserve(context.Background(), 1001, req); err != nil {
+		t.Fatalf("UpdateReserve() failed: %v", err)
+	}
+
+	if receivedID != 1001 {
+		t.Errorf("Expected update for ID 1001, got %d", receivedID)
+	}
+
+	// recMode 0 (all services) is stored as 9 when disabled
+	if got := receivedValues["recMode"]; len(got) == 0 || got[0] != "9" {
+		t.Errorf("Expected recMode=9, got %v", got)
+	}
+
+	// Other settings are preserved
+	if got := receivedValues["priority"]; len(got) == 0 || got[0] != "5" {
+		t.Errorf("Expected priority=5 to be preserved, got %v", got)
+	}
+}
+
+// TestUpdateReserve_KeepsRecFolders tests that updating a reservation sends its
+// recording and partial recording folders back unchanged
+func TestUpdateReserve_KeepsRecFolders(t *testing.T) {
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	var received map[string][]string
+	mock.SetUpdateReserveHandler(func(id int, values map[string][]string) (bool, string) {
+		received = values
+		return true, "予約を変更しました"
+	})
+
+	apiClient := client.NewClient(mock.URL())
+
+	res, err := apiClient.GetReserve(context.Background(), 1002)
+	if err != nil {
+		t.Fatalf("GetReserve() failed: %v", err)
+	}
+
+	if got := res.RecSetting.RecFolderList.String(); got != `C:\Recorded` {
+		t.Errorf("Expected folder C:\\Recorded, got %s", got)
+	}
+	want := models.RecFolders{{RecFolder: `C:\Recorded\1seg`, WritePlugIn: "Write_OneService.dll"}}
+	if !reflect.DeepEqual(res.RecSetting.PartialRecFolder, want) {
+		t.Fatalf("Expected partial folders %+v, got %+v", want, res.RecSetting.PartialRecFolder)
+	}
+
+	req := models.NewReserveRequestFromReservation(res)
+	req.SetDisabled(true)
+	if _, err := apiClient.UpdateReserve(context.Background(), 1002, req); err != nil {
+		t.Fatalf("UpdateReserve() failed: %v", err)
+	}
+
+	checks := map[string][]string{
+		"recFolder":          {`C:\Recorded`},
+		"writePlugIn":        {"Write_Default.dll"},
+		"partialrecFolder":   {`C:\Recorded\1seg`},
+		"partialwritePlugIn": {"Write_OneService.dll"},
+	}
+	for name, want := range checks {
+		if got := received[name]; !reflect.DeepEqual(got, want) {
+			t.Errorf("Expected %s=%v, got %v", name, want, got)
+		}
+	}
+
+	// Reservations without a partial recording folder send none
+	res, _ = apiClient.GetReserve(context.Background(), 1001)
+	if len(res.RecSetting.PartialRecFolder) != 0 {
+		t.Errorf("Expected no partial folders, got %+v", res.RecSetting.PartialRecFolder)
+	}
+	if _, err := apiClient.UpdateReserve(context.Background(), 1001, models.NewReserveRequestFromReservation(res)); err != nil {
+		t.Fatalf("UpdateReserve() failed: %v", err)
+	}
+	if got, ok := received["partialrecFolder"]; ok {
+		t.Errorf("Expected no partialrecFolder fields, got %v", got)
+	}
+}
+
+// TestRecSetting_RecFoldersJSON tests that the folders of a reservation keep their
+// JSON layout and survive a JSON round trip
+func TestRecSetting_RecFoldersJSON(t *testing.T) {
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	res, err := client.NewClient(mock.URL()).GetReserve(context.Background(), 1002)
+	if err != nil {
+		t.Fatalf("GetReserve() failed: %v", err)
+	}
+
+	data, err := json.Marshal(res.RecSetting)
+	if err != nil {
+		t.Fatalf("json.Marshal() failed: %v", err)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("json.Unmarshal() failed: %v", err)
+	}
+	want := map[string]string{
+		"rec_folder_list":    `{"rec_folders":[{"rec_folder":"C:\\Recorded","write_plugin":"Write_Default.dll","rec_name_plugin":""}]}`,
+		"partial_rec_folder": `{"rec_folder":"C:\\Recorded\\1seg","write_plugin":"Write_OneService.dll","rec_name_plugin":""}`,
+	}
+	for name, value := range want {
+		if got := string(fields[name]); got != value {
+			t.Errorf("Expected %s=%s, got %s", name, value, got)
+		}
+	}
+
+	var decoded models.RecSetting
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() failed: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, res.RecSetting) {
+		t.Errorf("Expected %+v after round trip, got %+v", res.RecSetting, decoded)
+	}
+}
+
+// TestRecSettingRequest_SetDisabled tests that every mode survives disable and enable
+func TestRecSettingRequest_SetDisabled(t *testing.T) {
+	for mode := models.RecModeAll; mode <= models.RecModeView; mode++ {
+		rec := models.NewRecSettingRequest()
+		rec.RecMode = mode
+
+		rec.SetDisabled(true)
+		if !rec.IsDisabled() {
+			t.Errorf("Mode %d: expected disabled after SetDisabled(true), got recMode %d", mode, rec.RecMode)
+		}
+		if err := rec.Validate(); err != nil {
+			t.Errorf("Mode %d: disabled setting should be valid, got: %v", mode, err)
+		}
+
+		rec.SetDisabled(false)
+		if rec.IsDisabled() || rec.RecMode != mode {
+			t.Errorf("Mode %d: expected original mode after SetDisabled(false), got recMode %d", mode, rec.RecMode)
+		}
+	}
+}
+
+// TestDeleteReserve_Success tests deleting a reservation
+func TestDeleteReserve_Success(t *testing.T) {
+	// Create mock server
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	var deletedID int
+	mock.SetDeleteReserveHandler(func(id int) (bool, string) {
+		deletedID = id
+		return true, "予約を削除しました"
+	})
+
+	// Create client
+	apiClient := client.NewClient(mock.URL())
+
+	if _, err := apiClient.DeleteReserve(context.Background(), 1002); err != nil {
+		t.Fatalf("DeleteReserve() failed: %v", err)
+	}
+
+	if deletedID != 1002 {
+		t.Errorf("Expected delete for ID 1002, got %d", deletedID)
+	}
+}
+
+// TestDeleteReserve_InvalidID tests delete with invalid ID
+func TestDeleteReserve_InvalidID(t *testing.T) {
+	// Create mock server
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	// Create client
+	apiClient := client.NewClient(mock.URL())
+
+	_, err := apiClient.DeleteReserve(context.Background(), 0)
+	if err == nil {
+		t.Fatal("Expected error for invalid ID, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "invalid reservation ID") {
+		t.Errorf("Error message should mention invalid ID, got: %v", err)
+	}
+}
+
+// TestGetReserve_NotFound tests error when the reservation ID does not exist
+func TestGetReserve_NotFound(t *testing.T) {
+	// Create mock server
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	// Create client
+	apiClient := client.NewClient(mock.URL())
+
+	_, err := apiClient.GetReserve(context.Background(), 9999)
+	if err == nil {
+		t.Fatal("Expected error for unknown reservation ID, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected error message to mention 'not found', got: %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package integration
 
 import (
+	"context"
 	"strings"
 	"testing"
 
@@ -18,7 +19,7 @@ func TestEnumReserveInfo_Success(t *testing.T) {
 	apiClient := client.NewClient(mock.URL())
 
 	// Call EnumReserveInfo
-	response, err := apiClient.EnumReserveInfo()
+	response, err := apiClient.EnumReserveInfo(context.Background())
 	if err != nil {
 		t.Fatalf("EnumReserveInfo() failed: %v", err)
 	}
@@ -96,7 +97,7 @@ func TestEnumReserveInfo_Empty(t *testing.T) {
 	apiClient := client.NewClient(mock.URL())
 
 	// Call EnumReserveInfo
-	response, err := apiClient.EnumReserveInfo()
+	response, err := apiClient.EnumReserveInfo(context.Background())
 	if err != nil {
 		t.Fatalf("EnumReserveInfo() failed: %v", err)
 	}
@@ -121,7 +122,7 @@ func TestEnumReserveInfo_ConnectionError(t *testing.T) {
 	apiClient := client.NewClient("http://localhost:99999")
 
 	// Call EnumReserveInfo (should fail)
-	_, err := apiClient.EnumReserveInfo()
+	_, err := apiClient.EnumReserveInfo(context.Background())
 	if err == nil {
 		t.Fatal("Expected connection error, got nil")
 	}
@@ -146,7 +147,7 @@ func TestEnumReserveInfo_MalformedXML(t *testing.T) {
 	apiClient := client.NewClient(mock.URL())
 
 	// Call EnumReserveInfo (should fail to parse)
-	_, err := apiClient.EnumReserveInfo()
+	_, err := apiClient.EnumReserveInfo(context.Background())
 	if err == nil {
 		t.Fatal("Expected XML parse error, got nil")
 	}
@@ -168,7 +169,7 @@ func TestEnumReserveInfo_JapaneseEncoding(t *testing.T) {
 	apiClient := client.NewClient(mock.URL())
 
 	// Call EnumReserveInfo
-	response, err := apiClient.EnumReserveInfo()
+	response, err := apiClient.EnumReserveInfo(context.Background())
 	if err != nil {
 		t.Fatalf("EnumReserveInfo() failed: %v", err)
 	}
@@ -198,7 +199,7 @@ func TestReservationInfo_RecModeString(t *testing.T) {
 	apiClient := client.NewClient(mock.URL())
 
 	// Call EnumReserveInfo
-	response, err := apiClient.EnumReserveInfo()
+	response, err := apiClient.EnumReserveInfo(context.Background())
 	if err != nil {
 		t.Fatalf("EnumReserveInfo() failed: %v", err)
 	}
@@ -0,0 +1,107 @@
+package integration
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/client"
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+	"github.com/epy0n0ff/epgtimer-cli/tests/testdata"
+)
+
+// TestAddReserve_Success tests reserving an EPG event
+func TestAddReserve_Success(t *testing.T) {
+	// Create mock server
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	var received map[string][]string
+	mock.SetAddReserveHandler(func(values map[string][]string) (bool, string) {
+		received = values
+		return true, "予約を追加しました"
+	})
+
+	// Create client
+	apiClient := client.NewClient(mock.URL())
+
+	// Reserve the second event from the EPG fixture
+	epg, err := apiClient.EnumEventInfo(context.Background(), 32736, 32736, 1024)
+	if err != nil {
+		t.Fatalf("EnumEventInfo() failed: %v", err)
+	}
+
+	req := models.NewReserveRequestFromEvent(&epg.Items[1])
+	req.Priority = 4
+	req.UseDefMarginFlag = 0
+	req.StartMargin = 60
+
+	resp, err := apiClient.AddReserve(context.Background(), req)
+	if err != nil {
+		t.Fatalf("AddReserve() failed: %v", err)
+	}
+
+	if !resp.IsSuccess() {
+		t.Errorf("Expected success=true, got success=false")
+	}
+
+	checks := map[string]string{
+		"onid":        "32736",
+		"tsid":        "32736",
+		"sid":         "1024",
+		"eid":         "7331",
+		"presetID":    "65535",
+		"priority":    "4",
+		"startMargin": "60",
+		"ctok":        mock.CToken,
+	}
+	for name, want := range checks {
+		if got := received[name]; len(got) == 0 || got[0] != want {
+			t.Errorf("Expected %s=%s, got %v", name, want, got)
+		}
+	}
+}
+
+// TestAddReserve_MissingEventID tests validation of the event ID
+func TestAddReserve_MissingEventID(t *testing.T) {
+	// Create mock server
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	// Create client
+	apiClient := client.NewClient(mock.URL())
+
+	req := models.NewReserveRequest(32736, 32736, 1024, 0)
+
+	_, err := apiClient.AddReserve(context.Background(), req)
+	if err == nil {
+		t.Fatal("Expected validation error, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "event ID") {
+		t.Errorf("Expected error message to mention event ID, got: %v", err)
+	}
+}
+
+// TestAddReserve_APIError tests handling of an error response
+func TestAddReserve_APIError(t *testing.T) {
+	// Create mock server
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	mock.SetAddReserveHandler(func(values map[string][]string) (bool, string) {
+		return false, "予約の追加に失敗しました"
+	})
+
+	// Create client
+	apiClient := client.NewClient(mock.URL())
+
+	_, err := apiClient.AddReserve(context.Background(), models.NewReserveRequest(32736, 32736, 1024, 7331))
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "予約の追加に失敗しました") {
+		t.Errorf("Expected error to contain the server message, got: %v", err)
+	}
+}
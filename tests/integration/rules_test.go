@@ -0,0 +1,66 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/client"
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+	"github.com/epy0n0ff/epgtimer-cli/tests/testdata"
+)
+
+// TestUpdateAutoAdd_Disable tests that disabling a rule keeps its other settings
+func TestUpdateAutoAdd_Disable(t *testing.T) {
+	mock := testdata.NewMockEMWUIServer()
+	defer mock.Close()
+
+	var receivedID int
+	var received map[string][]string
+	mock.SetUpdateAutoAddHandler(func(id int, values map[string][]string) (bool, string) {
+		receivedID = id
+		received = values
+		return true, "EPG自動予約を変更しました"
+	})
+
+	apiClient := client.NewClient(mock.URL())
+	response, err := apiClient.EnumAutoAdd(context.Background())
+	if err != nil {
+		t.Fatalf("EnumAutoAdd() failed: %v", err)
+	}
+
+	// Select the rules like 'rules disable --andKey タモリ'
+	filter := models.FilterOptions{AndKeyFilter: "タモリ"}
+	var selected []models.AutoAddRule
+	for _, rule := range response.Items {
+		if filter.Matches(&rule) {
+			selected = append(selected, rule)
+		}
+	}
+	if len(selected) != 1 || selected[0].ID != 2 {
+		t.Fatalf("Expected rule 2 to be selected, got %+v", selected)
+	}
+
+	req := models.NewAutoAddRuleRequestFromRule(&selected[0])
+	req.DisableFlag = 1
+	if _, err := apiClient.UpdateAutoAdd(context.Background(), selected[0].ID, req); err != nil {
+		t.Fatalf("UpdateAutoAdd() failed: %v", err)
+	}
+
+	if receivedID != 2 {
+		t.Errorf("Expected update for ID 2, got %d", receivedID)
+	}
+
+	checks := map[string]string{
+		"disableFlag": "1",
+		"andKey":      "ブラタモリ",
+		"notKey":      "[再]",
+		"priority":    "2",
+		"recMode":     "1",
+		"recFolder":   `D:\Recorded\Tamori`,
+	}
+	for name, want := range checks {
+		if got := received[name]; len(got) == 0 || got[0] != want {
+			t.Errorf("Expected %s=%s, got %v", name, want, got)
+		}
+	}
+}
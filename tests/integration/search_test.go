@@ -0,0 +1,166 @@
+package integration
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+)
+
+// searchTestEvents returns events for matcher tests (2025/12/22 is a Monday)
+func searchTestEvents() []models.EventInfo {
+	return []models.EventInfo{
+		{ONID: 32736, TSID: 32736, SID: 1024, EventID: 1, StartDate: "2025/12/22", StartTime: "06:00:00", Duration: 30 * 60,
+			EventName: "ＮＨＫニュース　おはよう日本", EventText: "全国のニュースと天気", FreeCAFlag: 0},
+		{ONID: 32736, TSID: 32736, SID: 1024, EventID: 2, StartDate: "2025/12/22", StartTime: "22:00:00", Duration: 50 * 60,
+			EventName: "ドキュメント７２時間", EventText: "コインランドリーの三日間", EventExtText: "再放送", FreeCAFlag: 0},
+		{ONID: 4, TSID: 16400, SID: 151, EventID: 3, StartDate: "2025/12/27", StartTime: "21:00:00", Duration: 120 * 60,
+			EventName: "土曜プレミアム映画　Star Wars", EventText: "SF映画", FreeCAFlag: 0},
+		{ONID: 4, TSID: 16625, SID: 211, EventID: 4, StartDate: "2025/12/28", StartTime: "23:30:00", Duration: 60 * 60,
+			EventName: "news zero", EventText: "最新ニュース", FreeCAFlag: 1},
+	}
+}
+
+// matchingEventIDs returns the IDs of the test events the settings match
+func matchingEventIDs(t *testing.T, settings models.SearchSettings) []int {
+	t.Helper()
+	matcher, err := models.NewEventMatcher(&settings)
+	if err != nil {
+		t.Fatalf("NewEventMatcher() failed: %v", err)
+	}
+
+	var ids []int
+	for _, event := range searchTestEvents() {
+		if matcher.Matches(&event) {
+			ids = append(ids, event.EventID)
+		}
+	}
+	return ids
+}
+
+// TestEventMatcher tests the keyword and filter semantics of search settings
+func TestEventMatcher(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings models.SearchSettings
+		expected []int
+	}{
+		{"Title only", models.SearchSettings{AndKey: "ニュース", TitleOnlyFlag: 1}, []int{1}},
+		{"Full text", models.SearchSettings{AndKey: "ニュース"}, []int{1, 4}},
+		{"All keywords", models.SearchSettings{AndKey: "ドキュメント　コインランドリー"}, []int{2}},
+		{"Not keyword", models.SearchSettings{AndKey: "ニュース", NotKey: "zero"}, []int{1}},
+		{"Not keyword in description", models.SearchSettings{AndKey: "ドキュメント", NotKey: "再放送"}, nil},
+		{"Width insensitive", models.SearchSettings{AndKey: "nhk", TitleOnlyFlag: 1}, []int{1}},
+		{"Case insensitive", models.SearchSettings{AndKey: "NEWS", TitleOnlyFlag: 1}, []int{4}},
+		{"Case sensitive", models.SearchSettings{AndKey: "NEWS", TitleOnlyFlag: 1, CaseFlag: 1}, nil},
+		{"Regex", models.SearchSettings{AndKey: "^(ドキュメント|news)", RegExpFlag: 1, TitleOnlyFlag: 1}, []int{2, 4}},
+		{"Regex width insensitive", models.SearchSettings{AndKey: "NHK.*日本", RegExpFlag: 1, TitleOnlyFlag: 1}, []int{1}},
+		{"Regex not key", models.SearchSettings{AndKey: "映画|ニュース", NotKey: "^news", RegExpFlag: 1}, []int{1, 3}},
+		{"Fuzzy", models.SearchSettings{AndKey: "時間ドキュ", AimaiFlag: 1, TitleOnlyFlag: 1}, []int{2}},
+		{"Free only", models.SearchSettings{AndKey: "ニュース", FreeCAFlag: 1}, []int{1}},
+		{"Pay only", models.SearchSettings{AndKey: "ニュース", FreeCAFlag: 2}, []int{4}},
+		{"Duration", models.SearchSettings{ChkDurationMin: 45, ChkDurationMax: 90}, []int{2, 4}},
+		{"Channels", models.SearchSettings{ServiceList: []models.ServiceInfo{{ONID: 4, TSID: 16400, SID: 151}}}, []int{3}},
+		{"Weekday evenings", models.SearchSettings{DateList: mustParseDateList(t, "月-19:00-月-23:00")}, []int{2}},
+		{"Not weekday evenings", models.SearchSettings{DateList: mustParseDateList(t, "月-19:00-月-23:00"), NotDateFlag: 1}, []int{1, 3, 4}},
+		{"Window across the week end", models.SearchSettings{DateList: mustParseDateList(t, "日-23:00-月-7:00")}, []int{1, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids := matchingEventIDs(t, tt.settings)
+			if !slices.Equal(ids, tt.expected) {
+				t.Errorf("Expected events %v, got %v", tt.expected, ids)
+			}
+		})
+	}
+}
+
+// TestEventMatcher_InvalidRegex tests regular expression errors
+func TestEventMatcher_InvalidRegex(t *testing.T) {
+	_, err := models.NewEventMatcher(&models.SearchSettings{AndKey: "ニュース(", RegExpFlag: 1})
+	if err == nil || !strings.Contains(err.Error(), "andKey") {
+		t.Errorf("Expected andKey regex error, got: %v", err)
+	}
+
+	_, err = models.NewEventMatcher(&models.SearchSettings{AndKey: "ニュース", NotKey: "[", RegExpFlag: 1})
+	if err == nil || !strings.Contains(err.Error(), "notKey") {
+		t.Errorf("Expected notKey regex error, got: %v", err)
+	}
+
+	// Without RegExpFlag, the keywords are plain text
+	if _, err := models.NewEventMatcher(&models.SearchSettings{AndKey: "ニュース("}); err != nil {
+		t.Errorf("Expected no error without regex, got: %v", err)
+	}
+}
+
+// TestDateInfo_Contains tests weekly time windows
+func TestDateInfo_Contains(t *testing.T) {
+	monday := func(hour, min int) time.Time { return time.Date(2025, 12, 22, hour, min, 0, 0, time.UTC) }
+
+	window := models.DateInfo{StartDayOfWeek: 1, StartHour: 23, EndDayOfWeek: 2, EndHour: 1}
+	tests := []struct {
+		t        time.Time
+		expected bool
+	}{
+		{monday(22, 59), false},
+		{monday(23, 0), true},
+		{monday(23, 59).Add(90 * time.Minute), false}, // Tuesday 1:29
+		{monday(0, 0).AddDate(0, 0, 1).Add(59 * time.Minute), true},
+		{monday(0, 0).AddDate(0, 0, 1).Add(time.Hour), false}, // The end is exclusive
+	}
+
+	for _, tt := range tests {
+		if got := window.Contains(tt.t); got != tt.expected {
+			t.Errorf("Contains(%s) = %v, expected %v", tt.t.Format("Mon 15:04"), got, tt.expected)
+		}
+	}
+}
+
+// TestAutoAddRuleRequest_SearchSettings tests converting a request to search settings
+func TestAutoAddRuleRequest_SearchSettings(t *testing.T) {
+	req := models.NewAutoAddRuleRequest("ニュース", "再放送", []string{"32736-32736-1024", "4-16400-151"})
+	req.RegExpFlag = 1
+	req.FreeCAFlag = 1
+	req.ChkDurationMin = 30
+	req.DateList = "月-21:00-月-23:30"
+
+	settings, err := req.SearchSettings()
+	if err != nil {
+		t.Fatalf("SearchSettings() failed: %v", err)
+	}
+	if settings.AndKey != "ニュース" || settings.NotKey != "再放送" || settings.TitleOnlyFlag != 1 || settings.RegExpFlag != 1 {
+		t.Errorf("Unexpected keywords: %+v", settings)
+	}
+	if settings.FreeCAFlag != 1 || settings.ChkDurationMin != 30 {
+		t.Errorf("Unexpected filters: %+v", settings)
+	}
+	if len(settings.DateList) != 1 || settings.DateList[0].StartHour != 21 || settings.DateList[0].EndMin != 30 {
+		t.Errorf("Unexpected date list: %+v", settings.DateList)
+	}
+	if len(settings.ServiceList) != 2 || settings.ServiceList[1].String() != "4-16400-151" {
+		t.Errorf("Unexpected service list: %+v", settings.ServiceList)
+	}
+
+	// The rule created from the settings is the same request
+	rule := &models.AutoAddRule{SearchSettings: *settings}
+	if again := models.NewAutoAddRuleRequestFromRule(rule); again.DateList != req.DateList || strings.Join(again.ServiceList, ",") != strings.Join(req.ServiceList, ",") {
+		t.Errorf("Round trip changed the request: %+v", again)
+	}
+
+	req.DateList = "月曜"
+	if _, err := req.SearchSettings(); err == nil {
+		t.Error("Expected error for an invalid date list")
+	}
+}
+
+func mustParseDateList(t *testing.T, s string) []models.DateInfo {
+	t.Helper()
+	dates, err := models.ParseDateList(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dates
+}
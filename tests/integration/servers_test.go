@@ -0,0 +1,161 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/epy0n0ff/epgtimer-cli/internal/client"
+	"github.com/epy0n0ff/epgtimer-cli/internal/formatters"
+	"github.com/epy0n0ff/epgtimer-cli/internal/models"
+	"github.com/epy0n0ff/epgtimer-cli/tests/testdata"
+)
+
+// mergedRules reads the rules of two mock servers and tags them with their server, like list does
+func mergedRules(t *testing.T) []models.AutoAddRule {
+	t.Helper()
+
+	var merged []models.AutoAddRule
+	for _, server := range []string{"living-room:5510", "parents:5510"} {
+		mock := testdata.NewMockEMWUIServer()
+		defer mock.Close()
+
+		response, err := client.NewClient(mock.URL()).EnumAutoAdd(context.Background())
+		if err != nil {
+			t.Fatalf("EnumAutoAdd() failed: %v", err)
+		}
+		for _, rule := range response.Items {
+			rule.Server = server
+			merged = append(merged, rule)
+		}
+	}
+	return merged
+}
+
+// TestServerColumn_Table tests that merged rules get a leading Server column
+func TestServerColumn_Table(t *testing.T) {
+	output, err := (&formatters.TableFormatter{}).Format(mergedRules(t))
+	if err != nil {
+		t.Fatalf("Format() failed: %v", err)
+	}
+
+	lines := strings.Split(output, "\n")
+	if !strings.HasPrefix(lines[0], "Server") {
+		t.Errorf("Expected header to start with Server, got: %s", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "living-room:5510  1 ") {
+		t.Errorf("Expected first row to start with the server, got: %s", lines[1])
+	}
+	if !strings.Contains(output, "parents:5510") {
+		t.Error("Expected rows of the second server")
+	}
+}
+
+// TestServerColumn_CSV tests the Server column of CSV and TSV output
+func TestServerColumn_CSV(t *testing.T) {
+	rules := mergedRules(t)
+
+	csvOutput, err := (&formatters.CSVFormatter{}).Format(rules)
+	if err != nil {
+		t.Fatalf("CSV Format() failed: %v", err)
+	}
+	if !strings.HasPrefix(csvOutput, "Server,ID,") || !strings.Contains(csvOutput, "\nparents:5510,1,") {
+		t.Errorf("Unexpected CSV output:\n%s", csvOutput)
+	}
+
+	tsvOutput, err := (&formatters.TSVFormatter{}).Format(rules)
+	if err != nil {
+		t.Fatalf("TSV Format() failed: %v", err)
+	}
+	if !strings.HasPrefix(tsvOutput, "Server\tID\t") {
+		t.Errorf("Unexpected TSV output:\n%s", tsvOutput)
+	}
+}
+
+// TestServerColumn_JSON tests the server field of JSON output
+func TestServerColumn_JSON(t *testing.T) {
+	output, err := (&formatters.JSONFormatter{}).Format(mergedRules(t))
+	if err != nil {
+		t.Fatalf("Format() failed: %v", err)
+	}
+
+	var parsed []models.AutoAddRule
+	if err := json.Unmarshal([]byte(output), &parsed); err != nil {
+		t.Fatalf("Output is not valid JSON: %v", err)
+	}
+	if parsed[0].Server != "living-room:5510" || parsed[len(parsed)-1].Server != "parents:5510" {
+		t.Errorf("Expected servers living-room:5510 and parents:5510, got %s and %s", parsed[0].Server, parsed[len(parsed)-1].Server)
+	}
+}
+
+// TestServerColumn_SingleServer tests that output of a single server is unchanged
+func TestServerColumn_SingleServer(t *testing.T) {
+	reservations := []models.ReservationInfo{
+		{ID: 1001, Title: "ニュース", StartDate: "2025/12/22", StartTime: "19:00:00", StationName: "NHK総合"},
+	}
+
+	output, err := (&formatters.ReservationsTableFormatter{}).Format(reservations)
+	if err != nil {
+		t.Fatalf("Format() failed: %v", err)
+	}
+	if strings.Contains(output, "Server") {
+		t.Errorf("Expected no Server column for a single server, got:\n%s", output)
+	}
+
+	jsonOutput, err := (&formatters.JSONFormatter{}).Format([]models.AutoAddRule{{ID: 1}})
+	if err != nil {
+		t.Fatalf("Format() failed: %v", err)
+	}
+	if strings.Contains(jsonOutput, "server") {
+		t.Errorf("Expected no server field for a single server, got:\n%s", jsonOutput)
+	}
+
+	reservations[0].Server = "parents:5510"
+	output, err = (&formatters.ReservationsTableFormatter{}).Format(reservations)
+	if err != nil {
+		t.Fatalf("Format() failed: %v", err)
+	}
+	if !strings.Contains(output, "parents:5510 1001") {
+		t.Errorf("Expected Server column, got:\n%s", output)
+	}
+}
+
+// TestChannelFilter_PerServer tests that --channel is resolved against the channel list of
+// each server, since the same remote control key maps to different channels in other regions
+func TestChannelFilter_PerServer(t *testing.T) {
+	tokyo := testdata.NewMockEMWUIServer()
+	defer tokyo.Close()
+
+	// Key 1 is NHK総合・大阪 on the second server; its reservations are still on NHK総合・東京
+	osaka := testdata.NewMockEMWUIServer()
+	defer osaka.Close()
+	osaka.SetEnumServiceHandler(func() (string, int) {
+		return `<?xml version="1.0" encoding="UTF-8" ?>
+<entry><total>1</total><index>0</index><count>1</count><items>
+<serviceinfo><ONID>32080</ONID><TSID>32080</TSID><SID>1024</SID><service_type>1</service_type>
+<service_name>NHK総合・大阪</service_name><network_name>地上デジタル</network_name>
+<remote_control_key_id>1</remote_control_key_id></serviceinfo>
+</items></entry>`, http.StatusOK
+	})
+
+	out, err := runCLI(t, tokyo, "", "reservations", "--channel", "key:1", "--format", "json",
+		"--endpoint", tokyo.URL(), "--endpoint", osaka.URL())
+	if err != nil {
+		t.Fatalf("reservations failed: %v\n%s", err, out)
+	}
+
+	var reservations []map[string]any
+	if err := json.Unmarshal([]byte(out), &reservations); err != nil {
+		t.Fatalf("Invalid JSON output: %v\n%s", err, out)
+	}
+	if len(reservations) != 2 {
+		t.Fatalf("Expected the 2 reservations of the first server, got %d:\n%s", len(reservations), out)
+	}
+	for _, res := range reservations {
+		if res["server"] == strings.TrimPrefix(osaka.URL(), "http://") {
+			t.Errorf("Expected no reservations of the second server, got %v", res)
+		}
+	}
+}
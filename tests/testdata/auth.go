@@ -0,0 +1,79 @@
+package testdata
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Digest challenge parameters of the mock server
+const (
+	mockRealm  = "EMWUI"
+	mockNonce  = "dcd98b7102dd2f0e8b11d0f600bfb0c093"
+	mockOpaque = "5ccc069c403ebaf9f0171e9517f40e41"
+)
+
+// RequireAuth makes the mock server require HTTP authentication
+// scheme is "basic" or "digest" (MD5, qop=auth).
+func (m *MockEMWUIServer) RequireAuth(scheme, user, password string) {
+	m.AuthScheme = scheme
+	m.AuthUser = user
+	m.AuthPassword = password
+}
+
+// authorize checks the request's credentials and writes a 401 challenge if they are missing or wrong
+func (m *MockEMWUIServer) authorize(w http.ResponseWriter, r *http.Request) bool {
+	switch m.AuthScheme {
+	case "":
+		return true
+
+	case "basic":
+		user, password, ok := r.BasicAuth()
+		if ok && user == m.AuthUser && password == m.AuthPassword {
+			return true
+		}
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Basic realm="%s"`, mockRealm))
+
+	case "digest":
+		if m.checkDigest(r) {
+			return true
+		}
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Digest realm="%s", qop="auth", nonce="%s", opaque="%s", algorithm=MD5`,
+			mockRealm, mockNonce, mockOpaque))
+	}
+
+	http.Error(w, "Unauthorized", http.StatusUnauthorized)
+	return false
+}
+
+// checkDigest verifies a Digest Authorization header (RFC 2617, qop=auth)
+func (m *MockEMWUIServer) checkDigest(r *http.Request) bool {
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, "Digest ") {
+		return false
+	}
+
+	params := make(map[string]string)
+	for _, part := range strings.Split(strings.TrimPrefix(header, "Digest "), ", ") {
+		key, value, _ := strings.Cut(part, "=")
+		params[key] = strings.Trim(value, `"`)
+	}
+
+	if params["username"] != m.AuthUser || params["nonce"] != mockNonce ||
+		params["opaque"] != mockOpaque || params["uri"] != r.URL.RequestURI() || params["qop"] != "auth" {
+		return false
+	}
+
+	ha1 := md5Hex(m.AuthUser + ":" + mockRealm + ":" + m.AuthPassword)
+	ha2 := md5Hex(r.Method + ":" + params["uri"])
+	expected := md5Hex(ha1 + ":" + mockNonce + ":" + params["nc"] + ":" + params["cnonce"] + ":auth:" + ha2)
+
+	return params["response"] == expected
+}
+
+func md5Hex(s string) string {
+	sum := md5.Sum([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
@@ -15,29 +15,59 @@ type MockEMWUIServer struct {
 	// Callbacks for custom behavior
 	OnSetAutoAdd      func(values map[string][]string) (success bool, message string)
 	OnDeleteAutoAdd   func(id int) (success bool, message string)
+	OnUpdateAutoAdd   func(id int, values map[string][]string) (success bool, message string)
+	OnAddReserve      func(values map[string][]string) (success bool, message string)
+	OnUpdateReserve   func(id int, values map[string][]string) (success bool, message string)
+	OnDeleteReserve   func(id int) (success bool, message string)
+	OnProtectRecInfo  func(id int, protect bool) (success bool, message string)
+	OnDeleteRecInfo   func(id int) (success bool, message string)
+	OnSetManuAdd      func(values map[string][]string) (success bool, message string)
+	OnDeleteManuAdd   func(id int) (success bool, message string)
 	OnEnumAutoAdd     func() (xmlResponse string, statusCode int)
 	OnEnumService     func() (xmlResponse string, statusCode int)
 	OnEnumReserveInfo func() (xmlResponse string, statusCode int)
 	OnEnumRecInfo     func() (xmlResponse string, statusCode int)
 	OnEnumEventInfo   func() (xmlResponse string, statusCode int)
+	OnEnumManuAdd     func() (xmlResponse string, statusCode int)
+	OnEnumRecPreset   func() (xmlResponse string, statusCode int)
 	// CSRF token to return in HTML page
 	CToken string
+	// Required authentication (see RequireAuth)
+	AuthScheme   string
+	AuthUser     string
+	AuthPassword string
 	// Response mode flags
 	EnumAutoAddEmpty     bool // If true, return empty response
 	EnumServiceEmpty     bool
 	EnumReserveInfoEmpty bool
 	EnumRecInfoEmpty     bool
 	EnumEventInfoEmpty   bool
+	EnumManuAddEmpty     bool
+	EnumRecPresetEmpty   bool
 }
 
 // NewMockEMWUIServer creates a new mock EMWUI server
 func NewMockEMWUIServer() *MockEMWUIServer {
+	return newMockEMWUIServer(httptest.NewServer)
+}
+
+// NewMockEMWUIServerTLS creates a new mock EMWUI server serving https with a self-signed certificate
+func NewMockEMWUIServerTLS() *MockEMWUIServer {
+	return newMockEMWUIServer(httptest.NewTLSServer)
+}
+
+func newMockEMWUIServer(start func(http.Handler) *httptest.Server) *MockEMWUIServer {
 	mock := &MockEMWUIServer{
 		CToken: "test-csrf-token-12345", // Default test token
 	}
 
 	// Default handler
-	mock.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	mock.Server = start(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Check credentials when authentication is required
+		if !mock.authorize(w, r) {
+			return
+		}
+
 		// Handle HTML page request for ctok
 		if r.URL.Path == "/EMWUI/autoaddepg.html" {
 			w.Header().Set("Content-Type", "text/html; charset=utf-8")
@@ -195,6 +225,80 @@ func NewMockEMWUIServer() *MockEMWUIServer {
 			return
 		}
 
+		// Handle EnumManuAdd endpoint (GET /api/EnumManuAdd)
+		if r.URL.Path == "/api/EnumManuAdd" {
+			if r.Method != http.MethodGet {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+
+			// Call custom handler if set
+			if mock.OnEnumManuAdd != nil {
+				xmlResp, statusCode := mock.OnEnumManuAdd()
+				w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+				w.WriteHeader(statusCode)
+				fmt.Fprint(w, xmlResp)
+				return
+			}
+
+			// Default behavior: load from fixtures
+			var filename string
+			if mock.EnumManuAddEmpty {
+				filename = "enummanuadd_empty.xml"
+			} else {
+				filename = "enummanuadd_success.xml"
+			}
+
+			mock.serveFixture(w, filename)
+			return
+		}
+
+		// Handle EnumRecPreset endpoint (GET /api/EnumRecPreset)
+		if r.URL.Path == "/api/EnumRecPreset" {
+			if r.Method != http.MethodGet {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+
+			// Call custom handler if set
+			if mock.OnEnumRecPreset != nil {
+				xmlResp, statusCode := mock.OnEnumRecPreset()
+				w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+				w.WriteHeader(statusCode)
+				fmt.Fprint(w, xmlResp)
+				return
+			}
+
+			// Default behavior: load from fixtures
+			var filename string
+			if mock.EnumRecPresetEmpty {
+				filename = "enumrecpreset_empty.xml"
+			} else {
+				filename = "enumrecpreset_success.xml"
+			}
+
+			mock.serveFixture(w, filename)
+			return
+		}
+
+		// Handle SetReserve endpoint (POST /api/SetReserve?id=N)
+		if r.URL.Path == "/api/SetReserve" {
+			mock.handleSetReserve(w, r)
+			return
+		}
+
+		// Handle SetRecInfo endpoint (POST /api/SetRecInfo?id=N)
+		if r.URL.Path == "/api/SetRecInfo" {
+			mock.handleSetRecInfo(w, r)
+			return
+		}
+
+		// Handle SetManuAdd endpoint (POST /api/SetManuAdd?id=N)
+		if r.URL.Path == "/api/SetManuAdd" {
+			mock.handleSetManuAdd(w, r)
+			return
+		}
+
 		// Only handle SetAutoAdd endpoint
 		if !strings.HasPrefix(r.URL.Path, "/api/SetAutoAdd") {
 			http.Error(w, "Not found", http.StatusNotFound)
@@ -222,17 +326,17 @@ func NewMockEMWUIServer() *MockEMWUIServer {
 		// Check if this is a delete request (del=1)
 		isDel := len(values["del"]) > 0 && values["del"][0] == "1"
 
+		// Extract ID from query parameter
+		id := 0
+		if idStr := r.URL.Query().Get("id"); idStr != "" {
+			fmt.Sscanf(idStr, "%d", &id)
+		}
+
 		// Call custom handler if set
 		var success bool
 		var message string
 		if isDel {
 			// Handle delete request
-			// Extract ID from query parameter
-			id := 0
-			if idStr := r.URL.Query().Get("id"); idStr != "" {
-				fmt.Sscanf(idStr, "%d", &id)
-			}
-
 			if mock.OnDeleteAutoAdd != nil {
 				success, message = mock.OnDeleteAutoAdd(id)
 			} else {
@@ -245,6 +349,9 @@ func NewMockEMWUIServer() *MockEMWUIServer {
 					message = "Invalid rule ID"
 				}
 			}
+		} else if id > 0 && mock.OnUpdateAutoAdd != nil {
+			// Handle update request for an existing rule
+			success, message = mock.OnUpdateAutoAdd(id, values)
 		} else {
 			// Handle add/update request
 			if mock.OnSetAutoAdd != nil {
@@ -293,6 +400,192 @@ func (m *MockEMWUIServer) SetDeleteAutoAddHandler(handler func(id int) (success
 	m.OnDeleteAutoAdd = handler
 }
 
+// SetUpdateAutoAddHandler sets a custom handler for update requests (SetAutoAdd with id > 0)
+func (m *MockEMWUIServer) SetUpdateAutoAddHandler(handler func(id int, values map[string][]string) (success bool, message string)) {
+	m.OnUpdateAutoAdd = handler
+}
+
+// SetAddReserveHandler sets a custom handler for new reservations (SetReserve with id=0)
+func (m *MockEMWUIServer) SetAddReserveHandler(handler func(values map[string][]string) (success bool, message string)) {
+	m.OnAddReserve = handler
+}
+
+// SetUpdateReserveHandler sets a custom handler for reservation updates (SetReserve with id > 0)
+func (m *MockEMWUIServer) SetUpdateReserveHandler(handler func(id int, values map[string][]string) (success bool, message string)) {
+	m.OnUpdateReserve = handler
+}
+
+// SetDeleteReserveHandler sets a custom handler for reservation deletes (SetReserve with del=1)
+func (m *MockEMWUIServer) SetDeleteReserveHandler(handler func(id int) (success bool, message string)) {
+	m.OnDeleteReserve = handler
+}
+
+// handleSetReserve simulates the SetReserve API
+func (m *MockEMWUIServer) handleSetReserve(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Bad request", http.StatusBadRequest)
+		return
+	}
+
+	values := make(map[string][]string)
+	for key, vals := range r.PostForm {
+		values[key] = vals
+	}
+
+	id := 0
+	if idStr := r.URL.Query().Get("id"); idStr != "" {
+		fmt.Sscanf(idStr, "%d", &id)
+	}
+	isDel := len(values["del"]) > 0 && values["del"][0] == "1"
+
+	var success bool
+	var message string
+	if id > 0 {
+		// Existing reservation: delete or update
+		if isDel && m.OnDeleteReserve != nil {
+			success, message = m.OnDeleteReserve(id)
+		} else if !isDel && m.OnUpdateReserve != nil {
+			success, message = m.OnUpdateReserve(id, values)
+		} else {
+			success, message = true, "予約を変更しました"
+		}
+	} else if m.OnAddReserve != nil {
+		success, message = m.OnAddReserve(values)
+	} else {
+		// Default: success if the event and ctok are present
+		success = len(values["eid"]) > 0 && values["eid"][0] != "" &&
+			len(values["ctok"]) > 0 && values["ctok"][0] == m.CToken
+		if success {
+			message = "予約を追加しました"
+		} else {
+			message = "Missing required parameters"
+		}
+	}
+
+	writeSetResponse(w, success, message)
+}
+
+// SetProtectRecInfoHandler sets a custom handler for recording protect changes (SetRecInfo with protect=0/1)
+func (m *MockEMWUIServer) SetProtectRecInfoHandler(handler func(id int, protect bool) (success bool, message string)) {
+	m.OnProtectRecInfo = handler
+}
+
+// SetDeleteRecInfoHandler sets a custom handler for recording deletes (SetRecInfo with del=1)
+func (m *MockEMWUIServer) SetDeleteRecInfoHandler(handler func(id int) (success bool, message string)) {
+	m.OnDeleteRecInfo = handler
+}
+
+// handleSetRecInfo simulates the SetRecInfo API
+func (m *MockEMWUIServer) handleSetRecInfo(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Bad request", http.StatusBadRequest)
+		return
+	}
+
+	id := 0
+	if idStr := r.URL.Query().Get("id"); idStr != "" {
+		fmt.Sscanf(idStr, "%d", &id)
+	}
+
+	if id <= 0 || r.PostForm.Get("ctok") != m.CToken {
+		writeSetResponse(w, false, "Missing required parameters")
+		return
+	}
+
+	success, message := true, "録画情報を変更しました"
+	if r.PostForm.Get("del") == "1" {
+		if m.OnDeleteRecInfo != nil {
+			success, message = m.OnDeleteRecInfo(id)
+		} else {
+			message = "録画情報を削除しました"
+		}
+	} else if protect := r.PostForm.Get("protect"); protect != "" && m.OnProtectRecInfo != nil {
+		success, message = m.OnProtectRecInfo(id, protect == "1")
+	}
+
+	writeSetResponse(w, success, message)
+}
+
+// SetManuAddHandler sets a custom handler for new program-based rules (SetManuAdd with id=0)
+func (m *MockEMWUIServer) SetManuAddHandler(handler func(values map[string][]string) (success bool, message string)) {
+	m.OnSetManuAdd = handler
+}
+
+// SetDeleteManuAddHandler sets a custom handler for program-based rule deletes (SetManuAdd with del=1)
+func (m *MockEMWUIServer) SetDeleteManuAddHandler(handler func(id int) (success bool, message string)) {
+	m.OnDeleteManuAdd = handler
+}
+
+// handleSetManuAdd simulates the SetManuAdd API
+func (m *MockEMWUIServer) handleSetManuAdd(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Bad request", http.StatusBadRequest)
+		return
+	}
+
+	values := make(map[string][]string)
+	for key, vals := range r.PostForm {
+		values[key] = vals
+	}
+
+	id := 0
+	if idStr := r.URL.Query().Get("id"); idStr != "" {
+		fmt.Sscanf(idStr, "%d", &id)
+	}
+
+	if r.PostForm.Get("ctok") != m.CToken {
+		writeSetResponse(w, false, "Missing required parameters")
+		return
+	}
+
+	var success bool
+	var message string
+	if r.PostForm.Get("del") == "1" {
+		if m.OnDeleteManuAdd != nil {
+			success, message = m.OnDeleteManuAdd(id)
+		} else {
+			success, message = id > 0, "プログラム予約を削除しました"
+		}
+	} else if m.OnSetManuAdd != nil {
+		success, message = m.OnSetManuAdd(values)
+	} else {
+		// Default: success if the channel and start time are present
+		success = r.PostForm.Get("serviceID") != "" && r.PostForm.Get("startH") != ""
+		if success {
+			message = "プログラム予約を追加しました"
+		} else {
+			message = "Missing required parameters"
+		}
+	}
+
+	writeSetResponse(w, success, message)
+}
+
+// writeSetResponse writes an EMWUI-style <entry><success>/<err> response
+func writeSetResponse(w http.ResponseWriter, success bool, message string) {
+	w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+	if success {
+		fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8" ?><entry><success>%s</success></entry>`, message)
+	} else {
+		fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8" ?><entry><err>%s</err></entry>`, message)
+	}
+}
+
 // NewFailingServer creates a mock server that always returns errors
 func NewFailingServer() *MockEMWUIServer {
 	mock := NewMockEMWUIServer()
@@ -398,3 +691,27 @@ func NewEmptyEnumEventInfoServer() *MockEMWUIServer {
 	mock.EnumEventInfoEmpty = true
 	return mock
 }
+
+// SetEnumManuAddHandler sets a custom handler for EnumManuAdd requests
+func (m *MockEMWUIServer) SetEnumManuAddHandler(handler func() (xmlResponse string, statusCode int)) {
+	m.OnEnumManuAdd = handler
+}
+
+// NewEmptyEnumManuAddServer creates a mock server that returns empty EnumManuAdd response
+func NewEmptyEnumManuAddServer() *MockEMWUIServer {
+	mock := NewMockEMWUIServer()
+	mock.EnumManuAddEmpty = true
+	return mock
+}
+
+// SetEnumRecPresetHandler sets a custom handler for EnumRecPreset requests
+func (m *MockEMWUIServer) SetEnumRecPresetHandler(handler func() (xmlResponse string, statusCode int)) {
+	m.OnEnumRecPreset = handler
+}
+
+// NewEmptyEnumRecPresetServer creates a mock server that returns empty EnumRecPreset response
+func NewEmptyEnumRecPresetServer() *MockEMWUIServer {
+	mock := NewMockEMWUIServer()
+	mock.EnumRecPresetEmpty = true
+	return mock
+}